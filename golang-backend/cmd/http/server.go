@@ -7,15 +7,19 @@ import (
 	"cardgame/internal/api/routes"
 	"cardgame/internal/infra"
 	"cardgame/internal/infra/environment"
+	bootstrap "cardgame/internal/infra/temporal"
 	"cardgame/internal/infra/ws"
 	"cardgame/internal/services"
+	"context"
 	"io"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"go.temporal.io/sdk/client"
 )
 
 func main() {
@@ -71,14 +75,35 @@ func main() {
 	refreshTokenSqlRepository := infra.NewSQLRefreshTokenRepository(db)
 	gameRedisRepository := infra.NewRedisGameRepository(redis)
 	eventRepository := infra.NewRedisEventRepository(redis)
+	timerRepository := infra.NewRedisTimerRepository(redis)
 
-	games, err := gameRedisRepository.GetAllGames()
+	// Round deadlines run on Temporal when it is configured, otherwise they
+	// are polled from Redis
+	var deadlineScheduler services.DeadlineScheduler = timerRepository
+	var temporalClient client.Client
 
-	if err != nil {
-		log.Fatalf("failed to get all games: %v", err)
+	if env.TemporalHostPort != "" {
+		temporalArgs := bootstrap.NewTemporalInstanceArgs(env.TemporalHostPort, env.TemporalNamespace, env.TemporalClientCertPath, env.TemporalClientKeyPath)
+		temporalClient, err = bootstrap.NewTemporalInstance(temporalArgs)
+
+		if err != nil {
+			log.Fatalf("failed to create Temporal client: %v", err)
+		}
+
+		defer temporalClient.Close()
+
+		deadlineScheduler = bootstrap.NewTemporalRoundScheduler(temporalClient, env.TemporalTaskQueue)
 	}
 
 	// Services
+	gameRehydrator := services.NewGameRehydrator(gameRedisRepository, eventRepository)
+
+	games, err := gameRehydrator.RehydrateAll()
+
+	if err != nil {
+		log.Fatalf("failed to rehydrate games: %v", err)
+	}
+
 	deckCreationService := services.NewChatGPTService(env.ChatGPTAPIKey)
 	jwtService := services.NewJWTAuthService(refreshTokenSqlRepository)
 	authService := services.NewAuthService(userSqlRepository, jwtService)
@@ -88,10 +113,33 @@ func main() {
 		gameRedisRepository,
 		eventRepository,
 		deckCreationService,
+		gameRehydrator,
 		publisher,
+		deadlineScheduler,
+		services.GameRules{
+			MinPlayerCount:        env.MinPlayerCount,
+			AutoStartWhenFull:     env.AutoStartWhenFull,
+			PickingTimeout:        time.Duration(env.PickingTimeoutSeconds) * time.Second,
+			JudgingTimeout:        time.Duration(env.JudgingTimeoutSeconds) * time.Second,
+			RoundPauseTimeout:     time.Duration(env.RoundPauseSeconds) * time.Second,
+			DisconnectGracePeriod: time.Duration(env.DisconnectGraceSeconds) * time.Second,
+		},
 		games,
 	)
 
+	if temporalClient != nil {
+		roundWorker := bootstrap.NewRoundWorker(temporalClient, env.TemporalTaskQueue, gameCoordinator)
+
+		if err := roundWorker.Start(); err != nil {
+			log.Fatalf("failed to start Temporal round worker: %v", err)
+		}
+
+		defer roundWorker.Stop()
+	} else {
+		gameTimerPoller := services.NewGameTimerPoller(timerRepository, gameCoordinator, 250*time.Millisecond)
+		go gameTimerPoller.Run(context.Background())
+	}
+
 	// Configs
 	googleConfig := config.NewGoogleOAuthConfig(env.GoogleOAuthRedirectURI, env.GoogleOAuthClientID, env.GoogleOAuthClientSecret)
 	discordConfig := config.NewDiscordOAuthConfig(env.DiscordOAuthRedirectURI, env.DiscordOAuthClientID, env.DiscordOAuthClientSecret)
@@ -100,6 +148,7 @@ func main() {
 	googleAuthHandler := handlers.NewGoogleAuthHandler(authService)
 	discordAuthHandler := handlers.NewDiscordAuthHandler(authService)
 	sharedAuthHandler := handlers.NewSharedAuthHandler(authService)
+	gameSocketHandler := handlers.NewGameSocketHandler(gameCoordinator, publisher)
 
 	// Controller Container
 	controllerContainer := controllers.NewControllerContainer(
@@ -109,6 +158,7 @@ func main() {
 		googleAuthHandler,
 		discordAuthHandler,
 		sharedAuthHandler,
+		gameSocketHandler,
 		gameCoordinator,
 		gameRedisRepository,
 		hub,
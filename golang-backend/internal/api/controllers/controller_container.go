@@ -23,6 +23,7 @@ func NewControllerContainer(
 	googleAuthHandler *handlers.GoogleAuthHandler,
 	discordAuthHandler *handlers.DiscordAuthHandler,
 	sharedAuthHandler *handlers.SharedAuthHandler,
+	gameSocketHandler *handlers.GameSocketHandler,
 	gameCoordinator *services.GameCoordinator,
 	gameRepository repositories.GameRepository,
 	hub *ws.Hub,
@@ -39,6 +40,7 @@ func NewControllerContainer(
 	gameController := NewGameController(
 		env,
 		gameCoordinator,
+		gameSocketHandler,
 		gameRepository,
 		hub,
 	)
@@ -1,15 +1,17 @@
 package controllers
 
 import (
+	"cardgame/internal/api/handlers"
 	"cardgame/internal/api/request"
 	"cardgame/internal/api/validation"
-	"cardgame/internal/domain/aggregates"
 	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/projections"
 	"cardgame/internal/domain/repositories"
+	domainvalidation "cardgame/internal/domain/validation"
 	"cardgame/internal/infra/environment"
 	"cardgame/internal/infra/ws"
 	"cardgame/internal/services"
-	"encoding/json"
+	"errors"
 	"log"
 
 	"github.com/gofiber/fiber/v2"
@@ -17,23 +19,26 @@ import (
 )
 
 type GameController struct {
-	Env             *environment.Env
-	gameCoordinator *services.GameCoordinator
-	gameRepository  repositories.GameRepository
-	hub             *ws.Hub
+	Env               *environment.Env
+	gameCoordinator   *services.GameCoordinator
+	gameSocketHandler *handlers.GameSocketHandler
+	gameRepository    repositories.GameRepository
+	hub               *ws.Hub
 }
 
 func NewGameController(
 	env *environment.Env,
 	gameCoordinator *services.GameCoordinator,
+	gameSocketHandler *handlers.GameSocketHandler,
 	gameRepository repositories.GameRepository,
 	hub *ws.Hub,
 ) *GameController {
 	return &GameController{
-		Env:             env,
-		gameCoordinator: gameCoordinator,
-		gameRepository:  gameRepository,
-		hub:             hub,
+		Env:               env,
+		gameCoordinator:   gameCoordinator,
+		gameSocketHandler: gameSocketHandler,
+		gameRepository:    gameRepository,
+		hub:               hub,
 	}
 }
 
@@ -44,15 +49,31 @@ func (gc *GameController) HandleJoinWebsocketGameRoom(connection *websocket.Conn
 	client := &ws.Client{
 		Conn:   connection,
 		RoomID: gameId,
+		UserID: claim.UserID,
 		Send:   make(chan []byte, 64),
 	}
 
+	// Nothing of the game is sent before the user is known to be allowed in
+	if !gc.gameSocketHandler.Admit(client, claim) {
+		return
+	}
+
 	gc.hub.Join(gameId, client)
-	gc.gameCoordinator.Join(gameId, claim)
+	gc.gameSocketHandler.Connect(client, claim)
 
 	defer func() {
 		gc.hub.Leave(client)
-		gc.gameCoordinator.Leave(gameId, claim)
+
+		// Another tab may still have the game open
+		for _, other := range gc.hub.Clients(gameId) {
+			if other != client && other.UserID == claim.UserID {
+				return
+			}
+		}
+
+		if err := gc.gameCoordinator.Disconnect(gameId, claim); err != nil {
+			log.Printf("Error disconnecting user %s from game %s: %v", claim.UserID, gameId, err)
+		}
 	}()
 
 	// Incoming message pump
@@ -65,72 +86,73 @@ func (gc *GameController) HandleJoinWebsocketGameRoom(connection *websocket.Conn
 			return
 		}
 
-		var message request.GameEventRequest
-		if err := json.Unmarshal(msg, &message); err != nil {
-			log.Printf("Error unmarshaling WebSocket message: %v", err)
-			continue // Continue processing other messages
-		}
-
-		switch message.Type {
-		case aggregates.EventGameBegins:
-			var payload request.GameEventPayloadGameBeginsRequest
-			if err := json.Unmarshal(message.Payload, &payload); err != nil {
-				log.Printf("Error unmarshaling GameBegins payload: %v", err)
-				continue
-			}
-			gc.gameCoordinator.BeginGame(payload.GameID, claim)
+		gc.gameSocketHandler.Handle(client, claim, msg)
+	}
+}
 
-		case aggregates.EventCardPlayed:
-			var payload request.GameEventPayloadJoinedGameRequest
-			if err := json.Unmarshal(message.Payload, &payload); err != nil {
-				log.Printf("Error unmarshaling CardPlayed payload: %v", err)
-				continue
-			}
-			// gc.gameCoordinator.PlayCard(payload.GameID, claim, payload.CardID)
+func (gc *GameController) HandleGetGames(c *fiber.Ctx) error {
+	games, err := gc.gameRepository.GetAllGames()
 
-		case aggregates.EventJudgeChoseWinningCard:
-			var payload request.GameEventPayloadJudgeChoseWinningCardRequest
-			if err := json.Unmarshal(message.Payload, &payload); err != nil {
-				log.Printf("Error unmarshaling JudgeChoseWinningCard payload: %v", err)
-				continue
-			}
-			// gc.gameCoordinator.PickWinningCard(payload.GameID, claim, payload.CardID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(err)
+	}
 
-		case aggregates.EventRoundContinued:
-			var payload request.GameEventPayloadGameRoundContinuedRequest
-			if err := json.Unmarshal(message.Payload, &payload); err != nil {
-				log.Printf("Error unmarshaling RoundContinued payload: %v", err)
-				continue
-			}
-			// gc.gameCoordinator.ContinueRound(payload.GameID, claim)
+	claim := c.Locals("user").(*entities.CustomClaim)
 
-		case aggregates.EventEmojiClicked:
-			var payload request.GameEventPayloadEmojiClickedRequest
-			if err := json.Unmarshal(message.Payload, &payload); err != nil {
-				log.Printf("Error unmarshaling EmojiClicked payload: %v", err)
-				continue
-			}
-			// gc.gameCoordinator.ClickEmoji(payload.GameID, claim, payload.Emoji)
+	views := make([]*projections.GameView, 0, len(games))
 
-		default:
-			log.Printf("Unknown WebSocket message type: %s", message.Type)
+	for _, game := range games {
+		// Private games are only listed for those who can get in
+		if !game.IsVisibleTo(claim.UserID) {
 			continue
 		}
+
+		views = append(views, projections.NewGameView(game, claim.UserID))
 	}
+
+	return c.Status(fiber.StatusOK).JSON(views)
 }
 
-func (gc *GameController) HandleGetGames(c *fiber.Ctx) error {
-	games, err := gc.gameRepository.GetAllGames()
+func (gc *GameController) CreateGame(c *fiber.Ctx) error {
+	var request request.CreateGameRequest
+
+	if err := validation.BindAndValidate(c, &request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	claim := c.Locals("user").(*entities.CustomClaim)
+
+	game, err := gc.gameCoordinator.Create(request.Name, request.Subject, request.WinnerCount, request.MaxPlayerCount, request.HandSize, request.Mode, request.TieRule, request.HouseRules, request.Visibility, request.Password, claim)
+
+	var validationError *domainvalidation.ValidationError
+
+	if errors.As(err, &validationError) {
+		return c.Status(fiber.StatusBadRequest).JSON(validation.NewAPIError(fiber.StatusBadRequest, validationError.Message, map[string]string{
+			validationError.Field: validationError.Message,
+		}))
+	}
 
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(games)
+	return c.Status(fiber.StatusCreated).JSON(game)
 }
 
-func (gc *GameController) CreateGame(c *fiber.Ctx) error {
-	var request request.CreateGameRequest
+func (gc *GameController) HandleResolveInvite(c *fiber.Ctx) error {
+	claim := c.Locals("user").(*entities.CustomClaim)
+
+	game, err := gc.gameCoordinator.ResolveInvite(c.Params("code"), claim)
+
+	if err != nil {
+		return commandError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(game)
+}
+
+func (gc *GameController) HandleAcceptInvite(c *fiber.Ctx) error {
+	var request request.AcceptInviteRequest
 
 	if err := validation.BindAndValidate(c, &request); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(err)
@@ -138,11 +160,56 @@ func (gc *GameController) CreateGame(c *fiber.Ctx) error {
 
 	claim := c.Locals("user").(*entities.CustomClaim)
 
-	game, err := gc.gameCoordinator.Create(request.Name, request.Subject, request.WinnerCount, request.MaxPlayerCount, claim)
+	game, err := gc.gameCoordinator.AcceptInvite(c.Params("code"), request.Password, claim)
 
 	if err != nil {
+		return commandError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(game)
+}
+
+// HandleChangeSettings changes the settings of a game in setup. The lobby is
+// sent the new settings as with any other change to the game.
+func (gc *GameController) HandleChangeSettings(c *fiber.Ctx) error {
+	var request request.ChangeSettingsRequest
+
+	if err := validation.BindAndValidate(c, &request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	claim := c.Locals("user").(*entities.CustomClaim)
+
+	game, err := gc.gameCoordinator.ChangeSettings(c.Params("id"), claim, services.GameSettingsUpdate(request))
+
+	if err != nil {
+		return commandError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(game)
+}
+
+// commandError replies with the status that matches why a game command, such
+// as using an invite, was refused.
+func commandError(c *fiber.Ctx, err error) error {
+	var validationError *domainvalidation.ValidationError
+
+	if !errors.As(err, &validationError) {
 		return c.Status(fiber.StatusInternalServerError).JSON(err)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(game)
+	status := fiber.StatusBadRequest
+
+	switch validationError.Code {
+	case domainvalidation.ErrCodeInviteNotFound, domainvalidation.ErrCodeGameNotFound:
+		status = fiber.StatusNotFound
+	case domainvalidation.ErrCodeWrongPassword, domainvalidation.ErrCodePlayerBanned, domainvalidation.ErrCodeNotGameOwner, domainvalidation.ErrCodePlayerNotInGame:
+		status = fiber.StatusForbidden
+	case domainvalidation.ErrCodeWrongGameStatus:
+		status = fiber.StatusConflict
+	}
+
+	return c.Status(status).JSON(validation.NewAPIError(status, validationError.Message, map[string]string{
+		validationError.Field: validationError.Message,
+	}))
 }
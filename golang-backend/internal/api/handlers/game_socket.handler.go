@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"cardgame/internal/api/request"
+	"cardgame/internal/api/response"
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/infra/ws"
+	"cardgame/internal/services"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// GameSocketHandlerFunc handles one type of inbound websocket message for a
+// player connected to the given game.
+type GameSocketHandlerFunc func(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error
+
+// socketPublisher is the part of ws.Publisher the handler replies through.
+type socketPublisher interface {
+	PublishToClient(c *ws.Client, eventType string, payload any) error
+	WriteToClient(c *ws.Client, eventType string, payload any) error
+	DisconnectUser(roomID string, userID string, eventType string, payload any) error
+}
+
+// GameSocketHandler routes inbound websocket messages to the game coordinator
+// by message type and replies to the sender with an ack or an error.
+type GameSocketHandler struct {
+	gameCoordinator *services.GameCoordinator
+	publisher       socketPublisher
+	handlers        map[aggregates.GameEventType]GameSocketHandlerFunc
+}
+
+func NewGameSocketHandler(gameCoordinator *services.GameCoordinator, publisher *ws.Publisher) *GameSocketHandler {
+	h := &GameSocketHandler{
+		gameCoordinator: gameCoordinator,
+		publisher:       publisher,
+		handlers:        map[aggregates.GameEventType]GameSocketHandlerFunc{},
+	}
+
+	h.Register(aggregates.EventJoinedGame, h.handleJoinGame)
+	h.Register(aggregates.EventGameBegins, h.handleBeginGame)
+	h.Register(aggregates.EventCardPlayed, h.handlePlayCard)
+	h.Register(aggregates.EventJudgeChoseWinningCard, h.handlePickWinningCard)
+	h.Register(aggregates.EventVoteCast, h.handleVote)
+	h.Register(aggregates.EventRoundContinued, h.handleContinueRound)
+	h.Register(aggregates.EventEmojiClicked, h.handleEmojiClicked)
+	h.Register(aggregates.EventUniverseRebooted, h.handleRebootUniverse)
+	h.Register(aggregates.EventHandMulliganed, h.handleMulligan)
+	h.Register(aggregates.EventPlayerLeft, h.handleLeaveGame)
+	h.Register(aggregates.EventPlayerKicked, h.handleModeratePlayer(aggregates.EventPlayerKicked, gameCoordinator.Kick))
+	h.Register(aggregates.EventPlayerBanned, h.handleModeratePlayer(aggregates.EventPlayerBanned, gameCoordinator.Ban))
+	h.Register(aggregates.EventPlayerMuted, h.handleModeratePlayer(aggregates.EventPlayerMuted, gameCoordinator.Mute))
+	h.Register(aggregates.EventPlayerUnmuted, h.handleModeratePlayer(aggregates.EventPlayerUnmuted, gameCoordinator.Unmute))
+	h.Register(aggregates.EventSpectatorJoined, h.handleWatchGame)
+	h.Register(aggregates.EventGamePaused, h.handlePauseGame)
+	h.Register(aggregates.EventGameResumed, h.handleResumeGame)
+	h.Register(aggregates.EventRoundForceAdvanced, h.handleForceAdvance)
+	h.Register(aggregates.EventRematchCreated, h.handleRematch)
+	h.Register(aggregates.EventInviteCodeRotated, h.handleRotateInviteCode)
+	h.Register(aggregates.EventSettingsChanged, h.handleChangeSettings)
+	h.Register(aggregates.EventPlayerReady, h.handleReady)
+	h.Register(aggregates.EventPlayerUnready, h.handleUnready)
+	h.Register(aggregates.EventSpectatorPromoted, h.handleModeratePlayer(aggregates.EventSpectatorPromoted, gameCoordinator.PromoteSpectator))
+
+	return h
+}
+
+func (h *GameSocketHandler) Register(messageType aggregates.GameEventType, handler GameSocketHandlerFunc) {
+	h.handlers[messageType] = handler
+}
+
+// Handle decodes a raw message from a client, dispatches it to the handler
+// registered for its type and replies to that client with the outcome. The
+// game id always comes from the connection, never from the message.
+func (h *GameSocketHandler) Handle(client *ws.Client, claim *entities.CustomClaim, msg []byte) {
+	var message request.GameEventRequest
+
+	if err := json.Unmarshal(msg, &message); err != nil {
+		h.replyError(client, message, validation.NewValidationError(validation.ErrCodeInvalidMessage, "message is not valid JSON", ""))
+		return
+	}
+
+	err := h.dispatch(client.RoomID, claim, message)
+
+	if err != nil {
+		log.Printf("Error handling WebSocket %s message: %v", message.Type, err)
+		h.replyError(client, message, toValidationError(err))
+		return
+	}
+
+	h.reply(client, aggregates.CommandAck, response.CommandAckResponse{
+		RequestID: message.RequestID,
+		Type:      message.Type,
+	})
+}
+
+// Admit checks a newly connected client before it joins the game's room, so
+// users who may not see the game are never sent any of it. They are told why
+// and should be disconnected when it returns false.
+func (h *GameSocketHandler) Admit(client *ws.Client, claim *entities.CustomClaim) bool {
+	err := h.gameCoordinator.Admit(client.RoomID, claim)
+
+	if err == nil {
+		return true
+	}
+
+	log.Printf("Turned user %s away from game %s: %v", claim.UserID, client.RoomID, err)
+
+	validationError := toValidationError(err)
+
+	err = h.publisher.WriteToClient(client, string(aggregates.CommandError), response.CommandErrorResponse{
+		Type:  aggregates.EventJoinedGame,
+		Error: *validationError,
+	})
+
+	if err != nil {
+		log.Printf("Error replying to WebSocket client: %v", err)
+		return false
+	}
+
+	if validationError.Code != validation.ErrCodePlayerBanned && validationError.Code != validation.ErrCodeInviteRequired {
+		return false
+	}
+
+	err = h.publisher.WriteToClient(client, string(aggregates.PlayerKickedMessage), kickedPayload(client.RoomID, validationError))
+
+	if err != nil {
+		log.Printf("Error replying to WebSocket client: %v", err)
+	}
+
+	return false
+}
+
+// Connect joins an admitted client to its game, replying with an error when
+// the game turned the user away. Users banned, or whose invite was taken
+// back, since they were admitted are disconnected.
+func (h *GameSocketHandler) Connect(client *ws.Client, claim *entities.CustomClaim) {
+	err := h.gameCoordinator.Join(client.RoomID, claim)
+
+	if err == nil {
+		return
+	}
+
+	log.Printf("Error joining user %s to game %s: %v", claim.UserID, client.RoomID, err)
+
+	validationError := toValidationError(err)
+
+	h.replyError(client, request.GameEventRequest{Type: aggregates.EventJoinedGame}, validationError)
+
+	if validationError.Code != validation.ErrCodePlayerBanned && validationError.Code != validation.ErrCodeInviteRequired {
+		return
+	}
+
+	err = h.publisher.DisconnectUser(client.RoomID, claim.UserID, string(aggregates.PlayerKickedMessage), kickedPayload(client.RoomID, validationError))
+
+	if err != nil {
+		log.Printf("Error disconnecting user %s from game %s: %v", claim.UserID, client.RoomID, err)
+	}
+}
+
+// kickedPayload tells a user turned away from a game whether they were banned.
+func kickedPayload(gameId string, validationError *validation.ValidationError) map[string]interface{} {
+	return map[string]interface{}{
+		"game_id": gameId,
+		"banned":  validationError.Code == validation.ErrCodePlayerBanned,
+		"code":    validationError.Code,
+	}
+}
+
+func (h *GameSocketHandler) dispatch(gameId string, claim *entities.CustomClaim, message request.GameEventRequest) error {
+	handler, ok := h.handlers[message.Type]
+
+	if !ok {
+		return validation.NewValidationError(
+			validation.ErrCodeUnknownMessageType,
+			fmt.Sprintf("unknown message type: %s", message.Type),
+			"type",
+		)
+	}
+
+	return handler(gameId, claim, message.Payload)
+}
+
+func (h *GameSocketHandler) replyError(client *ws.Client, message request.GameEventRequest, validationError *validation.ValidationError) {
+	h.reply(client, aggregates.CommandError, response.CommandErrorResponse{
+		RequestID: message.RequestID,
+		Type:      message.Type,
+		Error:     *validationError,
+	})
+}
+
+func (h *GameSocketHandler) reply(client *ws.Client, replyType aggregates.OutboundWebsocketGameType, payload any) {
+	if err := h.publisher.PublishToClient(client, string(replyType), payload); err != nil {
+		log.Printf("Error replying to WebSocket client: %v", err)
+	}
+}
+
+// toValidationError keeps structured errors from the game as they are and
+// wraps anything else in a generic rejection. Other errors can say how the
+// server is put together, so they are only ever logged, never sent.
+func toValidationError(err error) *validation.ValidationError {
+	var validationError *validation.ValidationError
+
+	if errors.As(err, &validationError) {
+		return validationError
+	}
+
+	return validation.NewValidationError(validation.ErrCodeCommandRejected, "the command could not be completed, try again", "")
+}
+
+// invalidPayload reports a payload that could not be decoded for the given
+// message type.
+func invalidPayload(messageType aggregates.GameEventType, err error) error {
+	return validation.NewValidationError(
+		validation.ErrCodeInvalidPayload,
+		fmt.Sprintf("invalid %s payload: %v", messageType, err),
+		"payload",
+	)
+}
+
+func (h *GameSocketHandler) handleJoinGame(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.Join(gameId, claim)
+}
+
+func (h *GameSocketHandler) handleBeginGame(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	var p request.GameEventPayloadGameBeginsRequest
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return invalidPayload(aggregates.EventGameBegins, err)
+	}
+
+	return h.gameCoordinator.BeginGame(gameId, claim, p.Force)
+}
+
+func (h *GameSocketHandler) handleReady(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.Ready(gameId, claim)
+}
+
+func (h *GameSocketHandler) handleUnready(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.Unready(gameId, claim)
+}
+
+func (h *GameSocketHandler) handlePlayCard(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	var p request.GameEventPayloadPlayCardRequest
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return invalidPayload(aggregates.EventCardPlayed, err)
+	}
+
+	cardIds := p.CardIDs
+
+	if len(cardIds) == 0 && p.CardID != "" {
+		cardIds = []string{p.CardID}
+	}
+
+	return h.gameCoordinator.PlayCard(gameId, claim, cardIds)
+}
+
+func (h *GameSocketHandler) handlePickWinningCard(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	var p request.GameEventPayloadJudgeChoseWinningCardRequest
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return invalidPayload(aggregates.EventJudgeChoseWinningCard, err)
+	}
+
+	return h.gameCoordinator.PickWinningCard(gameId, claim, p.SubmissionID)
+}
+
+func (h *GameSocketHandler) handleVote(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	var p request.GameEventPayloadVoteCastRequest
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return invalidPayload(aggregates.EventVoteCast, err)
+	}
+
+	return h.gameCoordinator.Vote(gameId, claim, p.SubmissionID)
+}
+
+func (h *GameSocketHandler) handleContinueRound(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.ContinueRound(gameId, claim)
+}
+
+func (h *GameSocketHandler) handleRebootUniverse(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.RebootUniverse(gameId, claim)
+}
+
+func (h *GameSocketHandler) handleMulligan(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.Mulligan(gameId, claim)
+}
+
+func (h *GameSocketHandler) handleLeaveGame(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.Leave(gameId, claim)
+}
+
+func (h *GameSocketHandler) handleWatchGame(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.Watch(gameId, claim)
+}
+
+func (h *GameSocketHandler) handlePauseGame(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.PauseGame(gameId, claim)
+}
+
+func (h *GameSocketHandler) handleResumeGame(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.ResumeGame(gameId, claim)
+}
+
+func (h *GameSocketHandler) handleForceAdvance(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.ForceAdvance(gameId, claim)
+}
+
+func (h *GameSocketHandler) handleRematch(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	var p request.GameEventPayloadRematchRequest
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return invalidPayload(aggregates.EventRematchCreated, err)
+	}
+
+	_, err := h.gameCoordinator.Rematch(gameId, claim, strings.TrimSpace(p.Subject))
+
+	return err
+}
+
+func (h *GameSocketHandler) handleRotateInviteCode(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	return h.gameCoordinator.RotateInviteCode(gameId, claim)
+}
+
+func (h *GameSocketHandler) handleChangeSettings(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	var p request.ChangeSettingsRequest
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return invalidPayload(aggregates.EventSettingsChanged, err)
+	}
+
+	_, err := h.gameCoordinator.ChangeSettings(gameId, claim, services.GameSettingsUpdate(p))
+
+	return err
+}
+
+func (h *GameSocketHandler) handleEmojiClicked(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+	var p request.GameEventPayloadEmojiClickedRequest
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return invalidPayload(aggregates.EventEmojiClicked, err)
+	}
+
+	return h.gameCoordinator.ClickEmoji(gameId, claim, p.Emoji)
+}
+
+// handleModeratePlayer decodes the player an owner is moderating and passes
+// them on to the given coordinator command.
+func (h *GameSocketHandler) handleModeratePlayer(messageType aggregates.GameEventType, moderate func(gameId string, claim *entities.CustomClaim, userId string) error) GameSocketHandlerFunc {
+	return func(gameId string, claim *entities.CustomClaim, payload json.RawMessage) error {
+		var p request.GameEventPayloadModeratePlayerRequest
+
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return invalidPayload(messageType, err)
+		}
+
+		return moderate(gameId, claim, p.PlayerID)
+	}
+}
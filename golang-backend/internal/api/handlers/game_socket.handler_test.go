@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"cardgame/internal/api/response"
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/infra/ws"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type sentReply struct {
+	client    *ws.Client
+	eventType string
+	payload   any
+}
+
+type testPublisher struct {
+	replies []sentReply
+}
+
+func (p *testPublisher) PublishToClient(c *ws.Client, eventType string, payload any) error {
+	p.replies = append(p.replies, sentReply{client: c, eventType: eventType, payload: payload})
+	return nil
+}
+
+func (p *testPublisher) WriteToClient(c *ws.Client, eventType string, payload any) error {
+	return p.PublishToClient(c, eventType, payload)
+}
+
+func (p *testPublisher) DisconnectUser(roomID string, userID string, eventType string, payload any) error {
+	return nil
+}
+
+// newTestHandler returns a handler with no message types registered, so each
+// test registers only the ones it sends.
+func newTestHandler() (*GameSocketHandler, *testPublisher) {
+	publisher := &testPublisher{}
+
+	return &GameSocketHandler{
+		publisher: publisher,
+		handlers:  map[aggregates.GameEventType]GameSocketHandlerFunc{},
+	}, publisher
+}
+
+func onlyReply(t *testing.T, publisher *testPublisher, client *ws.Client) sentReply {
+	t.Helper()
+
+	if len(publisher.replies) != 1 {
+		t.Fatalf("expected one reply, got %d", len(publisher.replies))
+	}
+
+	reply := publisher.replies[0]
+
+	if reply.client != client {
+		t.Fatalf("reply went to the wrong client")
+	}
+
+	return reply
+}
+
+func commandError(t *testing.T, reply sentReply) response.CommandErrorResponse {
+	t.Helper()
+
+	if reply.eventType != string(aggregates.CommandError) {
+		t.Fatalf("expected %s, got %s", aggregates.CommandError, reply.eventType)
+	}
+
+	payload, ok := reply.payload.(response.CommandErrorResponse)
+
+	if !ok {
+		t.Fatalf("expected a command error, got %T", reply.payload)
+	}
+
+	return payload
+}
+
+func TestHandleAcksCommands(t *testing.T) {
+	h, publisher := newTestHandler()
+	client := &ws.Client{RoomID: "game-1", UserID: "u1"}
+	claim := &entities.CustomClaim{UserID: "u1"}
+
+	var gotGameId string
+	var gotPayload json.RawMessage
+
+	h.Register(aggregates.EventGameBegins, func(gameId string, c *entities.CustomClaim, payload json.RawMessage) error {
+		gotGameId = gameId
+		gotPayload = payload
+		return nil
+	})
+
+	h.Handle(client, claim, []byte(`{"request_id":"r1","game_id":"another-game","type":"GameBegins","payload":{"user_id":"u1"}}`))
+
+	if gotGameId != "game-1" {
+		t.Fatalf("expected the game id from the connection, got %q", gotGameId)
+	}
+
+	if string(gotPayload) != `{"user_id":"u1"}` {
+		t.Fatalf("expected the payload to be passed on, got %s", gotPayload)
+	}
+
+	reply := onlyReply(t, publisher, client)
+
+	if reply.eventType != string(aggregates.CommandAck) {
+		t.Fatalf("expected %s, got %s", aggregates.CommandAck, reply.eventType)
+	}
+
+	ack, ok := reply.payload.(response.CommandAckResponse)
+
+	if !ok {
+		t.Fatalf("expected a command ack, got %T", reply.payload)
+	}
+
+	if ack.RequestID != "r1" || ack.Type != aggregates.EventGameBegins {
+		t.Fatalf("ack did not echo the request: %+v", ack)
+	}
+}
+
+func TestHandleRepliesWithTheCodeOfAFailedCommand(t *testing.T) {
+	h, publisher := newTestHandler()
+	client := &ws.Client{RoomID: "game-1", UserID: "u1"}
+
+	h.Register(aggregates.EventGameBegins, func(gameId string, c *entities.CustomClaim, payload json.RawMessage) error {
+		return fmt.Errorf("failed to begin game: %w", validation.NewValidationError("NOT_ENOUGH_PLAYERS", "need more players", ""))
+	})
+
+	h.Handle(client, &entities.CustomClaim{UserID: "u1"}, []byte(`{"request_id":"r2","type":"GameBegins","payload":{}}`))
+
+	reply := commandError(t, onlyReply(t, publisher, client))
+
+	if reply.RequestID != "r2" || reply.Type != aggregates.EventGameBegins {
+		t.Fatalf("error did not echo the request: %+v", reply)
+	}
+
+	if reply.Error.Code != "NOT_ENOUGH_PLAYERS" {
+		t.Fatalf("expected NOT_ENOUGH_PLAYERS, got %s", reply.Error.Code)
+	}
+}
+
+func TestHandleRejectsUnknownMessageTypes(t *testing.T) {
+	h, publisher := newTestHandler()
+	client := &ws.Client{RoomID: "game-1", UserID: "u1"}
+
+	h.Handle(client, &entities.CustomClaim{UserID: "u1"}, []byte(`{"request_id":"r3","type":"NOT_A_COMMAND","payload":{}}`))
+
+	reply := commandError(t, onlyReply(t, publisher, client))
+
+	if reply.RequestID != "r3" {
+		t.Fatalf("expected request id r3, got %q", reply.RequestID)
+	}
+
+	if reply.Error.Code != validation.ErrCodeUnknownMessageType {
+		t.Fatalf("expected %s, got %s", validation.ErrCodeUnknownMessageType, reply.Error.Code)
+	}
+}
+
+func TestHandleRejectsMessagesThatAreNotJSON(t *testing.T) {
+	h, publisher := newTestHandler()
+	client := &ws.Client{RoomID: "game-1", UserID: "u1"}
+
+	h.Handle(client, &entities.CustomClaim{UserID: "u1"}, []byte(`not json`))
+
+	reply := commandError(t, onlyReply(t, publisher, client))
+
+	if reply.Error.Code != validation.ErrCodeInvalidMessage {
+		t.Fatalf("expected %s, got %s", validation.ErrCodeInvalidMessage, reply.Error.Code)
+	}
+}
+
+func TestToValidationErrorHidesUnexpectedErrors(t *testing.T) {
+	err := toValidationError(fmt.Errorf("failed to append events: %w", errors.New("dial tcp 10.0.0.7:6379: connection refused")))
+
+	if err.Code != validation.ErrCodeCommandRejected {
+		t.Fatalf("expected %s, got %s", validation.ErrCodeCommandRejected, err.Code)
+	}
+
+	if strings.Contains(err.Message, "6379") || strings.Contains(err.Message, "append") {
+		t.Fatalf("rejection leaked the error: %q", err.Message)
+	}
+}
+
+func TestToValidationErrorKeepsRuleErrors(t *testing.T) {
+	ruleError := validation.NewValidationError(validation.ErrCodeNotJudge, "only the judge can pick", "")
+
+	if err := toValidationError(fmt.Errorf("failed to pick: %w", ruleError)); err != ruleError {
+		t.Fatalf("expected the rule error to be sent as it is, got %+v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"cardgame/internal/domain/entities"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+// LimitPerUser lets each signed in user make at most max requests per window
+// through the handler, e.g. to stop invite codes being guessed. It goes after
+// RequireAuth, and falls back to the caller's IP without a user.
+func LimitPerUser(max int, window time.Duration) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        max,
+		Expiration: window,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			if claim, ok := c.Locals("user").(*entities.CustomClaim); ok {
+				return claim.UserID
+			}
+
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Too many requests, try again later",
+			})
+		},
+	})
+}
@@ -1,8 +1,36 @@
 package request
 
+import "cardgame/internal/domain/valueobjects"
+
 type CreateGameRequest struct {
-	Name           string `json:"name" validate:"required"`
-	WinnerCount    int    `json:"winner_count" validate:"required,numeric"`
-	MaxPlayerCount int    `json:"max_player_count" validate:"required,numeric"`
-	Subject        string `json:"subject" validate:"required"`
+	Name           string                  `json:"name" validate:"required"`
+	WinnerCount    int                     `json:"winner_count" validate:"required,numeric"`
+	MaxPlayerCount int                     `json:"max_player_count" validate:"required,numeric"`
+	HandSize       int                     `json:"hand_size" validate:"omitempty,numeric,min=3,max=20"`
+	Mode           string                  `json:"mode" validate:"omitempty,oneof=Czar Democracy"`
+	TieRule        string                  `json:"tie_rule" validate:"omitempty,oneof=AllWin NoPoints Random"`
+	HouseRules     valueobjects.HouseRules `json:"house_rules"`
+	Subject        string                  `json:"subject" validate:"required"`
+	Visibility     string                  `json:"visibility" validate:"omitempty,oneof=Public Private"`
+	Password       string                  `json:"password" validate:"omitempty,max=72"`
+}
+
+// AcceptInviteRequest carries the password of the private game being joined,
+// which is left empty when the game has none.
+type AcceptInviteRequest struct {
+	Password string `json:"password"`
+}
+
+// ChangeSettingsRequest changes the settings of a game in setup. Settings left
+// out stay as they are.
+type ChangeSettingsRequest struct {
+	Name           *string                  `json:"name" validate:"omitempty,min=1"`
+	WinnerCount    *int                     `json:"winner_count" validate:"omitempty,min=1"`
+	MaxPlayerCount *int                     `json:"max_player_count" validate:"omitempty,min=1"`
+	HandSize       *int                     `json:"hand_size" validate:"omitempty,min=3,max=20"`
+	Timers         *valueobjects.Timers     `json:"timers"`
+	HouseRules     *valueobjects.HouseRules `json:"house_rules"`
+	Visibility     *string                  `json:"visibility" validate:"omitempty,oneof=Public Private"`
+	AutoStart      *bool                    `json:"auto_start"`
+	Password       *string                  `json:"password" validate:"omitempty,max=72"`
 }
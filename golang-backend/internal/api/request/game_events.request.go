@@ -6,14 +6,18 @@ import (
 )
 
 type GameEventRequest struct {
-	GameID  string                   `json:"game_id"`
-	Type    aggregates.GameEventType `json:"type"`
-	Payload json.RawMessage          `json:"payload"`
+	RequestID string                   `json:"request_id"`
+	GameID    string                   `json:"game_id"`
+	Type      aggregates.GameEventType `json:"type"`
+	Payload   json.RawMessage          `json:"payload"`
 }
 
+// GameEventPayloadGameBeginsRequest begins the game. Force begins it even
+// though not every player is ready.
 type GameEventPayloadGameBeginsRequest struct {
 	GameID string `json:"game_id" validate:"required"`
 	UserID string `json:"user_id" validate:"required"`
+	Force  bool   `json:"force"`
 }
 
 type GameEventPayloadJoinedGameRequest struct {
@@ -29,13 +33,22 @@ type GameEventPayloadGameRoundContinuedRequest struct {
 }
 
 type GameEventPayloadJudgeChoseWinningCardRequest struct {
-	GameID string `json:"game_id" validate:"required"`
-	CardID string `json:"card_id" validate:"required"`
+	GameID       string `json:"game_id" validate:"required"`
+	SubmissionID string `json:"submission_id" validate:"required"`
 }
 
+// GameEventPayloadPlayCardRequest carries the played white cards in the
+// order they fill the blanks. CardID is accepted from clients that only ever
+// play a single card.
 type GameEventPayloadPlayCardRequest struct {
-	GameID string `json:"game_id" validate:"required"`
-	CardID string `json:"card_id" validate:"required"`
+	GameID  string   `json:"game_id" validate:"required"`
+	CardIDs []string `json:"card_ids"`
+	CardID  string   `json:"card_id"`
+}
+
+type GameEventPayloadVoteCastRequest struct {
+	GameID       string `json:"game_id" validate:"required"`
+	SubmissionID string `json:"submission_id" validate:"required"`
 }
 
 type GameEventPayloadEmojiClickedRequest struct {
@@ -43,3 +56,17 @@ type GameEventPayloadEmojiClickedRequest struct {
 	UserID string `json:"user_id" validate:"required"`
 	Emoji  string `json:"emoji" validate:"required"`
 }
+
+// GameEventPayloadRematchRequest starts a rematch of a finished game. The
+// rematch reuses the game's cards unless a subject for a new deck is given.
+type GameEventPayloadRematchRequest struct {
+	GameID  string `json:"game_id" validate:"required"`
+	Subject string `json:"subject"`
+}
+
+// GameEventPayloadModeratePlayerRequest names the player the owner is kicking,
+// banning, muting, unmuting or promoting.
+type GameEventPayloadModeratePlayerRequest struct {
+	GameID   string `json:"game_id" validate:"required"`
+	PlayerID string `json:"player_id" validate:"required"`
+}
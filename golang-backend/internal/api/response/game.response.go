@@ -1,6 +1,6 @@
 package response
 
-import "cardgame/internal/domain/aggregates"
+import "cardgame/internal/domain/projections"
 
-type GetGamesResponse []*aggregates.Game
-type GetGameResponse *aggregates.Game
+type GetGamesResponse []*projections.GameView
+type GetGameResponse *projections.GameView
@@ -0,0 +1,17 @@
+package response
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/validation"
+)
+
+type CommandAckResponse struct {
+	RequestID string                   `json:"request_id"`
+	Type      aggregates.GameEventType `json:"type"`
+}
+
+type CommandErrorResponse struct {
+	RequestID string                     `json:"request_id"`
+	Type      aggregates.GameEventType   `json:"type"`
+	Error     validation.ValidationError `json:"error"`
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"cardgame/internal/api/controllers"
 	"cardgame/internal/api/middleware"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/websocket/v2"
@@ -17,4 +18,12 @@ func NewGameRouter(group fiber.Router, gc *controllers.GameController) {
 
 	group.Get("/games", middleware.RequireAuth(gc.Env), gc.HandleGetGames)
 	group.Post("/games/new", middleware.RequireAuth(gc.Env), gc.CreateGame)
+
+	// Looking up and accepting invites share one limit, so codes can't be
+	// guessed by trying them one after another
+	inviteLimit := middleware.LimitPerUser(10, time.Minute)
+
+	group.Get("/games/invite/:code", middleware.RequireAuth(gc.Env), inviteLimit, gc.HandleResolveInvite)
+	group.Post("/games/invite/:code", middleware.RequireAuth(gc.Env), inviteLimit, gc.HandleAcceptInvite)
+	group.Patch("/games/:id/settings", middleware.RequireAuth(gc.Env), gc.HandleChangeSettings)
 }
@@ -33,13 +33,15 @@ func (c *Collection) Shuffle() {
 	}
 }
 
+// ShuffleWithSeed shuffles the cards with their own source so the same seed
+// always yields the same order when events are replayed.
 func (c *Collection) ShuffleWithSeed(seed int64) {
-	rand.Seed(seed)
+	r := rand.New(rand.NewSource(seed))
 
 	n := len(c.Cards)
 
 	for i := n - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
+		j := r.Intn(i + 1)
 		c.Cards[i], c.Cards[j] = c.Cards[j], c.Cards[i]
 	}
 }
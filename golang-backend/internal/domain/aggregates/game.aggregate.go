@@ -7,7 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"slices"
 	"time"
 
 	"encoding/json"
@@ -18,8 +18,10 @@ import (
 type GameEventType string
 
 const (
+	EventGameCreated           GameEventType = "GameCreated"
 	EventGameBegins            GameEventType = "GameBegins"
 	EventJoinedGame            GameEventType = "JoinedGame"
+	EventBotJoined             GameEventType = "BotJoined"
 	EventCardPlayed            GameEventType = "CardPlayed"
 	EventRoundContinued        GameEventType = "RoundContinued"
 	EventJudgeChoseWinningCard GameEventType = "JudgeChoseWinningCard"
@@ -31,11 +33,38 @@ const (
 	EventGameWinner            GameEventType = "GameWinner"
 	EventClockUpdate           GameEventType = "ClockUpdate"
 	EventEmojiClicked          GameEventType = "EmojiClicked"
+	EventUniverseRebooted      GameEventType = "UniverseRebooted"
+	EventHandMulliganed        GameEventType = "HandMulliganed"
+	EventVoteCast              GameEventType = "VoteCast"
+	EventVotesCounted          GameEventType = "VotesCounted"
+	EventPlayerDisconnected    GameEventType = "PlayerDisconnected"
+	EventPlayerReconnected     GameEventType = "PlayerReconnected"
+	EventPlayerLeft            GameEventType = "PlayerLeft"
+	EventOwnerChanged          GameEventType = "OwnerChanged"
+	EventRoundVoided           GameEventType = "RoundVoided"
+	EventPlayerKicked          GameEventType = "PlayerKicked"
+	EventPlayerBanned          GameEventType = "PlayerBanned"
+	EventPlayerMuted           GameEventType = "PlayerMuted"
+	EventPlayerUnmuted         GameEventType = "PlayerUnmuted"
+	EventSpectatorJoined       GameEventType = "SpectatorJoined"
+	EventSpectatorLeft         GameEventType = "SpectatorLeft"
+	EventSpectatorPromoted     GameEventType = "SpectatorPromoted"
+	EventSpectatorSeated       GameEventType = "SpectatorSeated"
+	EventGamePaused            GameEventType = "GamePaused"
+	EventGameResumed           GameEventType = "GameResumed"
+	EventRoundForceAdvanced    GameEventType = "RoundForceAdvanced"
+	EventRematchCreated        GameEventType = "RematchCreated"
+	EventInviteAccepted        GameEventType = "InviteAccepted"
+	EventInviteCodeRotated     GameEventType = "InviteCodeRotated"
+	EventSettingsChanged       GameEventType = "SettingsChanged"
+	EventPlayerReady           GameEventType = "PlayerReady"
+	EventPlayerUnready         GameEventType = "PlayerUnready"
 )
 
 type GameEvent struct {
 	ID        string          `json:"id"`
 	GameID    string          `json:"game_id"`
+	Version   int64           `json:"version"`
 	Type      GameEventType   `json:"type"`
 	Payload   json.RawMessage `json:"payload"`
 	CreatedAt time.Time       `json:"created_at"`
@@ -66,34 +95,100 @@ const (
 type OutboundWebsocketGameType string
 
 const (
-	GameUpdate          OutboundWebsocketGameType = "GAME_UPDATE"
-	ChatMessage         OutboundWebsocketGameType = "CHAT_MESSAGE"
-	EmojiClickedMessage OutboundWebsocketGameType = "EMOJI_CLICKED"
+	GameUpdate            OutboundWebsocketGameType = "GAME_UPDATE"
+	ChatMessage           OutboundWebsocketGameType = "CHAT_MESSAGE"
+	EmojiClickedMessage   OutboundWebsocketGameType = "EMOJI_CLICKED"
+	CommandAck            OutboundWebsocketGameType = "COMMAND_ACK"
+	CommandError          OutboundWebsocketGameType = "COMMAND_ERROR"
+	ClockUpdate           OutboundWebsocketGameType = "CLOCK_UPDATE"
+	PlayerKickedMessage   OutboundWebsocketGameType = "PLAYER_KICKED"
+	RematchStartedMessage OutboundWebsocketGameType = "REMATCH_STARTED"
 )
 
 type GameEventPayloadJudgeChoseWinningCard struct {
-	GameID string `json:"game_id"`
-	CardID string `json:"card_id"`
+	GameID       string `json:"game_id"`
+	SubmissionID string `json:"submission_id"`
+	// CardID identifies the winner in events from before submissions
+	CardID string `json:"card_id,omitempty"`
 }
 
-func NewGameEventPayloadJudgeChoseWinningCard(gameID string, cardID string) GameEventPayloadJudgeChoseWinningCard {
+func NewGameEventPayloadJudgeChoseWinningCard(gameID string, submissionID string) GameEventPayloadJudgeChoseWinningCard {
 	return GameEventPayloadJudgeChoseWinningCard{
-		GameID: gameID,
-		CardID: cardID,
+		GameID:       gameID,
+		SubmissionID: submissionID,
+	}
+}
+
+type GameEventPayloadGameCreated struct {
+	GameID         string                  `json:"game_id"`
+	Name           string                  `json:"name"`
+	Collection     *Collection             `json:"collection"`
+	WinnerCount    int                     `json:"winner_count"`
+	MaxPlayerCount int                     `json:"max_player_count"`
+	MinPlayerCount int                     `json:"min_player_count"`
+	HandSize       int                     `json:"hand_size"`
+	Mode           valueobjects.GameMode   `json:"mode"`
+	TieRule        valueobjects.TieRule    `json:"tie_rule"`
+	HouseRules     valueobjects.HouseRules `json:"house_rules"`
+	Visibility     valueobjects.Visibility `json:"visibility,omitempty"`
+	InviteCode     string                  `json:"invite_code,omitempty"`
+	PasswordHash   string                  `json:"password_hash,omitempty"` // bcrypt hash, empty when the game has no password
+	OwnerPlayerID  string                  `json:"owner_player_id"`
+	Owner          *entities.CustomClaim   `json:"owner"`
+	BannedUserIDs  []string                `json:"banned_user_ids,omitempty"` // Carried over from the game this is a rematch of
+	Timers         valueobjects.Timers     `json:"timers,omitempty"`          // Carried over from the game this is a rematch of
+	AutoStart      bool                    `json:"auto_start,omitempty"`      // Carried over from the game this is a rematch of
+}
+
+func NewGameEventPayloadGameCreated(gameID string, name string, collection *Collection, winnerCount int, maxPlayerCount int, minPlayerCount int, handSize int, mode valueobjects.GameMode, tieRule valueobjects.TieRule, houseRules valueobjects.HouseRules, visibility valueobjects.Visibility, inviteCode string, passwordHash string, ownerPlayerID string, owner *entities.CustomClaim) GameEventPayloadGameCreated {
+	return GameEventPayloadGameCreated{
+		GameID:         gameID,
+		Name:           name,
+		Collection:     collection,
+		WinnerCount:    winnerCount,
+		MaxPlayerCount: maxPlayerCount,
+		MinPlayerCount: minPlayerCount,
+		HandSize:       handSize,
+		Mode:           mode,
+		TieRule:        tieRule,
+		HouseRules:     houseRules,
+		Visibility:     visibility,
+		InviteCode:     inviteCode,
+		PasswordHash:   passwordHash,
+		OwnerPlayerID:  ownerPlayerID,
+		Owner:          owner,
 	}
 }
 
 type GameEventPayloadJoinedGame struct {
-	GameID string                `json:"game_id"`
-	UserID string                `json:"user_id"`
-	Claim  *entities.CustomClaim `json:"claim"`
+	GameID   string                `json:"game_id"`
+	UserID   string                `json:"user_id"`
+	PlayerID string                `json:"player_id"`
+	Claim    *entities.CustomClaim `json:"claim"`
 }
 
-func NewGameEventPayloadJoinedGame(gameID string, userID string, claim *entities.CustomClaim) GameEventPayloadJoinedGame {
+func NewGameEventPayloadJoinedGame(gameID string, userID string, playerID string, claim *entities.CustomClaim) GameEventPayloadJoinedGame {
 	return GameEventPayloadJoinedGame{
-		GameID: gameID,
-		UserID: userID,
-		Claim:  claim,
+		GameID:   gameID,
+		UserID:   userID,
+		PlayerID: playerID,
+		Claim:    claim,
+	}
+}
+
+type GameEventPayloadBotJoined struct {
+	GameID   string `json:"game_id"`
+	UserID   string `json:"user_id"`
+	PlayerID string `json:"player_id"`
+	Name     string `json:"name"`
+}
+
+func NewGameEventPayloadBotJoined(gameID string, userID string, playerID string, name string) GameEventPayloadBotJoined {
+	return GameEventPayloadBotJoined{
+		GameID:   gameID,
+		UserID:   userID,
+		PlayerID: playerID,
+		Name:     name,
 	}
 }
 
@@ -114,16 +209,20 @@ func NewGameEventPayloadGameRoundContinuedWithCards(gameID string, userID string
 }
 
 type GameEventPayloadPlayCard struct {
-	GameID string                `json:"game_id"`
-	CardID string                `json:"card_id"`
-	Claim  *entities.CustomClaim `json:"claim"`
+	GameID       string                `json:"game_id"`
+	SubmissionID string                `json:"submission_id"`
+	CardIDs      []string              `json:"card_ids"`
+	Claim        *entities.CustomClaim `json:"claim"`
+	// CardID is the single card played in events from before submissions
+	CardID string `json:"card_id,omitempty"`
 }
 
-func NewGameEventPayloadPlayCard(gameID string, cardID string, claim *entities.CustomClaim) GameEventPayloadPlayCard {
+func NewGameEventPayloadPlayCard(gameID string, submissionID string, cardIDs []string, claim *entities.CustomClaim) GameEventPayloadPlayCard {
 	return GameEventPayloadPlayCard{
-		GameID: gameID,
-		CardID: cardID,
-		Claim:  claim,
+		GameID:       gameID,
+		SubmissionID: submissionID,
+		CardIDs:      cardIDs,
+		Claim:        claim,
 	}
 }
 
@@ -155,6 +254,120 @@ func NewGameEventPayloadDealCards(gameID string, playerID string, cardIDs []stri
 	}
 }
 
+// GameEventPayloadRedrawHand replaces a player's whole hand with the given
+// cards. The old hand goes to the discard pile.
+type GameEventPayloadRedrawHand struct {
+	GameID   string   `json:"game_id"`
+	PlayerID string   `json:"player_id"`
+	CardIDs  []string `json:"card_ids"`
+}
+
+func NewGameEventPayloadRedrawHand(gameID string, playerID string, cardIDs []string) GameEventPayloadRedrawHand {
+	return GameEventPayloadRedrawHand{
+		GameID:   gameID,
+		PlayerID: playerID,
+		CardIDs:  cardIDs,
+	}
+}
+
+type GameEventPayloadVoteCast struct {
+	GameID       string `json:"game_id"`
+	PlayerID     string `json:"player_id"`
+	SubmissionID string `json:"submission_id"`
+}
+
+func NewGameEventPayloadVoteCast(gameID string, playerID string, submissionID string) GameEventPayloadVoteCast {
+	return GameEventPayloadVoteCast{
+		GameID:       gameID,
+		PlayerID:     playerID,
+		SubmissionID: submissionID,
+	}
+}
+
+// GameEventPayloadVotesCounted closes a democracy round. The winners are
+// decided when the votes are counted, ties included, so replay never has to
+// break a tie again.
+type GameEventPayloadVotesCounted struct {
+	GameID               string   `json:"game_id"`
+	WinningSubmissionIDs []string `json:"winning_submission_ids"`
+}
+
+func NewGameEventPayloadVotesCounted(gameID string, winningSubmissionIDs []string) GameEventPayloadVotesCounted {
+	return GameEventPayloadVotesCounted{
+		GameID:               gameID,
+		WinningSubmissionIDs: winningSubmissionIDs,
+	}
+}
+
+// GameEventPayloadPlayerConnection records a player's connection dropping or
+// coming back.
+type GameEventPayloadPlayerConnection struct {
+	GameID   string `json:"game_id"`
+	PlayerID string `json:"player_id"`
+}
+
+func NewGameEventPayloadPlayerConnection(gameID string, playerID string) GameEventPayloadPlayerConnection {
+	return GameEventPayloadPlayerConnection{
+		GameID:   gameID,
+		PlayerID: playerID,
+	}
+}
+
+// GameEventPayloadPlayerLeft records a player giving up their seat, or the
+// owner kicking them out of it.
+type GameEventPayloadPlayerLeft struct {
+	GameID   string `json:"game_id"`
+	PlayerID string `json:"player_id"`
+}
+
+func NewGameEventPayloadPlayerLeft(gameID string, playerID string) GameEventPayloadPlayerLeft {
+	return GameEventPayloadPlayerLeft{
+		GameID:   gameID,
+		PlayerID: playerID,
+	}
+}
+
+type GameEventPayloadOwnerChanged struct {
+	GameID   string `json:"game_id"`
+	PlayerID string `json:"player_id"`
+}
+
+func NewGameEventPayloadOwnerChanged(gameID string, playerID string) GameEventPayloadOwnerChanged {
+	return GameEventPayloadOwnerChanged{
+		GameID:   gameID,
+		PlayerID: playerID,
+	}
+}
+
+// GameEventPayloadPlayerModerated records the owner banning, muting,
+// unmuting or promoting a player. Bans are by user id and outlast the
+// player's seat.
+type GameEventPayloadPlayerModerated struct {
+	GameID   string `json:"game_id"`
+	PlayerID string `json:"player_id"`
+}
+
+func NewGameEventPayloadPlayerModerated(gameID string, playerID string) GameEventPayloadPlayerModerated {
+	return GameEventPayloadPlayerModerated{
+		GameID:   gameID,
+		PlayerID: playerID,
+	}
+}
+
+// GameEventPayloadRoundVoided restarts the current round with a new black
+// card. Played cards go back to their owners.
+type GameEventPayloadRoundVoided struct {
+	GameID      string `json:"game_id"`
+	BlackCardID string `json:"black_card_id"`
+}
+
+func NewGameEventPayloadRoundVoided(gameID string, blackCardID string) GameEventPayloadRoundVoided {
+	return GameEventPayloadRoundVoided{
+		GameID:      gameID,
+		BlackCardID: blackCardID,
+	}
+}
+
 type GameEventPayloadDrawBlackCard struct {
 	GameID string `json:"game_id"`
 	CardID string `json:"card_id"`
@@ -191,38 +404,205 @@ func NewGameEventPayloadClockUpdate(gameID string, nextAutoProgressAt time.Time)
 	}
 }
 
+// GameEventPayloadGamePaused records the owner pausing or resuming the game.
+type GameEventPayloadGamePaused struct {
+	GameID   string `json:"game_id"`
+	PlayerID string `json:"player_id"`
+}
+
+func NewGameEventPayloadGamePaused(gameID string, playerID string) GameEventPayloadGamePaused {
+	return GameEventPayloadGamePaused{
+		GameID:   gameID,
+		PlayerID: playerID,
+	}
+}
+
+// GameEventPayloadRoundForceAdvanced records the owner moving a round on from
+// the given status. The events that move it follow.
+type GameEventPayloadRoundForceAdvanced struct {
+	GameID      string                   `json:"game_id"`
+	PlayerID    string                   `json:"player_id"`
+	RoundStatus valueobjects.RoundStatus `json:"round_status"`
+}
+
+func NewGameEventPayloadRoundForceAdvanced(gameID string, playerID string, roundStatus valueobjects.RoundStatus) GameEventPayloadRoundForceAdvanced {
+	return GameEventPayloadRoundForceAdvanced{
+		GameID:      gameID,
+		PlayerID:    playerID,
+		RoundStatus: roundStatus,
+	}
+}
+
+// GameEventPayloadRematchCreated records the owner of a finished game starting
+// a rematch of it.
+type GameEventPayloadRematchCreated struct {
+	GameID        string `json:"game_id"`
+	PlayerID      string `json:"player_id"`
+	RematchGameID string `json:"rematch_game_id"`
+}
+
+func NewGameEventPayloadRematchCreated(gameID string, playerID string, rematchGameID string) GameEventPayloadRematchCreated {
+	return GameEventPayloadRematchCreated{
+		GameID:        gameID,
+		PlayerID:      playerID,
+		RematchGameID: rematchGameID,
+	}
+}
+
+// GameEventPayloadInviteAccepted records a user getting into a private game
+// with its invite code, and its password if it has one.
+type GameEventPayloadInviteAccepted struct {
+	GameID     string `json:"game_id"`
+	UserID     string `json:"user_id"`
+	InviteCode string `json:"invite_code"`
+}
+
+func NewGameEventPayloadInviteAccepted(gameID string, userID string, inviteCode string) GameEventPayloadInviteAccepted {
+	return GameEventPayloadInviteAccepted{
+		GameID:     gameID,
+		UserID:     userID,
+		InviteCode: inviteCode,
+	}
+}
+
+// GameEventPayloadInviteCodeRotated records the owner replacing a private
+// game's invite code. The old code stops working.
+type GameEventPayloadInviteCodeRotated struct {
+	GameID     string `json:"game_id"`
+	PlayerID   string `json:"player_id"`
+	InviteCode string `json:"invite_code"`
+}
+
+func NewGameEventPayloadInviteCodeRotated(gameID string, playerID string, inviteCode string) GameEventPayloadInviteCodeRotated {
+	return GameEventPayloadInviteCodeRotated{
+		GameID:     gameID,
+		PlayerID:   playerID,
+		InviteCode: inviteCode,
+	}
+}
+
+// GameSettings are the settings the owner can change before the game begins.
+type GameSettings struct {
+	Name           string                  `json:"name"`
+	WinnerCount    int                     `json:"winner_count"`
+	MaxPlayerCount int                     `json:"max_player_count"`
+	HandSize       int                     `json:"hand_size"`
+	Timers         valueobjects.Timers     `json:"timers"`
+	HouseRules     valueobjects.HouseRules `json:"house_rules"`
+	Visibility     valueobjects.Visibility `json:"visibility"`
+	InviteCode     string                  `json:"invite_code,omitempty"`
+	PasswordHash   string                  `json:"password_hash,omitempty"`
+	AutoStart      bool                    `json:"auto_start"`
+}
+
+// GameEventPayloadSettingsChanged records the owner changing the game's
+// settings in setup. It holds all of the settings, changed or not. Players
+// who were ready have to ready up again for the new settings.
+type GameEventPayloadSettingsChanged struct {
+	GameID   string       `json:"game_id"`
+	PlayerID string       `json:"player_id"`
+	Settings GameSettings `json:"settings"`
+}
+
+func NewGameEventPayloadSettingsChanged(gameID string, playerID string, settings GameSettings) GameEventPayloadSettingsChanged {
+	return GameEventPayloadSettingsChanged{
+		GameID:   gameID,
+		PlayerID: playerID,
+		Settings: settings,
+	}
+}
+
+// GameEventPayloadPlayerReady records a player in the lobby saying they are,
+// or are no longer, ready for the game to begin.
+type GameEventPayloadPlayerReady struct {
+	GameID   string `json:"game_id"`
+	PlayerID string `json:"player_id"`
+}
+
+func NewGameEventPayloadPlayerReady(gameID string, playerID string) GameEventPayloadPlayerReady {
+	return GameEventPayloadPlayerReady{
+		GameID:   gameID,
+		PlayerID: playerID,
+	}
+}
+
 type GameEventPayloadGameWinner struct {
 	GameID   string `json:"game_id"`
 	PlayerID string `json:"player_id"`
 	Score    int    `json:"score"`
+	// ShamedPlayerIDs are the players who lost the game to a bot
+	ShamedPlayerIDs []string `json:"shamed_player_ids,omitempty"`
 }
 
-func NewGameEventPayloadGameWinner(gameID string, playerID string, score int) GameEventPayloadGameWinner {
+func NewGameEventPayloadGameWinner(gameID string, playerID string, score int, shamedPlayerIDs []string) GameEventPayloadGameWinner {
 	return GameEventPayloadGameWinner{
-		GameID:   gameID,
-		PlayerID: playerID,
-		Score:    score,
+		GameID:          gameID,
+		PlayerID:        playerID,
+		Score:           score,
+		ShamedPlayerIDs: shamedPlayerIDs,
 	}
 }
 
+// DefaultMinPlayerCount is how many players a game needs to begin when it was
+// created without its own minimum: a judge and two players to choose between.
+const DefaultMinPlayerCount = 3
+
+// DefaultHandSize is how many white cards each player holds when the game was
+// created without its own hand size.
+const DefaultHandSize = 10
+
+// A game's own hand size is between MinHandSize and MaxHandSize. Every hand
+// holds enough cards for any black card.
+const (
+	MinHandSize = entities.MaxPick
+	MaxHandSize = 20
+)
+
+// Rando Cardrissian is the bot that joins games played with the
+// RandoCardrissian house rule.
+const (
+	RandoUserID = "rando-cardrissian"
+	RandoName   = "Rando Cardrissian"
+)
+
+// Game is not safe for concurrent use. Live games are owned by a single
+// goroutine in the game coordinator and all changes go through it.
 type Game struct {
-	Mutex              sync.RWMutex             `json:"-"`
 	ID                 string                   `json:"id"`
 	Name               string                   `json:"name"`
 	Collection         *Collection              `json:"collection"`
 	WinnerCount        int                      `json:"winner_count"`
 	MaxPlayerCount     int                      `json:"max_player_count"`
+	MinPlayerCount     int                      `json:"min_player_count"`
+	HandSize           int                      `json:"hand_size"`
+	Mode               valueobjects.GameMode    `json:"mode"`
+	TieRule            valueobjects.TieRule     `json:"tie_rule"`
+	HouseRules         valueobjects.HouseRules  `json:"house_rules"`
+	Timers             valueobjects.Timers      `json:"timers"`
+	AutoStart          bool                     `json:"auto_start"` // Begin once everyone is ready
+	Visibility         valueobjects.Visibility  `json:"visibility"`
+	InviteCode         string                   `json:"invite_code"`
+	PasswordHash       string                   `json:"password_hash"`
+	InvitedUserIDs     []string                 `json:"invited_user_ids"` // Users who got into a private game with its invite
 	Status             valueobjects.GameStatus  `json:"status"`
 	Players            []*Player                `json:"players"`
-	WhiteCards         []*entities.Card         `json:"white_cards"`
+	Spectators         []*Player                `json:"spectators"`
+	Submissions        []*entities.Submission   `json:"submissions"`
+	Votes              map[string]string        `json:"votes"` // voter user id -> submission id
 	UsedCards          []*entities.Card         `json:"used_cards"`
 	BlackCard          *entities.Card           `json:"black_card"`
 	RoundStatus        valueobjects.RoundStatus `json:"round_status"`
 	CurrentGameRound   int                      `json:"current_game_round"`
 	RoundWinner        *Player                  `json:"round_winner"`
+	BannedUserIDs      []string                 `json:"banned_user_ids"`
+	RematchGameID      string                   `json:"rematch_game_id"`
+	WinningSubmission  *entities.Submission     `json:"winning_submission"`
+	LastEventID        string                   `json:"last_event_id"`
+	Version            int64                    `json:"version"`
 	LastVacatedAt      time.Time                `json:"last_vacated_at"`
 	LastEventAt        time.Time                `json:"last_event_at"`
 	NextAutoProgressAt time.Time                `json:"next_auto_progress_at"`
+	PausedAt           time.Time                `json:"paused_at"`
 	CreatedAt          time.Time                `json:"created_at"`
 	UpdatedAt          time.Time                `json:"updated_at"`
 	DeletedAt          time.Time                `json:"-"`
@@ -236,7 +616,7 @@ func NewGame(
 	maxPlayerCount int,
 	status valueobjects.GameStatus,
 	players []*Player,
-	whiteCards []*entities.Card,
+	submissions []*entities.Submission,
 	usedCards []*entities.Card,
 	blackCard *entities.Card,
 	roundStatus valueobjects.RoundStatus,
@@ -257,7 +637,7 @@ func NewGame(
 		MaxPlayerCount:     maxPlayerCount,
 		Status:             status,
 		Players:            players,
-		WhiteCards:         whiteCards,
+		Submissions:        submissions,
 		UsedCards:          usedCards,
 		BlackCard:          blackCard,
 		RoundStatus:        roundStatus,
@@ -272,36 +652,229 @@ func NewGame(
 	}
 }
 
-func (g *Game) Lock() {
-	g.Mutex.RLock()
+// NewGameFromEvents rebuilds a game by applying its events in order. The
+// first event must be the EventGameCreated that started the game.
+func NewGameFromEvents(events []GameEvent) (*Game, error) {
+	if len(events) == 0 {
+		return nil, fmt.Errorf("could not rebuild game, no events given")
+	}
+
+	if events[0].Type != EventGameCreated {
+		return nil, fmt.Errorf("could not rebuild game %s, first event is %s instead of %s", events[0].GameID, events[0].Type, EventGameCreated)
+	}
+
+	g := &Game{ID: events[0].GameID}
+
+	for i := range events {
+		if err := g.ApplyEvent(&events[i]); err != nil {
+			return nil, fmt.Errorf("could not rebuild game %s at event %s: %w", g.ID, events[i].ID, err)
+		}
+	}
+
+	return g, nil
+}
+
+// Clone copies everything an event can change. Cards never change once they
+// are in the collection, so the copy shares them with the original.
+func (g *Game) Clone() *Game {
+	if g == nil {
+		return nil
+	}
+
+	cloned := *g
+
+	if g.Collection != nil {
+		cloned.Collection = &Collection{Cards: slices.Clone(g.Collection.Cards)}
+	}
+
+	cloned.InvitedUserIDs = slices.Clone(g.InvitedUserIDs)
+	cloned.BannedUserIDs = slices.Clone(g.BannedUserIDs)
+	cloned.UsedCards = slices.Clone(g.UsedCards)
+	cloned.Players = clonePlayers(g.Players)
+	cloned.Spectators = clonePlayers(g.Spectators)
+
+	if g.Votes != nil {
+		cloned.Votes = make(map[string]string, len(g.Votes))
+
+		for voter, submissionID := range g.Votes {
+			cloned.Votes[voter] = submissionID
+		}
+	}
+
+	if g.Submissions != nil {
+		cloned.Submissions = make([]*entities.Submission, len(g.Submissions))
+
+		for i, submission := range g.Submissions {
+			cloned.Submissions[i] = cloneSubmission(submission)
+
+			if submission == g.WinningSubmission {
+				cloned.WinningSubmission = cloned.Submissions[i]
+			}
+		}
+	}
+
+	if cloned.WinningSubmission == g.WinningSubmission {
+		cloned.WinningSubmission = cloneSubmission(g.WinningSubmission)
+	}
+
+	// The round winner points at one of the players
+	if g.RoundWinner != nil {
+		cloned.RoundWinner = g.RoundWinner.Clone()
+
+		for _, player := range cloned.Players {
+			if player.UserID == g.RoundWinner.UserID {
+				cloned.RoundWinner = player
+				break
+			}
+		}
+	}
+
+	return &cloned
+}
+
+func clonePlayers(players []*Player) []*Player {
+	if players == nil {
+		return nil
+	}
+
+	cloned := make([]*Player, len(players))
+
+	for i, player := range players {
+		cloned[i] = player.Clone()
+	}
+
+	return cloned
 }
 
-func (g *Game) Unlock() {
-	g.Mutex.RUnlock()
+func cloneSubmission(submission *entities.Submission) *entities.Submission {
+	if submission == nil {
+		return nil
+	}
+
+	return entities.NewSubmission(submission.ID, submission.UserID, slices.Clone(submission.Cards))
 }
 
-func (g *Game) ClearUsedCards() {
-	g.Lock()
-	defer g.Unlock()
+// RequiredPlayerCount is how many players the game needs before it can begin.
+func (g *Game) RequiredPlayerCount() int {
+	if g.MinPlayerCount > 0 {
+		return g.MinPlayerCount
+	}
+
+	return DefaultMinPlayerCount
+}
 
+// TargetHandSize is how many white cards every hand is filled up to.
+func (g *Game) TargetHandSize() int {
+	if g.HandSize > 0 {
+		return g.HandSize
+	}
+
+	return DefaultHandSize
+}
+
+// CardsNeededToFillHands is how many white cards it takes to bring every hand
+// back up to the target hand size.
+func (g *Game) CardsNeededToFillHands() int {
+	needed := 0
+
+	for _, player := range g.Players {
+		if missing := g.TargetHandSize() - len(player.Deck); missing > 0 {
+			needed += missing
+		}
+	}
+
+	return needed
+}
+
+// IsFull reports whether the game has reached its maximum number of players.
+// Bots don't take up a seat.
+func (g *Game) IsFull() bool {
+	return g.MaxPlayerCount > 0 && len(g.GetHumanPlayers()) >= g.MaxPlayerCount
+}
+
+func (g *Game) ClearUsedCards() {
 	g.UsedCards = []*entities.Card{}
 }
 
-func (g *Game) ShouldShuffle() bool {
-	g.Lock()
-	defer g.Unlock()
+// MarkCardAsUsed keeps a drawn card from being drawn again until the next shuffle.
+func (g *Game) MarkCardAsUsed(card *entities.Card) {
+	g.UsedCards = append(g.UsedCards, card)
+}
 
+// ShouldShuffle reports whether the draw pile can't cover the next round and
+// a shuffle would put discarded cards back into it.
+func (g *Game) ShouldShuffle() bool {
 	availableWhiteCards := len(g.GetUnplayedWhiteCards())
 	availableBlackCards := len(g.GetUnplayedBlackCards())
-	playersNeedingCards := len(g.GetNonJudgePlayers())
 
-	return availableWhiteCards < playersNeedingCards || availableBlackCards < 1
+	if availableWhiteCards >= g.CardsNeededToFillHands() && availableBlackCards >= 1 {
+		return false
+	}
+
+	return len(g.UsedCards) > len(g.heldCards())
 }
 
-func (g *Game) GetNonJudgePlayers() []*Player {
-	g.Lock()
-	defer g.Unlock()
+// heldCards are the cards that are in play and must stay out of the draw
+// pile: every hand, every placed card, the board and the black card.
+func (g *Game) heldCards() []*entities.Card {
+	held := []*entities.Card{}
+
+	for _, player := range g.Players {
+		held = append(held, player.Deck...)
+
+		held = append(held, player.PlacedCards...)
+	}
+
+	for _, submission := range g.Submissions {
+		held = append(held, submission.Cards...)
+	}
+
+	if g.BlackCard != nil {
+		held = append(held, g.BlackCard)
+	}
+
+	return held
+}
+
+func (g *Game) GetHumanPlayers() []*Player {
+	humanPlayers := []*Player{}
 
+	for _, player := range g.Players {
+		if !player.IsBot {
+			humanPlayers = append(humanPlayers, player)
+		}
+	}
+
+	return humanPlayers
+}
+
+// GetUnreadyPlayers returns the players the owner is still waiting on before
+// beginning the game. The owner is ready by beginning it.
+func (g *Game) GetUnreadyPlayers() []*Player {
+	unreadyPlayers := []*Player{}
+
+	for _, player := range g.GetHumanPlayers() {
+		if !player.IsOwner && !player.IsReady {
+			unreadyPlayers = append(unreadyPlayers, player)
+		}
+	}
+
+	return unreadyPlayers
+}
+
+func (g *Game) GetBotPlayers() []*Player {
+	botPlayers := []*Player{}
+
+	for _, player := range g.Players {
+		if player.IsBot {
+			botPlayers = append(botPlayers, player)
+		}
+	}
+
+	return botPlayers
+}
+
+func (g *Game) GetNonJudgePlayers() []*Player {
 	nonJudgePlayers := []*Player{}
 
 	for _, player := range g.Players {
@@ -314,9 +887,6 @@ func (g *Game) GetNonJudgePlayers() []*Player {
 }
 
 func (g *Game) GetUnplayedBlackCards() []*entities.Card {
-	g.Lock()
-	defer g.Unlock()
-
 	usedCardsMap := make(map[string]*entities.Card)
 
 	for _, card := range g.UsedCards {
@@ -335,9 +905,6 @@ func (g *Game) GetUnplayedBlackCards() []*entities.Card {
 }
 
 func (g *Game) GetUnplayedWhiteCards() []*entities.Card {
-	g.Lock()
-	defer g.Unlock()
-
 	usedCardsMap := make(map[string]*entities.Card)
 
 	for _, card := range g.UsedCards {
@@ -356,65 +923,46 @@ func (g *Game) GetUnplayedWhiteCards() []*entities.Card {
 }
 
 func (g *Game) SetStatus(status valueobjects.GameStatus) {
-	g.Lock()
-	defer g.Unlock()
-
 	g.Status = status
 }
 
-func (g *Game) SetRoundStatus(status valueobjects.RoundStatus) {
-	g.Lock()
-	defer g.Unlock()
+// SetRoundStatus moves the round to the given status if the transition table
+// allows it.
+func (g *Game) SetRoundStatus(status valueobjects.RoundStatus) error {
+	if err := g.RoundStatus.ValidateTransition(status); err != nil {
+		return fmt.Errorf("could not change round status of game %s: %w", g.ID, err)
+	}
 
 	g.RoundStatus = status
+
+	return nil
 }
 
 func (g *Game) SetRoundWinner(player *Player) {
-	g.Lock()
-	defer g.Unlock()
-
 	g.RoundWinner = player
 }
 
 func (g *Game) SetLastEventAt(t time.Time) {
-	g.Lock()
-	defer g.Unlock()
-
 	g.LastEventAt = t
 }
 
 func (g *Game) SetNextAutoProgressAt(t time.Time) {
-	g.Lock()
-	defer g.Unlock()
-
 	g.NextAutoProgressAt = t
 }
 
 func (g *Game) SetBlackCard(card *entities.Card) {
-	g.Lock()
-	defer g.Unlock()
-
 	g.BlackCard = card
 }
 
-func (g *Game) SetWhiteCards(cards []*entities.Card) {
-	g.Lock()
-	defer g.Unlock()
-
-	g.WhiteCards = cards
+func (g *Game) SetSubmissions(submissions []*entities.Submission) {
+	g.Submissions = submissions
 }
 
 func (g *Game) IncrementGameRound() {
-	g.Lock()
-	defer g.Unlock()
-
 	g.CurrentGameRound++
 }
 
 func (g *Game) HasPlayers() bool {
-	g.Lock()
-	defer g.Unlock()
-
 	if g.Players == nil {
 		return false
 	}
@@ -423,30 +971,60 @@ func (g *Game) HasPlayers() bool {
 }
 
 func (g *Game) HasCollection() bool {
-	g.Lock()
-	defer g.Unlock()
-
 	return g.Collection != nil
 }
 
 func (g *Game) IsInProgress() bool {
-	g.Lock()
-	defer g.Unlock()
-
 	return g.Status == valueobjects.InProgress
 }
 
 func (g *Game) IsInSetup() bool {
-	g.Lock()
-	defer g.Unlock()
-
 	return g.Status == valueobjects.Setup
 }
 
-func (g *Game) AddPlayer(player *Player) error {
-	g.Lock()
-	defer g.Unlock()
+func (g *Game) IsFinished() bool {
+	return g.Status == valueobjects.Finished
+}
+
+// IsPaused reports whether the owner has paused the game. Round timers are
+// frozen until it is resumed.
+func (g *Game) IsPaused() bool {
+	return !g.PausedAt.IsZero()
+}
+
+// Settings returns the game's current settings.
+func (g *Game) Settings() GameSettings {
+	return GameSettings{
+		Name:           g.Name,
+		WinnerCount:    g.WinnerCount,
+		MaxPlayerCount: g.MaxPlayerCount,
+		HandSize:       g.HandSize,
+		Timers:         g.Timers,
+		HouseRules:     g.HouseRules,
+		Visibility:     g.Visibility,
+		InviteCode:     g.InviteCode,
+		PasswordHash:   g.PasswordHash,
+		AutoStart:      g.AutoStart,
+	}
+}
+
+func (g *Game) IsPrivate() bool {
+	return g.Visibility.IsPrivate()
+}
+
+// IsVisibleTo reports whether the user can see and join the game. Private
+// games are only visible to those in them and those who accepted an invite.
+func (g *Game) IsVisibleTo(userId string) bool {
+	if !g.IsPrivate() || slices.Contains(g.InvitedUserIDs, userId) {
+		return true
+	}
 
+	_, err := g.FindPlayerOrSpectator(userId)
+
+	return err == nil
+}
+
+func (g *Game) AddPlayer(player *Player) error {
 	if player == nil {
 		return fmt.Errorf("could not add nil player to game")
 	}
@@ -461,9 +1039,6 @@ func (g *Game) AddPlayer(player *Player) error {
 }
 
 func (g *Game) RemovePlayer(player *Player) error {
-	g.Lock()
-	defer g.Unlock()
-
 	if !g.HasPlayers() {
 		return fmt.Errorf("could not remove player, no players exist")
 	}
@@ -478,10 +1053,12 @@ func (g *Game) RemovePlayer(player *Player) error {
 	return fmt.Errorf("could not remove player, player not found")
 }
 
-func (g *Game) RemoveWasJudgeFromAllPlayers() error {
-	g.Lock()
-	defer g.Unlock()
+// IsBanned reports whether the owner has banned the user from the game.
+func (g *Game) IsBanned(userId string) bool {
+	return slices.Contains(g.BannedUserIDs, userId)
+}
 
+func (g *Game) RemoveWasJudgeFromAllPlayers() error {
 	if !g.HasPlayers() {
 		return fmt.Errorf("could not remove judge from all players, no players exist")
 	}
@@ -494,19 +1071,14 @@ func (g *Game) RemoveWasJudgeFromAllPlayers() error {
 }
 
 func (g *Game) ClearBoard() {
-	g.Lock()
-	defer g.Unlock()
-
-	g.SetWhiteCards([]*entities.Card{})
+	g.SetSubmissions([]*entities.Submission{})
+	g.Votes = map[string]string{}
 	g.SetBlackCard(nil)
-	g.SetRoundStatus(valueobjects.Waiting)
 	g.SetRoundWinner(nil)
+	g.WinningSubmission = nil
 }
 
 func (g *Game) PickNewBlackCard() error {
-	g.Lock()
-	defer g.Unlock()
-
 	if !g.HasCollection() {
 		return fmt.Errorf("could not pick new black card because collection is nil")
 	}
@@ -522,7 +1094,7 @@ func (g *Game) FindNewJudge() (*Player, error) {
 	}
 
 	for _, player := range g.Players {
-		if !player.WasJudge && !player.IsJudge {
+		if !player.WasJudge && !player.IsJudge && !player.IsBot {
 			return player, nil
 		}
 	}
@@ -544,12 +1116,10 @@ func (g *Game) FindCurrentJudge() (*Player, error) {
 	return nil, fmt.Errorf("could not find current judge in game %s", g.ID)
 }
 
+// WasAllPlayersJudge reports whether every player who can judge has had a turn.
 func (g *Game) WasAllPlayersJudge() bool {
-	g.Lock()
-	defer g.Unlock()
-
 	for _, player := range g.Players {
-		if !player.WasJudge {
+		if !player.WasJudge && !player.IsBot {
 			return false
 		}
 	}
@@ -558,9 +1128,6 @@ func (g *Game) WasAllPlayersJudge() bool {
 }
 
 func (g *Game) PickNewJudge() error {
-	g.Lock()
-	defer g.Unlock()
-
 	if !g.HasPlayers() {
 		return fmt.Errorf("could not pick a new judge, no player length")
 	}
@@ -584,10 +1151,17 @@ func (g *Game) PickNewJudge() error {
 	return nil
 }
 
-func (g *Game) FindPlayerByUserId(userId string) (*Player, error) {
-	g.Lock()
-	defer g.Unlock()
+func (g *Game) FindOwner() *Player {
+	for _, player := range g.Players {
+		if player.IsOwner {
+			return player
+		}
+	}
+
+	return nil
+}
 
+func (g *Game) FindPlayerByUserId(userId string) (*Player, error) {
 	if !g.HasPlayers() {
 		return nil, fmt.Errorf("could not find player by user id in game %s, no players in game", g.ID)
 	}
@@ -601,10 +1175,64 @@ func (g *Game) FindPlayerByUserId(userId string) (*Player, error) {
 	return nil, fmt.Errorf("could not find player by user id because user id %s does not exist in game %s", userId, g.ID)
 }
 
-func (g *Game) FindCardByPlayerId(playerId string, cardId string) (*entities.Card, error) {
-	g.Lock()
-	defer g.Unlock()
+func (g *Game) FindSpectator(userId string) (*Player, error) {
+	for _, spectator := range g.Spectators {
+		if spectator.UserID == userId {
+			return spectator, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find spectator because user id %s is not watching game %s", userId, g.ID)
+}
+
+// FindPlayerOrSpectator finds anyone in the game, whether they have a seat or
+// are watching.
+func (g *Game) FindPlayerOrSpectator(userId string) (*Player, error) {
+	if player, err := g.FindPlayerByUserId(userId); err == nil {
+		return player, nil
+	}
+
+	return g.FindSpectator(userId)
+}
+
+func (g *Game) RemoveSpectator(userId string) error {
+	for i, spectator := range g.Spectators {
+		if spectator.UserID == userId {
+			g.Spectators = append(g.Spectators[:i], g.Spectators[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("could not remove spectator, user id %s is not watching", userId)
+}
+
+// GetPromotedSpectators returns the spectators waiting to take a seat at the
+// next round boundary.
+func (g *Game) GetPromotedSpectators() []*Player {
+	promoted := []*Player{}
+
+	for _, spectator := range g.Spectators {
+		if spectator.IsPromoted {
+			promoted = append(promoted, spectator)
+		}
+	}
+
+	return promoted
+}
+
+// HasOpenSeat reports whether another spectator can be promoted, counting
+// the seats already promised to promoted spectators.
+func (g *Game) HasOpenSeat() bool {
+	return g.MaxPlayerCount <= 0 || len(g.GetHumanPlayers())+len(g.GetPromotedSpectators()) < g.MaxPlayerCount
+}
 
+// IsAtRoundBoundary reports whether players can be seated without disturbing
+// a round, which is before the game begins and between rounds.
+func (g *Game) IsAtRoundBoundary() bool {
+	return g.IsInSetup() || (g.IsInProgress() && g.RoundStatus.CanContinueRound())
+}
+
+func (g *Game) FindCardByPlayerId(playerId string, cardId string) (*entities.Card, error) {
 	player, err := g.FindPlayerByUserId(playerId)
 
 	if err != nil {
@@ -621,28 +1249,32 @@ func (g *Game) FindCardByPlayerId(playerId string, cardId string) (*entities.Car
 	return nil, fmt.Errorf("card not found in player's deck")
 }
 
-func (g *Game) FindWhiteCardByCardId(cardId string) (*entities.Card, error) {
-	g.Lock()
-	defer g.Unlock()
-
-	for _, card := range g.WhiteCards {
-		if card.ID == cardId {
-			return card, nil
+func (g *Game) FindSubmission(submissionId string) (*entities.Submission, error) {
+	for _, submission := range g.Submissions {
+		if submission.ID == submissionId {
+			return submission, nil
 		}
 	}
 
-	return nil, fmt.Errorf("card not found in cards")
+	return nil, fmt.Errorf("submission not found on the board")
 }
 
-func (g *Game) AddWhiteCardToGameBoard(card *entities.Card) error {
-	g.Lock()
-	defer g.Unlock()
+func (g *Game) FindSubmissionByCardId(cardId string) (*entities.Submission, error) {
+	for _, submission := range g.Submissions {
+		if submission.ContainsCard(cardId) {
+			return submission, nil
+		}
+	}
 
-	if card == nil {
-		return fmt.Errorf("could not add nil card to game board")
+	return nil, fmt.Errorf("card not found in any submission")
+}
+
+func (g *Game) AddSubmission(submission *entities.Submission) error {
+	if submission == nil || len(submission.Cards) == 0 {
+		return fmt.Errorf("could not add empty submission to game board")
 	}
 
-	g.WhiteCards = append(g.WhiteCards, card)
+	g.Submissions = append(g.Submissions, submission)
 
 	return nil
 }
@@ -653,7 +1285,7 @@ func (g *Game) HasAllPlayersPlayedWhiteCard() (bool, error) {
 	}
 
 	for _, player := range g.Players {
-		if player.PlacedCard == nil && !player.IsJudge {
+		if !player.HasAlreadyPlayedWhiteCard() && !player.IsJudge {
 			return false, nil
 		}
 	}
@@ -661,22 +1293,180 @@ func (g *Game) HasAllPlayersPlayedWhiteCard() (bool, error) {
 	return true, nil
 }
 
-func (g *Game) FindWhiteCardOwner(card *entities.Card) (*Player, error) {
-	if card == nil {
-		return nil, fmt.Errorf("could not find white card owner because card is nil")
+// advanceIfAllPlayed moves the round on to judging, or to voting in a
+// democracy, once everyone who has to play a card has.
+func (g *Game) advanceIfAllPlayed() error {
+	if g.RoundStatus != valueobjects.PlayersPickingCard || len(g.Submissions) == 0 {
+		return nil
 	}
 
-	for _, player := range g.Players {
-		if player.IsJudge {
+	hasAllPlayersPlayedWhiteCard, err := g.HasAllPlayersPlayedWhiteCard()
+
+	if err != nil || !hasAllPlayersPlayedWhiteCard {
+		return err
+	}
+
+	nextStatus := valueobjects.JudgePickingWinningCard
+
+	if g.IsDemocracy() {
+		nextStatus = valueobjects.PlayersVoting
+	}
+
+	return g.SetRoundStatus(nextStatus)
+}
+
+// removeSubmissionsBy takes a departed player's submission off the board,
+// along with their vote and any votes for their submission.
+func (g *Game) removeSubmissionsBy(userId string) {
+	submissions := []*entities.Submission{}
+
+	for _, submission := range g.Submissions {
+		if submission.UserID != userId {
+			submissions = append(submissions, submission)
 			continue
 		}
 
-		if player.PlacedCard.ID == card.ID {
+		for voter, submissionID := range g.Votes {
+			if submissionID == submission.ID {
+				delete(g.Votes, voter)
+			}
+		}
+	}
+
+	g.Submissions = submissions
+	delete(g.Votes, userId)
+}
+
+// FindNewOwner returns the player ownership passes to when the owner leaves,
+// preferring players who are still connected.
+func (g *Game) FindNewOwner() *Player {
+	var fallback *Player
+
+	for _, player := range g.GetHumanPlayers() {
+		if player.IsOwner {
+			continue
+		}
+
+		if !player.IsDisconnected {
+			return player
+		}
+
+		if fallback == nil {
+			fallback = player
+		}
+	}
+
+	return fallback
+}
+
+func (g *Game) FindSubmissionOwner(submission *entities.Submission) (*Player, error) {
+	if submission == nil {
+		return nil, fmt.Errorf("could not find submission owner because submission is nil")
+	}
+
+	for _, player := range g.Players {
+		if player.UserID == submission.UserID && !player.IsJudge {
 			return player, nil
 		}
 	}
 
-	return nil, fmt.Errorf("could not find white card owner")
+	return nil, fmt.Errorf("could not find submission owner")
+}
+
+// RedrawHand discards the player's hand and replaces it with the given cards.
+// Hands can only be redrawn while the player still has a card to pick.
+func (g *Game) RedrawHand(player *Player, cardIDs []string) error {
+	if g.RoundStatus != valueobjects.PlayersPickingCard {
+		return fmt.Errorf("could not redraw hand while round status is %s", g.RoundStatus)
+	}
+
+	if player.HasAlreadyPlayedWhiteCard() {
+		return fmt.Errorf("could not redraw hand, player %s has already played", player.UserID)
+	}
+
+	cards := make([]*entities.Card, 0, len(cardIDs))
+
+	for _, cardID := range cardIDs {
+		card := g.Collection.FindCardByID(cardID)
+
+		if card == nil {
+			return fmt.Errorf("could not draw card %s, it is not in the collection", cardID)
+		}
+
+		cards = append(cards, card)
+	}
+
+	player.Deck = cards
+
+	for _, card := range cards {
+		g.MarkCardAsUsed(card)
+	}
+
+	return nil
+}
+
+func (g *Game) IsDemocracy() bool {
+	return g.Mode.IsDemocracy()
+}
+
+// GetVoters returns the players who vote in a democracy round. Bots play
+// cards but never vote.
+func (g *Game) GetVoters() []*Player {
+	return g.GetHumanPlayers()
+}
+
+func (g *Game) HasVoted(userId string) bool {
+	_, voted := g.Votes[userId]
+
+	return voted
+}
+
+func (g *Game) HasAllPlayersVoted() bool {
+	for _, voter := range g.GetVoters() {
+		if !g.HasVoted(voter.UserID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CountVotes returns how many votes each submission on the board received.
+func (g *Game) CountVotes() map[string]int {
+	counts := make(map[string]int)
+
+	for _, submissionID := range g.Votes {
+		counts[submissionID]++
+	}
+
+	return counts
+}
+
+// GetMostVotedSubmissions returns every submission that received the most
+// votes, in board order. Nothing is returned when no votes were cast.
+func (g *Game) GetMostVotedSubmissions() []*entities.Submission {
+	counts := g.CountVotes()
+	mostVotes := 0
+
+	for _, count := range counts {
+		if count > mostVotes {
+			mostVotes = count
+		}
+	}
+
+	mostVoted := []*entities.Submission{}
+
+	if mostVotes == 0 {
+		return mostVoted
+	}
+
+	for _, submission := range g.Submissions {
+		if counts[submission.ID] == mostVotes {
+			mostVoted = append(mostVoted, submission)
+		}
+	}
+
+	return mostVoted
 }
 
 func (g *Game) CheckForWinner() *Player {
@@ -689,12 +1479,80 @@ func (g *Game) CheckForWinner() *Player {
 }
 
 func (g *Game) ApplyEvent(event *GameEvent) error {
-	g.Lock()
-	defer g.Unlock()
+	if event.Version != g.Version+1 {
+		return fmt.Errorf("could not apply event %s to game %s, event version is %d but expected %d", event.ID, g.ID, event.Version, g.Version+1)
+	}
+
+	// An event that fails part way through must not leave half of itself
+	// behind, so the game goes back to how it was before the event
+	before := g.Clone()
+
+	if err := g.applyEvent(event); err != nil {
+		*g = *before
+		return err
+	}
 
 	g.SetLastEventAt(event.CreatedAt)
+	g.LastEventID = event.ID
+	g.Version = event.Version
 
+	return nil
+}
+
+// applyEvent changes the game according to a single event. It may stop part
+// way through an event it rejects, ApplyEvent puts the game back afterwards.
+func (g *Game) applyEvent(event *GameEvent) error {
 	switch event.Type {
+	case EventGameCreated:
+		var payload GameEventPayloadGameCreated
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventGameCreated payload: %w", err)
+		}
+
+		if g.Collection != nil {
+			return fmt.Errorf("could not create game ID %s, game was already created", payload.GameID)
+		}
+
+		if payload.Collection == nil {
+			return fmt.Errorf("could not create game ID %s, collection is nil", payload.GameID)
+		}
+
+		owner, err := NewPlayer(payload.Owner)
+
+		if err != nil {
+			return fmt.Errorf("could not create game ID %s, error creating owner: %w", payload.GameID, err)
+		}
+
+		owner.ID = payload.OwnerPlayerID
+		owner.SetIsOwner(true)
+
+		g.ID = payload.GameID
+		g.Name = payload.Name
+		g.Collection = payload.Collection
+		g.WinnerCount = payload.WinnerCount
+		g.MaxPlayerCount = payload.MaxPlayerCount
+		g.MinPlayerCount = payload.MinPlayerCount
+		g.HandSize = payload.HandSize
+		g.Mode = payload.Mode
+		g.TieRule = payload.TieRule
+		g.HouseRules = payload.HouseRules
+		g.Visibility = payload.Visibility
+		g.InviteCode = payload.InviteCode
+		g.PasswordHash = payload.PasswordHash
+		g.BannedUserIDs = payload.BannedUserIDs
+		g.Timers = payload.Timers
+		g.AutoStart = payload.AutoStart
+		g.Status = valueobjects.Setup
+		g.Players = []*Player{owner}
+		g.Submissions = []*entities.Submission{}
+		g.Votes = map[string]string{}
+		g.UsedCards = []*entities.Card{}
+		g.RoundStatus = valueobjects.Waiting
+		g.LastVacatedAt = event.CreatedAt
+		g.NextAutoProgressAt = event.CreatedAt
+		g.CreatedAt = event.CreatedAt
+		g.UpdatedAt = event.CreatedAt
 	case EventGameBegins:
 		var payload events.GameEventPayloadGameBegins
 
@@ -702,7 +1560,14 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 			return fmt.Errorf("failed to unmarshal EventGameBegins payload: %w", err)
 		}
 
-		g.SetRoundStatus(valueobjects.PlayersPickingCard)
+		if !g.IsInSetup() {
+			return fmt.Errorf("could not begin game %s, game status is %s", g.ID, g.Status)
+		}
+
+		if err := g.SetRoundStatus(valueobjects.PlayersPickingCard); err != nil {
+			return err
+		}
+
 		g.SetStatus(valueobjects.InProgress)
 	case EventShuffle:
 		var payload GameEventPayloadShuffle
@@ -713,6 +1578,16 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 
 		g.ClearUsedCards()
 		g.Collection.ShuffleWithSeed(payload.Seed)
+
+		// Only discarded cards go back into the draw pile
+		seen := make(map[string]bool)
+
+		for _, card := range g.heldCards() {
+			if !seen[card.ID] {
+				seen[card.ID] = true
+				g.MarkCardAsUsed(card)
+			}
+		}
 	case EventDealCards:
 		var payload GameEventPayloadDealCards
 
@@ -720,17 +1595,21 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 			return fmt.Errorf("failed to unmarshal EventDealCards payload: %w", err)
 		}
 
-		for _, player := range g.Players {
-			if player.UserID == payload.PlayerID {
-				player.Deck = []*entities.Card{}
-				for _, cardID := range payload.CardIDs {
-					card := g.Collection.FindCardByID(cardID)
-					if card != nil {
-						player.Deck = append(player.Deck, card)
-					}
-				}
-				break
+		player, err := g.FindPlayerByUserId(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not deal cards: %w", err)
+		}
+
+		for _, cardID := range payload.CardIDs {
+			card := g.Collection.FindCardByID(cardID)
+
+			if card == nil {
+				return fmt.Errorf("could not deal card %s, it is not in the collection", cardID)
 			}
+
+			player.Deck = append(player.Deck, card)
+			g.MarkCardAsUsed(card)
 		}
 	case EventDrawBlackCard:
 		var payload GameEventPayloadDrawBlackCard
@@ -743,6 +1622,7 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 
 		if card != nil {
 			g.SetBlackCard(card)
+			g.MarkCardAsUsed(card)
 		}
 	case EventSetJudge:
 		var payload GameEventPayloadSetJudge
@@ -775,11 +1655,32 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 			return fmt.Errorf("could not join game ID %s, error creating player: %w", payload.GameID, err)
 		}
 
+		// Events written before player ids were recorded get a fresh one
+		if payload.PlayerID != "" {
+			player.ID = payload.PlayerID
+		}
+
 		if g.Players == nil {
 			return fmt.Errorf("could not join game ID %s, game players is nil", payload.GameID)
 		}
 
 		g.Players = append(g.Players, player)
+	case EventBotJoined:
+		var payload GameEventPayloadBotJoined
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventBotJoined payload: %w", err)
+		}
+
+		if g.Players == nil {
+			return fmt.Errorf("could not add bot to game ID %s, game players is nil", payload.GameID)
+		}
+
+		if _, err := g.FindPlayerByUserId(payload.UserID); err == nil {
+			return fmt.Errorf("could not add bot to game ID %s, %s is already playing", payload.GameID, payload.UserID)
+		}
+
+		g.Players = append(g.Players, NewBotPlayer(payload.PlayerID, payload.UserID, payload.Name))
 	case EventRoundContinued:
 		var payload GameEventPayloadGameRoundContinuedWithCards
 
@@ -787,54 +1688,67 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 			return fmt.Errorf("failed to unmarshal EventGameBegins payload: %w", err)
 		}
 
+		// Voided rounds also go back to picking, but through EventRoundVoided
+		if !g.RoundStatus.CanContinueRound() {
+			return fmt.Errorf("could not continue round while round status is %s", g.RoundStatus)
+		}
+
 		for _, player := range g.Players {
+			player.IsRoundWinner = false
+
 			if player.IsJudge {
 				continue
 			}
 
-			player.RemovePlacedCard()
+			player.ClearPlacedCards()
 
-			// Check if this player should get a new card
+			// Rounds continued before hands were topped up with EventDealCards
+			// hand out their replacement card here
 			if cardID, exists := payload.PlayerCards[player.UserID]; exists {
 				// Find the card in the collection
 				for _, card := range g.Collection.Cards {
 					if card.ID == cardID {
 						player.Deck = append(player.Deck, card)
+						g.MarkCardAsUsed(card)
 						break
 					}
 				}
 			}
 		}
 
-		judge, err := g.FindCurrentJudge()
+		// Democracy games have no judge to pass on. The judge may also have
+		// left since picking the winner
+		if !g.IsDemocracy() {
+			if judge, err := g.FindCurrentJudge(); err == nil {
+				judge.SetIsJudge(false)
+				judge.SetWasJudge(true)
+			}
 
-		if err != nil {
-			return fmt.Errorf("could not continue round: %w", err)
-		}
+			err := g.PickNewJudge()
 
-		judge.SetIsJudge(false)
-		judge.SetWasJudge(true)
+			if err != nil {
+				return fmt.Errorf("could not pick new judge: %w", err)
+			}
+		}
 
 		g.SetRoundWinner(nil)
 		g.ClearBoard()
 
-		err = g.PickNewJudge()
-
-		if err != nil {
-			return fmt.Errorf("could not pick new judge: %w", err)
-		}
-
 		if payload.BlackCardID != "" {
 			for _, card := range g.Collection.Cards {
 				if card.ID == payload.BlackCardID {
 					g.SetBlackCard(card)
+					g.MarkCardAsUsed(card)
 					break
 				}
 			}
 		}
 
 		g.IncrementGameRound()
-		g.SetRoundStatus(valueobjects.PlayersPickingCard)
+
+		if err := g.SetRoundStatus(valueobjects.PlayersPickingCard); err != nil {
+			return err
+		}
 
 	case EventJudgeChoseWinningCard:
 		var payload GameEventPayloadJudgeChoseWinningCard
@@ -843,21 +1757,37 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 			return fmt.Errorf("failed to unmarshal EventJudgeChoseWinningCard payload: %w", err)
 		}
 
-		winningCard, err := g.FindWhiteCardByCardId(payload.CardID)
+		if !g.RoundStatus.CanPickWinningCard() {
+			return fmt.Errorf("could not choose winning card while round status is %s", g.RoundStatus)
+		}
+
+		var winningSubmission *entities.Submission
+		var err error
+
+		if payload.SubmissionID != "" {
+			winningSubmission, err = g.FindSubmission(payload.SubmissionID)
+		} else {
+			winningSubmission, err = g.FindSubmissionByCardId(payload.CardID)
+		}
 
 		if err != nil {
-			return fmt.Errorf("could not find winning card: %w", err)
+			return fmt.Errorf("could not find winning submission: %w", err)
 		}
 
-		winner, err := g.FindWhiteCardOwner(winningCard)
+		winner, err := g.FindSubmissionOwner(winningSubmission)
 
 		if err != nil {
-			return fmt.Errorf("could not find white card owner: %w", err)
+			return fmt.Errorf("could not find winning submission owner: %w", err)
+		}
+
+		if err := g.SetRoundStatus(valueobjects.JudgeChoseWinningCard); err != nil {
+			return err
 		}
 
 		winner.IncrementScore()
-		g.SetRoundStatus(valueobjects.JudgeChoseWinningCard)
+		winner.IsRoundWinner = true
 		g.SetRoundWinner(winner)
+		g.WinningSubmission = winningSubmission
 
 	case EventCardPlayed:
 		var payload GameEventPayloadPlayCard
@@ -866,41 +1796,151 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 			return fmt.Errorf("failed to unmarshal EventCardPlayed payload: %w", err)
 		}
 
-		fmt.Printf("EventCardPlayed: UserID=%s, CardID=%s\n", payload.Claim.UserID, payload.CardID)
+		if !g.RoundStatus.CanPlayCards() {
+			return fmt.Errorf("unable to play white card while round status is %s", g.RoundStatus)
+		}
 
-		player, _ := g.FindPlayerByUserId(payload.Claim.UserID)
-		card, _ := g.FindCardByPlayerId(payload.Claim.UserID, payload.CardID)
+		player, err := g.FindPlayerByUserId(payload.Claim.UserID)
 
-		fmt.Printf("Found player: %s, Found card: %s\n", player.UserID, card.ID)
+		if err != nil {
+			return fmt.Errorf("unable to play white card: %w", err)
+		}
 
-		err := player.RemoveCardFromDeck(card.ID)
+		if player.HasAlreadyPlayedWhiteCard() {
+			return fmt.Errorf("unable to play white card, player %s has already played", player.UserID)
+		}
+
+		cardIDs := payload.CardIDs
+
+		if len(cardIDs) == 0 && payload.CardID != "" {
+			cardIDs = []string{payload.CardID}
+		}
+
+		// Every card is looked up before any leaves the hand
+		cards := make([]*entities.Card, 0, len(cardIDs))
+
+		for _, cardID := range cardIDs {
+			card, err := g.FindCardByPlayerId(payload.Claim.UserID, cardID)
+
+			if err != nil {
+				return fmt.Errorf("unable to play white card: %w", err)
+			}
+
+			cards = append(cards, card)
+		}
+
+		if len(cards) == 0 {
+			return fmt.Errorf("unable to play white card, no cards given")
+		}
+
+		for _, card := range cards {
+			if err := player.RemoveCardFromDeck(card.ID); err != nil {
+				return fmt.Errorf("unable to play white card: %w", err)
+			}
+		}
+
+		err = player.PlaceCards(cards)
 
 		if err != nil {
 			return fmt.Errorf("unable to play white card: %w", err)
 		}
 
-		err = player.SetCardAsPlacedCard(card)
+		// Events from before submissions are identified by the event itself
+		submissionID := payload.SubmissionID
+
+		if submissionID == "" {
+			submissionID = event.ID
+		}
+
+		err = g.AddSubmission(entities.NewSubmission(submissionID, player.UserID, cards))
 
 		if err != nil {
 			return fmt.Errorf("unable to play white card: %w", err)
 		}
 
-		err = g.AddWhiteCardToGameBoard(card)
+		if err := g.advanceIfAllPlayed(); err != nil {
+			return fmt.Errorf("unable to play white card: %w", err)
+		}
+	case EventVoteCast:
+		var payload GameEventPayloadVoteCast
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventVoteCast payload: %w", err)
+		}
+
+		if !g.RoundStatus.CanVote() {
+			return fmt.Errorf("could not vote while round status is %s", g.RoundStatus)
+		}
+
+		voter, err := g.FindPlayerByUserId(payload.PlayerID)
 
 		if err != nil {
-			return fmt.Errorf("unable to play white card: %w", err)
+			return fmt.Errorf("could not vote: %w", err)
 		}
 
-		fmt.Printf("Added card to game board. WhiteCards count: %d\n", len(g.WhiteCards))
+		if g.HasVoted(voter.UserID) {
+			return fmt.Errorf("could not vote, player %s has already voted", voter.UserID)
+		}
 
-		hasPlayersPlayedWhiteCard, err := g.HasAllPlayersPlayedWhiteCard()
+		submission, err := g.FindSubmission(payload.SubmissionID)
 
 		if err != nil {
-			return fmt.Errorf("unable to play white card: %w", err)
+			return fmt.Errorf("could not vote: %w", err)
+		}
+
+		if submission.UserID == voter.UserID {
+			return fmt.Errorf("could not vote, player %s voted for their own submission", voter.UserID)
+		}
+
+		if g.Votes == nil {
+			g.Votes = map[string]string{}
 		}
 
-		if hasPlayersPlayedWhiteCard {
-			g.SetRoundStatus(valueobjects.JudgePickingWinningCard)
+		g.Votes[voter.UserID] = submission.ID
+	case EventVotesCounted:
+		var payload GameEventPayloadVotesCounted
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventVotesCounted payload: %w", err)
+		}
+
+		if !g.RoundStatus.CanVote() {
+			return fmt.Errorf("could not count votes while round status is %s", g.RoundStatus)
+		}
+
+		winners := make([]*Player, 0, len(payload.WinningSubmissionIDs))
+		winningSubmissions := make([]*entities.Submission, 0, len(payload.WinningSubmissionIDs))
+
+		for _, submissionID := range payload.WinningSubmissionIDs {
+			submission, err := g.FindSubmission(submissionID)
+
+			if err != nil {
+				return fmt.Errorf("could not count votes: %w", err)
+			}
+
+			winner, err := g.FindSubmissionOwner(submission)
+
+			if err != nil {
+				return fmt.Errorf("could not count votes: %w", err)
+			}
+
+			winners = append(winners, winner)
+			winningSubmissions = append(winningSubmissions, submission)
+		}
+
+		if err := g.SetRoundStatus(valueobjects.JudgeChoseWinningCard); err != nil {
+			return err
+		}
+
+		for _, winner := range winners {
+			winner.IncrementScore()
+			winner.IsRoundWinner = true
+		}
+
+		// A tie shows the first winning submission as the round's winner
+		if len(winners) > 0 {
+			g.SetRoundWinner(winners[0])
+			g.WinningSubmission = winningSubmissions[0]
 		}
 	case EventGameWinner:
 		var payload GameEventPayloadGameWinner
@@ -909,6 +1949,10 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 			return fmt.Errorf("failed to unmarshal EventGameWinner payload: %w", err)
 		}
 
+		if err := g.SetRoundStatus(valueobjects.GameOver); err != nil {
+			return fmt.Errorf("could not declare game winner: %w", err)
+		}
+
 		// Find the winning player and mark them as game winner
 		for _, player := range g.Players {
 			if player.UserID == payload.PlayerID {
@@ -917,9 +1961,60 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 			}
 		}
 
-		g.SetRoundStatus(valueobjects.GameOver)
+		for _, shamedPlayerID := range payload.ShamedPlayerIDs {
+			if player, err := g.FindPlayerByUserId(shamedPlayerID); err == nil {
+				player.IsShamed = true
+			}
+		}
+
 		g.SetStatus(valueobjects.Finished)
 
+	case EventUniverseRebooted:
+		var payload GameEventPayloadRedrawHand
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventUniverseRebooted payload: %w", err)
+		}
+
+		player, err := g.FindPlayerByUserId(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not reboot the universe: %w", err)
+		}
+
+		if player.Score < 1 {
+			return fmt.Errorf("could not reboot the universe, player %s has no points to trade in", player.UserID)
+		}
+
+		if err := g.RedrawHand(player, payload.CardIDs); err != nil {
+			return fmt.Errorf("could not reboot the universe: %w", err)
+		}
+
+		if err := player.DecrementScore(); err != nil {
+			return fmt.Errorf("could not reboot the universe: %w", err)
+		}
+	case EventHandMulliganed:
+		var payload GameEventPayloadRedrawHand
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventHandMulliganed payload: %w", err)
+		}
+
+		player, err := g.FindPlayerByUserId(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not mulligan: %w", err)
+		}
+
+		if player.HasMulliganed {
+			return fmt.Errorf("could not mulligan, player %s has already taken their mulligan", player.UserID)
+		}
+
+		if err := g.RedrawHand(player, payload.CardIDs); err != nil {
+			return fmt.Errorf("could not mulligan: %w", err)
+		}
+
+		player.HasMulliganed = true
 	case EventClockUpdate:
 		var payload GameEventPayloadClockUpdate
 
@@ -928,6 +2023,332 @@ func (g *Game) ApplyEvent(event *GameEvent) error {
 		}
 
 		g.NextAutoProgressAt = payload.NextAutoProgressAt
+	case EventPlayerDisconnected:
+		var payload GameEventPayloadPlayerConnection
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventPlayerDisconnected payload: %w", err)
+		}
+
+		player, err := g.FindPlayerByUserId(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not disconnect player: %w", err)
+		}
+
+		player.IsDisconnected = true
+		player.DisconnectedAt = event.CreatedAt
+	case EventPlayerReconnected:
+		var payload GameEventPayloadPlayerConnection
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventPlayerReconnected payload: %w", err)
+		}
+
+		player, err := g.FindPlayerByUserId(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not reconnect player: %w", err)
+		}
+
+		player.IsDisconnected = false
+		player.DisconnectedAt = time.Time{}
+	case EventPlayerLeft, EventPlayerKicked:
+		var payload GameEventPayloadPlayerLeft
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal Event%s payload: %w", event.Type, err)
+		}
+
+		player, err := g.FindPlayerByUserId(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not remove player: %w", err)
+		}
+
+		if err := g.RemovePlayer(player); err != nil {
+			return fmt.Errorf("could not remove player: %w", err)
+		}
+
+		g.removeSubmissionsBy(player.UserID)
+
+		if len(g.GetHumanPlayers()) == 0 {
+			g.LastVacatedAt = event.CreatedAt
+		}
+
+		// The round waits on nobody once the last player still picking leaves,
+		// unless it was the judge, whose round is voided instead
+		if _, err := g.FindCurrentJudge(); err == nil || g.IsDemocracy() {
+			if err := g.advanceIfAllPlayed(); err != nil {
+				return fmt.Errorf("could not remove player: %w", err)
+			}
+		}
+	case EventOwnerChanged:
+		var payload GameEventPayloadOwnerChanged
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventOwnerChanged payload: %w", err)
+		}
+
+		owner, err := g.FindPlayerByUserId(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not change owner: %w", err)
+		}
+
+		for _, player := range g.Players {
+			player.SetIsOwner(false)
+		}
+
+		owner.SetIsOwner(true)
+	case EventRoundVoided:
+		var payload GameEventPayloadRoundVoided
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventRoundVoided payload: %w", err)
+		}
+
+		if !g.RoundStatus.CanVoidRound() {
+			return fmt.Errorf("could not void round while round status is %s", g.RoundStatus)
+		}
+
+		blackCard := g.Collection.FindCardByID(payload.BlackCardID)
+
+		if blackCard == nil {
+			return fmt.Errorf("could not void round, black card %s not found", payload.BlackCardID)
+		}
+
+		for _, player := range g.Players {
+			player.Deck = append(player.Deck, player.PlacedCards...)
+			player.ClearPlacedCards()
+		}
+
+		g.ClearBoard()
+		g.SetBlackCard(blackCard)
+		g.MarkCardAsUsed(blackCard)
+
+		if _, err := g.FindCurrentJudge(); err != nil && !g.IsDemocracy() {
+			if err := g.PickNewJudge(); err != nil {
+				return fmt.Errorf("could not void round: %w", err)
+			}
+		}
+
+		if g.RoundStatus != valueobjects.PlayersPickingCard {
+			if err := g.SetRoundStatus(valueobjects.PlayersPickingCard); err != nil {
+				return err
+			}
+		}
+	case EventPlayerBanned:
+		var payload GameEventPayloadPlayerModerated
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventPlayerBanned payload: %w", err)
+		}
+
+		if g.IsBanned(payload.PlayerID) {
+			return fmt.Errorf("could not ban player, user %s is already banned", payload.PlayerID)
+		}
+
+		g.BannedUserIDs = append(g.BannedUserIDs, payload.PlayerID)
+	case EventPlayerMuted, EventPlayerUnmuted:
+		var payload GameEventPayloadPlayerModerated
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal Event%s payload: %w", event.Type, err)
+		}
+
+		player, err := g.FindPlayerOrSpectator(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not change whether player is muted: %w", err)
+		}
+
+		player.IsMuted = event.Type == EventPlayerMuted
+	case EventSpectatorJoined:
+		var payload GameEventPayloadJoinedGame
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventSpectatorJoined payload: %w", err)
+		}
+
+		if _, err := g.FindPlayerOrSpectator(payload.UserID); err == nil {
+			return fmt.Errorf("could not watch game ID %s, %s is already in the game", payload.GameID, payload.UserID)
+		}
+
+		spectator, err := NewSpectator(payload.Claim)
+
+		if err != nil {
+			return fmt.Errorf("could not watch game ID %s, error creating spectator: %w", payload.GameID, err)
+		}
+
+		spectator.ID = payload.PlayerID
+
+		g.Spectators = append(g.Spectators, spectator)
+	case EventSpectatorLeft:
+		var payload GameEventPayloadPlayerLeft
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventSpectatorLeft payload: %w", err)
+		}
+
+		if err := g.RemoveSpectator(payload.PlayerID); err != nil {
+			return err
+		}
+	case EventSpectatorPromoted:
+		var payload GameEventPayloadPlayerModerated
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventSpectatorPromoted payload: %w", err)
+		}
+
+		spectator, err := g.FindSpectator(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not promote spectator: %w", err)
+		}
+
+		spectator.IsPromoted = true
+	case EventSpectatorSeated:
+		var payload GameEventPayloadPlayerModerated
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventSpectatorSeated payload: %w", err)
+		}
+
+		if !g.IsAtRoundBoundary() {
+			return fmt.Errorf("could not seat spectator while round status is %s", g.RoundStatus)
+		}
+
+		if g.IsFull() {
+			return fmt.Errorf("could not seat spectator, game ID %s is full", payload.GameID)
+		}
+
+		spectator, err := g.FindSpectator(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not seat spectator: %w", err)
+		}
+
+		if err := g.RemoveSpectator(spectator.UserID); err != nil {
+			return err
+		}
+
+		spectator.Role = valueobjects.Participant
+		spectator.IsPromoted = false
+
+		g.Players = append(g.Players, spectator)
+	case EventGamePaused:
+		if !g.IsInProgress() || g.IsPaused() {
+			return fmt.Errorf("could not pause game ID %s while game status is %s", g.ID, g.Status)
+		}
+
+		g.PausedAt = event.CreatedAt
+	case EventGameResumed:
+		if !g.IsPaused() {
+			return fmt.Errorf("could not resume game ID %s, it is not paused", g.ID)
+		}
+
+		// The round gets back the time it had left when it was paused
+		if !g.NextAutoProgressAt.IsZero() {
+			g.NextAutoProgressAt = g.NextAutoProgressAt.Add(event.CreatedAt.Sub(g.PausedAt))
+		}
+
+		g.PausedAt = time.Time{}
+	case EventRoundForceAdvanced:
+		var payload GameEventPayloadRoundForceAdvanced
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventRoundForceAdvanced payload: %w", err)
+		}
+
+		if !g.IsInProgress() || g.RoundStatus != payload.RoundStatus {
+			return fmt.Errorf("could not force the round on from %s while round status is %s", payload.RoundStatus, g.RoundStatus)
+		}
+	case EventRematchCreated:
+		var payload GameEventPayloadRematchCreated
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventRematchCreated payload: %w", err)
+		}
+
+		if !g.IsFinished() {
+			return fmt.Errorf("could not start a rematch of game ID %s while game status is %s", g.ID, g.Status)
+		}
+
+		if g.RematchGameID != "" {
+			return fmt.Errorf("could not start a rematch of game ID %s, rematch %s was already started", g.ID, g.RematchGameID)
+		}
+
+		g.RematchGameID = payload.RematchGameID
+	case EventInviteAccepted:
+		var payload GameEventPayloadInviteAccepted
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventInviteAccepted payload: %w", err)
+		}
+
+		if !g.IsPrivate() || payload.InviteCode != g.InviteCode {
+			return fmt.Errorf("could not accept invite to game ID %s, invite code %s is not the game's", g.ID, payload.InviteCode)
+		}
+
+		if !slices.Contains(g.InvitedUserIDs, payload.UserID) {
+			g.InvitedUserIDs = append(g.InvitedUserIDs, payload.UserID)
+		}
+	case EventInviteCodeRotated:
+		var payload GameEventPayloadInviteCodeRotated
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventInviteCodeRotated payload: %w", err)
+		}
+
+		if !g.IsPrivate() {
+			return fmt.Errorf("could not rotate invite code of game ID %s, it is not private", g.ID)
+		}
+
+		g.InviteCode = payload.InviteCode
+	case EventSettingsChanged:
+		var payload GameEventPayloadSettingsChanged
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal EventSettingsChanged payload: %w", err)
+		}
+
+		if !g.IsInSetup() {
+			return fmt.Errorf("could not change settings of game ID %s while game status is %s", g.ID, g.Status)
+		}
+
+		g.Name = payload.Settings.Name
+		g.WinnerCount = payload.Settings.WinnerCount
+		g.MaxPlayerCount = payload.Settings.MaxPlayerCount
+		g.HandSize = payload.Settings.HandSize
+		g.Timers = payload.Settings.Timers
+		g.HouseRules = payload.Settings.HouseRules
+		g.Visibility = payload.Settings.Visibility
+		g.InviteCode = payload.Settings.InviteCode
+		g.PasswordHash = payload.Settings.PasswordHash
+		g.AutoStart = payload.Settings.AutoStart
+
+		for _, player := range g.Players {
+			player.IsReady = false
+		}
+	case EventPlayerReady, EventPlayerUnready:
+		var payload GameEventPayloadPlayerReady
+
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal Event%s payload: %w", event.Type, err)
+		}
+
+		if !g.IsInSetup() {
+			return fmt.Errorf("could not change whether player is ready while game status is %s", g.Status)
+		}
+
+		player, err := g.FindPlayerByUserId(payload.PlayerID)
+
+		if err != nil {
+			return fmt.Errorf("could not change whether player is ready: %w", err)
+		}
+
+		player.IsReady = event.Type == EventPlayerReady
 	default:
 		return fmt.Errorf("unknown event type: %s", event.Type)
 	}
@@ -0,0 +1,412 @@
+package aggregates
+
+import (
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/events"
+	"cardgame/internal/domain/valueobjects"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+const testGameID = "game-1"
+
+func testClaim(userID string) *entities.CustomClaim {
+	return &entities.CustomClaim{UserID: userID, Name: userID}
+}
+
+func testCollection() *Collection {
+	collection := NewCollection()
+
+	for i := 0; i < 40; i++ {
+		collection.AddCard(entities.NewCard(fmt.Sprintf("w%d", i), entities.White, fmt.Sprintf("white %d", i)))
+	}
+
+	for i := 0; i < 5; i++ {
+		collection.AddCard(entities.NewCard(fmt.Sprintf("b%d", i), entities.Black, fmt.Sprintf("black _____ %d", i)))
+	}
+
+	return collection
+}
+
+func applyTestEvent(g *Game, eventType GameEventType, payload any) error {
+	raw, err := json.Marshal(payload)
+
+	if err != nil {
+		return err
+	}
+
+	event := NewGameEvent(testGameID, eventType, raw)
+	event.Version = g.Version + 1
+
+	return g.ApplyEvent(event)
+}
+
+func mustApply(t *testing.T, g *Game, eventType GameEventType, payload any) {
+	t.Helper()
+
+	if err := applyTestEvent(g, eventType, payload); err != nil {
+		t.Fatalf("could not apply %s: %v", eventType, err)
+	}
+}
+
+// newTestGame builds a game with the owner u1 and players u2 and u3, each
+// holding five cards, and plays it through to the given round status. u1
+// judges czar games and plays along in democracy games. Everyone else submits
+// their first card, and u2 wins the round.
+func newTestGame(t *testing.T, mode valueobjects.GameMode, status valueobjects.RoundStatus) *Game {
+	t.Helper()
+
+	g := &Game{ID: testGameID}
+
+	mustApply(t, g, EventGameCreated, NewGameEventPayloadGameCreated(testGameID, "test", testCollection(), 3, 6, 3, 5, mode, valueobjects.TieAllWin, valueobjects.HouseRules{}, valueobjects.PublicVisibility, "", "", "p1", testClaim("u1")))
+	mustApply(t, g, EventJoinedGame, NewGameEventPayloadJoinedGame(testGameID, "u2", "p2", testClaim("u2")))
+	mustApply(t, g, EventJoinedGame, NewGameEventPayloadJoinedGame(testGameID, "u3", "p3", testClaim("u3")))
+
+	for i, userID := range []string{"u1", "u2", "u3"} {
+		cardIDs := []string{}
+
+		for j := 0; j < 5; j++ {
+			cardIDs = append(cardIDs, fmt.Sprintf("w%d", i*5+j))
+		}
+
+		mustApply(t, g, EventDealCards, NewGameEventPayloadDealCards(testGameID, userID, cardIDs))
+	}
+
+	if status == valueobjects.Waiting {
+		return g
+	}
+
+	if mode != valueobjects.DemocracyMode {
+		mustApply(t, g, EventSetJudge, NewGameEventPayloadSetJudge(testGameID, "u1"))
+	}
+
+	mustApply(t, g, EventDrawBlackCard, NewGameEventPayloadDrawBlackCard(testGameID, "b0"))
+	mustApply(t, g, EventGameBegins, events.NewGameEventPayloadGameBegins(testGameID, "u1"))
+
+	if status == valueobjects.PlayersPickingCard {
+		return g
+	}
+
+	for _, player := range g.GetNonJudgePlayers() {
+		mustApply(t, g, EventCardPlayed, NewGameEventPayloadPlayCard(testGameID, "s-"+player.UserID, []string{player.Deck[0].ID}, testClaim(player.UserID)))
+	}
+
+	if status == valueobjects.JudgePickingWinningCard || status == valueobjects.PlayersVoting {
+		return g
+	}
+
+	if mode == valueobjects.DemocracyMode {
+		mustApply(t, g, EventVotesCounted, NewGameEventPayloadVotesCounted(testGameID, []string{"s-u2"}))
+	} else {
+		mustApply(t, g, EventJudgeChoseWinningCard, NewGameEventPayloadJudgeChoseWinningCard(testGameID, "s-u2"))
+	}
+
+	if status == valueobjects.JudgeChoseWinningCard {
+		return g
+	}
+
+	mustApply(t, g, EventGameWinner, NewGameEventPayloadGameWinner(testGameID, "u2", 1, nil))
+
+	if g.RoundStatus != status {
+		t.Fatalf("test game reached %s instead of %s", g.RoundStatus, status)
+	}
+
+	return g
+}
+
+func gameJSON(t *testing.T, g *Game) string {
+	t.Helper()
+
+	raw, err := json.Marshal(g)
+
+	if err != nil {
+		t.Fatalf("could not marshal game: %v", err)
+	}
+
+	return string(raw)
+}
+
+// firstCard is a card in the player's hand, or nothing when they have none.
+func firstCard(g *Game, userID string) string {
+	player, err := g.FindPlayerByUserId(userID)
+
+	if err != nil || len(player.Deck) == 0 {
+		return ""
+	}
+
+	return player.Deck[0].ID
+}
+
+// gameState is a round status in a game of the given mode.
+type gameState struct {
+	mode   valueobjects.GameMode
+	status valueobjects.RoundStatus
+}
+
+func (s gameState) String() string {
+	return fmt.Sprintf("%s/%s", s.mode, s.status)
+}
+
+var (
+	czarWaiting      = gameState{valueobjects.CzarMode, valueobjects.Waiting}
+	czarPicking      = gameState{valueobjects.CzarMode, valueobjects.PlayersPickingCard}
+	czarJudging      = gameState{valueobjects.CzarMode, valueobjects.JudgePickingWinningCard}
+	czarChosen       = gameState{valueobjects.CzarMode, valueobjects.JudgeChoseWinningCard}
+	czarOver         = gameState{valueobjects.CzarMode, valueobjects.GameOver}
+	democracyPicking = gameState{valueobjects.DemocracyMode, valueobjects.PlayersPickingCard}
+	democracyVoting  = gameState{valueobjects.DemocracyMode, valueobjects.PlayersVoting}
+	democracyChosen  = gameState{valueobjects.DemocracyMode, valueobjects.JudgeChoseWinningCard}
+)
+
+// gameStates are the states newTestGame can play a game through to.
+var gameStates = []gameState{
+	czarWaiting,
+	czarPicking,
+	czarJudging,
+	czarChosen,
+	czarOver,
+	democracyPicking,
+	democracyVoting,
+	democracyChosen,
+}
+
+// runningStates are the game states between the game beginning and ending.
+var runningStates = []gameState{
+	czarPicking,
+	czarJudging,
+	czarChosen,
+	democracyPicking,
+	democracyVoting,
+	democracyChosen,
+}
+
+type eventProbe struct {
+	eventType  GameEventType
+	payload    func(g *Game) any
+	acceptedIn []gameState
+}
+
+// eventProbes are one plausible event of every type, applied in every game
+// state whether or not it belongs there. Each must be applied in the states
+// it is accepted in and rejected in every other.
+var eventProbes = []eventProbe{
+	{EventGameCreated, func(g *Game) any {
+		return NewGameEventPayloadGameCreated(testGameID, "again", testCollection(), 3, 6, 3, 5, valueobjects.CzarMode, valueobjects.TieAllWin, valueobjects.HouseRules{}, valueobjects.PublicVisibility, "", "", "p9", testClaim("u9"))
+	}, nil},
+	{EventGameBegins, func(g *Game) any { return events.NewGameEventPayloadGameBegins(testGameID, "u1") }, []gameState{czarWaiting}},
+	{EventJoinedGame, func(g *Game) any { return NewGameEventPayloadJoinedGame(testGameID, "u4", "p4", testClaim("u4")) }, gameStates},
+	{EventBotJoined, func(g *Game) any { return NewGameEventPayloadBotJoined(testGameID, RandoUserID, "p-rando", RandoName) }, gameStates},
+	{EventCardPlayed, func(g *Game) any {
+		return NewGameEventPayloadPlayCard(testGameID, "s-probe", []string{firstCard(g, "u3")}, testClaim("u3"))
+	}, []gameState{czarPicking, democracyPicking}},
+	{EventRoundContinued, func(g *Game) any {
+		return NewGameEventPayloadGameRoundContinuedWithCards(testGameID, "u1", map[string]string{"u2": "w30"}, "b1")
+	}, []gameState{czarChosen, democracyChosen}},
+	{EventJudgeChoseWinningCard, func(g *Game) any { return NewGameEventPayloadJudgeChoseWinningCard(testGameID, "s-u3") }, []gameState{czarJudging}},
+	{EventShuffle, func(g *Game) any { return NewGameEventPayloadShuffle(testGameID, 42, "shuffle-1") }, gameStates},
+	{EventDealCards, func(g *Game) any { return NewGameEventPayloadDealCards(testGameID, "u2", []string{"w31", "w32"}) }, gameStates},
+	{EventDrawBlackCard, func(g *Game) any { return NewGameEventPayloadDrawBlackCard(testGameID, "b2") }, gameStates},
+	{EventSetJudge, func(g *Game) any { return NewGameEventPayloadSetJudge(testGameID, "u2") }, gameStates},
+	{EventGameWinner, func(g *Game) any { return NewGameEventPayloadGameWinner(testGameID, "u3", 3, nil) }, []gameState{czarChosen, democracyChosen}},
+	{EventHandMulliganed, func(g *Game) any {
+		return NewGameEventPayloadRedrawHand(testGameID, "u3", []string{"w35", "w36", "w37", "w38", "w39"})
+	}, []gameState{czarPicking, democracyPicking}},
+	// Nobody has a point to trade in while there are cards to pick
+	{EventUniverseRebooted, func(g *Game) any {
+		return NewGameEventPayloadRedrawHand(testGameID, "u3", []string{"w35", "w36", "w37", "w38", "w39"})
+	}, nil},
+	{EventVoteCast, func(g *Game) any { return NewGameEventPayloadVoteCast(testGameID, "u1", "s-u2") }, []gameState{democracyVoting}},
+	{EventVotesCounted, func(g *Game) any { return NewGameEventPayloadVotesCounted(testGameID, []string{"s-u3"}) }, []gameState{democracyVoting}},
+	{EventPlayerDisconnected, func(g *Game) any { return NewGameEventPayloadPlayerConnection(testGameID, "u2") }, gameStates},
+	{EventPlayerReconnected, func(g *Game) any { return NewGameEventPayloadPlayerConnection(testGameID, "u2") }, gameStates},
+	{EventPlayerLeft, func(g *Game) any { return NewGameEventPayloadPlayerLeft(testGameID, "u3") }, gameStates},
+	{EventOwnerChanged, func(g *Game) any { return NewGameEventPayloadOwnerChanged(testGameID, "u2") }, gameStates},
+	{EventRoundVoided, func(g *Game) any { return NewGameEventPayloadRoundVoided(testGameID, "b3") }, []gameState{czarPicking, czarJudging, democracyPicking, democracyVoting}},
+	{EventPlayerKicked, func(g *Game) any { return NewGameEventPayloadPlayerLeft(testGameID, "u3") }, gameStates},
+	{EventPlayerBanned, func(g *Game) any { return NewGameEventPayloadPlayerModerated(testGameID, "u3") }, gameStates},
+	{EventPlayerMuted, func(g *Game) any { return NewGameEventPayloadPlayerModerated(testGameID, "u2") }, gameStates},
+	{EventPlayerUnmuted, func(g *Game) any { return NewGameEventPayloadPlayerModerated(testGameID, "u2") }, gameStates},
+	{EventSpectatorJoined, func(g *Game) any { return NewGameEventPayloadJoinedGame(testGameID, "u6", "p6", testClaim("u6")) }, gameStates},
+	{EventSpectatorLeft, func(g *Game) any { return NewGameEventPayloadPlayerLeft(testGameID, "u6") }, nil},
+	{EventSpectatorPromoted, func(g *Game) any { return NewGameEventPayloadPlayerModerated(testGameID, "u6") }, nil},
+	{EventSpectatorSeated, func(g *Game) any { return NewGameEventPayloadPlayerModerated(testGameID, "u6") }, nil},
+	{EventGamePaused, func(g *Game) any { return NewGameEventPayloadGamePaused(testGameID, "u1") }, runningStates},
+	{EventGameResumed, func(g *Game) any { return NewGameEventPayloadGamePaused(testGameID, "u1") }, nil},
+	{EventRoundForceAdvanced, func(g *Game) any {
+		return NewGameEventPayloadRoundForceAdvanced(testGameID, "u1", g.RoundStatus)
+	}, runningStates},
+	{EventRematchCreated, func(g *Game) any { return NewGameEventPayloadRematchCreated(testGameID, "u1", "game-2") }, []gameState{czarOver}},
+	{EventInviteAccepted, func(g *Game) any { return NewGameEventPayloadInviteAccepted(testGameID, "u7", "") }, nil},
+	{EventInviteCodeRotated, func(g *Game) any { return NewGameEventPayloadInviteCodeRotated(testGameID, "u1", "NEWCODE") }, nil},
+	{EventSettingsChanged, func(g *Game) any {
+		settings := g.Settings()
+		settings.Name = "renamed"
+
+		return NewGameEventPayloadSettingsChanged(testGameID, "u1", settings)
+	}, []gameState{czarWaiting}},
+	{EventPlayerReady, func(g *Game) any { return NewGameEventPayloadPlayerReady(testGameID, "u2") }, []gameState{czarWaiting}},
+	{EventPlayerUnready, func(g *Game) any { return NewGameEventPayloadPlayerReady(testGameID, "u3") }, []gameState{czarWaiting}},
+	{EventClockUpdate, func(g *Game) any { return NewGameEventPayloadClockUpdate(testGameID, time.Unix(1700000000, 0).UTC()) }, gameStates},
+}
+
+func TestApplyEventInEveryRoundStatus(t *testing.T) {
+	for _, state := range gameStates {
+		for _, probe := range eventProbes {
+			accepted := slices.Contains(probe.acceptedIn, state)
+
+			t.Run(fmt.Sprintf("%s/%s", state, probe.eventType), func(t *testing.T) {
+				g := newTestGame(t, state.mode, state.status)
+				before := gameJSON(t, g)
+				version := g.Version
+
+				err := applyTestEvent(g, probe.eventType, probe.payload(g))
+
+				if !accepted {
+					if err == nil {
+						t.Fatalf("expected %s to be rejected in %s", probe.eventType, state)
+					}
+
+					if after := gameJSON(t, g); after != before {
+						t.Fatalf("rejected event changed the game: %v\nbefore: %s\nafter:  %s", err, before, after)
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("expected %s to be applied in %s: %v", probe.eventType, state, err)
+				}
+
+				if g.Version != version+1 {
+					t.Fatalf("expected version %d, got %d", version+1, g.Version)
+				}
+
+				if g.RoundStatus != state.status && !state.status.CanTransitionTo(g.RoundStatus) {
+					t.Fatalf("round status moved from %s to %s", state.status, g.RoundStatus)
+				}
+			})
+		}
+	}
+}
+
+func TestApplyEventRejectedPartWayLeavesGameUnchanged(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    valueobjects.RoundStatus
+		prepare   func(g *Game)
+		eventType GameEventType
+		payload   func(g *Game) any
+	}{
+		{
+			name:      "card played twice in one submission",
+			status:    valueobjects.PlayersPickingCard,
+			eventType: EventCardPlayed,
+			payload: func(g *Game) any {
+				cardID := firstCard(g, "u2")
+
+				return NewGameEventPayloadPlayCard(testGameID, "s-u2", []string{cardID, cardID}, testClaim("u2"))
+			},
+		},
+		{
+			name:      "deal with a card missing from the collection",
+			status:    valueobjects.PlayersPickingCard,
+			eventType: EventDealCards,
+			payload: func(g *Game) any {
+				return NewGameEventPayloadDealCards(testGameID, "u2", []string{"w35", "missing"})
+			},
+		},
+		{
+			name:   "round continued without anyone left to judge",
+			status: valueobjects.JudgeChoseWinningCard,
+			prepare: func(g *Game) {
+				// Bots never judge, so a round only a bot is left in has
+				// nobody to pass it on to
+				bot := NewBotPlayer("p-rando", RandoUserID, RandoName)
+				bot.PlacedCards = g.Players[2].PlacedCards
+				bot.IsRoundWinner = true
+				g.Players = []*Player{bot}
+			},
+			eventType: EventRoundContinued,
+			payload: func(g *Game) any {
+				return NewGameEventPayloadGameRoundContinuedWithCards(testGameID, "u1", nil, "b1")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, valueobjects.CzarMode, tt.status)
+
+			if tt.prepare != nil {
+				tt.prepare(g)
+			}
+
+			before := gameJSON(t, g)
+
+			if err := applyTestEvent(g, tt.eventType, tt.payload(g)); err == nil {
+				t.Fatal("expected the event to be rejected")
+			}
+
+			if after := gameJSON(t, g); after != before {
+				t.Fatalf("rejected event changed the game\nbefore: %s\nafter:  %s", before, after)
+			}
+		})
+	}
+}
+
+func TestApplyEventRejectsWrongVersion(t *testing.T) {
+	g := newTestGame(t, valueobjects.CzarMode, valueobjects.PlayersPickingCard)
+	before := gameJSON(t, g)
+
+	raw, _ := json.Marshal(NewGameEventPayloadClockUpdate(testGameID, time.Now()))
+	event := NewGameEvent(testGameID, EventClockUpdate, raw)
+	event.Version = g.Version + 2
+
+	if err := g.ApplyEvent(event); err == nil {
+		t.Fatal("expected an event from the future to be rejected")
+	}
+
+	if gameJSON(t, g) != before {
+		t.Fatal("rejected event changed the game")
+	}
+}
+
+func TestCloneDoesNotShareState(t *testing.T) {
+	g := newTestGame(t, valueobjects.CzarMode, valueobjects.JudgeChoseWinningCard)
+	cloned := g.Clone()
+
+	if gameJSON(t, cloned) != gameJSON(t, g) {
+		t.Fatal("clone differs from the game")
+	}
+
+	if cloned.RoundWinner != cloned.Players[1] {
+		t.Fatal("cloned round winner should be one of the cloned players")
+	}
+
+	if cloned.WinningSubmission != cloned.Submissions[0] {
+		t.Fatal("cloned winning submission should be one of the cloned submissions")
+	}
+
+	cloned.Players[1].Deck = nil
+	cloned.Submissions[0].Cards = nil
+	cloned.Collection.Cards[0], cloned.Collection.Cards[1] = cloned.Collection.Cards[1], cloned.Collection.Cards[0]
+
+	if len(g.Players[1].Deck) == 0 || len(g.Submissions[0].Cards) == 0 || g.Collection.Cards[0].ID != "w0" {
+		t.Fatal("changing the clone changed the game")
+	}
+}
+
+func TestCloneDoesNotShareVotes(t *testing.T) {
+	g := newTestGame(t, valueobjects.DemocracyMode, valueobjects.PlayersVoting)
+	mustApply(t, g, EventVoteCast, NewGameEventPayloadVoteCast(testGameID, "u1", "s-u2"))
+
+	cloned := g.Clone()
+	cloned.Votes["u3"] = "s-u2"
+
+	if g.HasVoted("u3") {
+		t.Fatal("voting in the clone voted in the game")
+	}
+}
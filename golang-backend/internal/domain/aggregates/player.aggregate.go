@@ -4,24 +4,33 @@ import (
 	"cardgame/internal/domain/entities"
 	"cardgame/internal/domain/valueobjects"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Player struct {
-	ID            string                  `json:"id"`
-	Score         int                     `json:"score"`
-	Role          valueobjects.PlayerRole `json:"role"`
-	IsOwner       bool                    `json:"is_owner"`
-	UserID        string                  `json:"user_id"`
-	Name          string                  `json:"name"`
-	Image         string                  `json:"image"`
-	Deck          []*entities.Card        `json:"deck"`
-	IsJudge       bool                    `json:"is_judge"`
-	WasJudge      bool                    `json:"was_judge"`
-	PlacedCard    *entities.Card          `json:"placed_card"`
-	IsRoundWinner bool                    `json:"is_round_winner"`
-	IsGameWinner  bool                    `json:"is_game_winner"`
+	ID             string                  `json:"id"`
+	Score          int                     `json:"score"`
+	Role           valueobjects.PlayerRole `json:"role"`
+	IsOwner        bool                    `json:"is_owner"`
+	UserID         string                  `json:"user_id"`
+	Name           string                  `json:"name"`
+	Image          string                  `json:"image"`
+	Deck           []*entities.Card        `json:"deck"`
+	IsJudge        bool                    `json:"is_judge"`
+	WasJudge       bool                    `json:"was_judge"`
+	PlacedCards    []*entities.Card        `json:"placed_cards"`
+	IsRoundWinner  bool                    `json:"is_round_winner"`
+	IsGameWinner   bool                    `json:"is_game_winner"`
+	IsShamed       bool                    `json:"is_shamed"`
+	IsBot          bool                    `json:"is_bot"`
+	HasMulliganed  bool                    `json:"has_mulliganed"`
+	IsDisconnected bool                    `json:"is_disconnected"` // Keeps their seat until the reconnect grace period after DisconnectedAt
+	DisconnectedAt time.Time               `json:"disconnected_at"`
+	IsMuted        bool                    `json:"is_muted"`
+	IsPromoted     bool                    `json:"is_promoted"` // A spectator the owner has given the next free seat
+	IsReady        bool                    `json:"is_ready"`    // Ready for the game to begin, only used in setup
 }
 
 func NewPlayer(claim *entities.CustomClaim) (*Player, error) {
@@ -42,12 +51,46 @@ func NewPlayer(claim *entities.CustomClaim) (*Player, error) {
 		Deck:          []*entities.Card{},
 		IsJudge:       false,
 		WasJudge:      false,
-		PlacedCard:    nil,
+		PlacedCards:   []*entities.Card{},
 		IsRoundWinner: false,
 		IsGameWinner:  false,
 	}, nil
 }
 
+// NewSpectator creates a user who watches the game without a seat. They are
+// never dealt cards or made judge.
+func NewSpectator(claim *entities.CustomClaim) (*Player, error) {
+	spectator, err := NewPlayer(claim)
+
+	if err != nil {
+		return nil, err
+	}
+
+	spectator.Role = valueobjects.Spectator
+
+	return spectator, nil
+}
+
+// NewBotPlayer creates a player that is driven by the server rather than a
+// connected user.
+func NewBotPlayer(playerID string, userID string, name string) *Player {
+	return &Player{
+		ID:            playerID,
+		Score:         0,
+		Role:          valueobjects.Participant,
+		IsOwner:       false,
+		UserID:        userID,
+		Name:          name,
+		Deck:          []*entities.Card{},
+		IsJudge:       false,
+		WasJudge:      false,
+		PlacedCards:   []*entities.Card{},
+		IsRoundWinner: false,
+		IsGameWinner:  false,
+		IsBot:         true,
+	}
+}
+
 func (p *Player) SetIsOwner(isOwner bool) *Player {
 	p.IsOwner = isOwner
 
@@ -72,26 +115,28 @@ func (p *Player) SetWasJudge(wasJudge bool) *Player {
 	return p
 }
 
-func (p *Player) SetPlacedCard(card *entities.Card) error {
-	if card == nil {
-		return fmt.Errorf("could not set nil card as placed card")
+// PlaceCards puts the player's submission for the round on the table, in the
+// order the blanks should be filled.
+func (p *Player) PlaceCards(cards []*entities.Card) error {
+	if len(cards) == 0 {
+		return fmt.Errorf("could not place cards because no cards were given")
 	}
 
-	p.PlacedCard = card
-
-	return nil
-}
-
-func (p *Player) RemovePlacedCard() error {
-	if p.PlacedCard == nil {
-		return fmt.Errorf("could not remove placed card because it is nil")
+	for _, card := range cards {
+		if card == nil {
+			return fmt.Errorf("could not place nil card")
+		}
 	}
 
-	p.PlacedCard = nil
+	p.PlacedCards = cards
 
 	return nil
 }
 
+func (p *Player) ClearPlacedCards() {
+	p.PlacedCards = []*entities.Card{}
+}
+
 func (p *Player) RemoveCardFromDeck(cardId string) error {
 	for i, card := range p.Deck {
 		if card.ID == cardId {
@@ -104,17 +149,7 @@ func (p *Player) RemoveCardFromDeck(cardId string) error {
 }
 
 func (p *Player) HasAlreadyPlayedWhiteCard() bool {
-	return p.PlacedCard != nil
-}
-
-func (p *Player) SetCardAsPlacedCard(card *entities.Card) error {
-	if card == nil {
-		return fmt.Errorf("could not set nil card as placed card")
-	}
-
-	p.PlacedCard = card
-
-	return nil
+	return len(p.PlacedCards) > 0
 }
 
 func (p *Player) HasPlayedCard(card *entities.Card) (bool, error) {
@@ -122,13 +157,29 @@ func (p *Player) HasPlayedCard(card *entities.Card) (bool, error) {
 		return false, fmt.Errorf("could not check if player has played card because card is nil")
 	}
 
-	return p.PlacedCard != nil && p.PlacedCard.ID == card.ID, nil
+	for _, placed := range p.PlacedCards {
+		if placed.ID == card.ID {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
 func (p *Player) IncrementScore() {
 	p.Score++
 }
 
+func (p *Player) DecrementScore() error {
+	if p.Score <= 0 {
+		return fmt.Errorf("could not decrement score of player %s, score is %d", p.UserID, p.Score)
+	}
+
+	p.Score--
+
+	return nil
+}
+
 // Clone creates a deep copy of the player
 func (p *Player) Clone() *Player {
 	if p == nil {
@@ -136,15 +187,25 @@ func (p *Player) Clone() *Player {
 	}
 
 	cloned := &Player{
-		Score:         p.Score,
-		Role:          p.Role,
-		UserID:        p.UserID,
-		Name:          p.Name,
-		Image:         p.Image,
-		IsJudge:       p.IsJudge,
-		WasJudge:      p.WasJudge,
-		IsRoundWinner: p.IsRoundWinner,
-		IsGameWinner:  p.IsGameWinner,
+		ID:             p.ID,
+		IsOwner:        p.IsOwner,
+		Score:          p.Score,
+		Role:           p.Role,
+		UserID:         p.UserID,
+		Name:           p.Name,
+		Image:          p.Image,
+		IsJudge:        p.IsJudge,
+		WasJudge:       p.WasJudge,
+		IsRoundWinner:  p.IsRoundWinner,
+		IsGameWinner:   p.IsGameWinner,
+		IsShamed:       p.IsShamed,
+		IsBot:          p.IsBot,
+		HasMulliganed:  p.HasMulliganed,
+		IsDisconnected: p.IsDisconnected,
+		DisconnectedAt: p.DisconnectedAt,
+		IsMuted:        p.IsMuted,
+		IsPromoted:     p.IsPromoted,
+		IsReady:        p.IsReady,
 	}
 
 	// Clone deck
@@ -155,9 +216,12 @@ func (p *Player) Clone() *Player {
 		}
 	}
 
-	// Clone placed card
-	if p.PlacedCard != nil {
-		cloned.PlacedCard = p.PlacedCard.Clone()
+	// Clone placed cards
+	if p.PlacedCards != nil {
+		cloned.PlacedCards = make([]*entities.Card, len(p.PlacedCards))
+		for i, card := range p.PlacedCards {
+			cloned.PlacedCards[i] = card.Clone()
+		}
 	}
 
 	return cloned
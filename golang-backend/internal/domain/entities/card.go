@@ -1,5 +1,13 @@
 package entities
 
+import "regexp"
+
+// blankPattern matches a blank on a black card, e.g. "_____".
+var blankPattern = regexp.MustCompile(`_{2,}`)
+
+// MaxPick is the most white cards a black card can ask for.
+const MaxPick = 3
+
 type CardType string
 
 const (
@@ -11,6 +19,7 @@ type Card struct {
 	ID        string   `json:"id"`
 	Type      CardType `json:"type"`
 	CardValue string   `json:"card_value"`
+	Pick      int      `json:"pick,omitempty"`
 }
 
 func NewCard(id string, cardType CardType, cardValue string) *Card {
@@ -30,5 +39,30 @@ func (c *Card) Clone() *Card {
 		ID:        c.ID,
 		Type:      c.Type,
 		CardValue: c.CardValue,
+		Pick:      c.Pick,
+	}
+}
+
+// PickCount is how many white cards a black card asks for. Cards without an
+// explicit pick count ask for one card per blank, and at least one.
+func (c *Card) PickCount() int {
+	if c == nil || c.Type != Black {
+		return 1
+	}
+
+	pick := c.Pick
+
+	if pick <= 0 {
+		pick = len(blankPattern.FindAllString(c.CardValue, -1))
+	}
+
+	if pick < 1 {
+		return 1
 	}
+
+	if pick > MaxPick {
+		return MaxPick
+	}
+
+	return pick
 }
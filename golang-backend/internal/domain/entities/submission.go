@@ -0,0 +1,27 @@
+package entities
+
+// Submission is the ordered set of white cards one player put forward for the
+// current black card.
+type Submission struct {
+	ID     string  `json:"id"`
+	UserID string  `json:"user_id"`
+	Cards  []*Card `json:"cards"`
+}
+
+func NewSubmission(id string, userID string, cards []*Card) *Submission {
+	return &Submission{
+		ID:     id,
+		UserID: userID,
+		Cards:  cards,
+	}
+}
+
+func (s *Submission) ContainsCard(cardID string) bool {
+	for _, card := range s.Cards {
+		if card.ID == cardID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,277 @@
+package projections
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/valueobjects"
+	"sort"
+	"time"
+)
+
+// PlayerView is what a single recipient is allowed to know about a player.
+// Hands are only included for the recipient themselves, and placed cards are
+// only revealed once the judge has picked a winner.
+type PlayerView struct {
+	ID             string                  `json:"id"`
+	Score          int                     `json:"score"`
+	Role           valueobjects.PlayerRole `json:"role"`
+	IsOwner        bool                    `json:"is_owner"`
+	UserID         string                  `json:"user_id"`
+	Name           string                  `json:"name"`
+	Image          string                  `json:"image"`
+	Deck           []*entities.Card        `json:"deck"`
+	DeckSize       int                     `json:"deck_size"`
+	IsJudge        bool                    `json:"is_judge"`
+	WasJudge       bool                    `json:"was_judge"`
+	PlacedCards    []*entities.Card        `json:"placed_cards"`
+	HasPlacedCard  bool                    `json:"has_placed_card"`
+	IsRoundWinner  bool                    `json:"is_round_winner"`
+	IsGameWinner   bool                    `json:"is_game_winner"`
+	IsShamed       bool                    `json:"is_shamed"`
+	IsBot          bool                    `json:"is_bot"`
+	HasMulliganed  bool                    `json:"has_mulliganed"`
+	HasVoted       bool                    `json:"has_voted"`
+	IsDisconnected bool                    `json:"is_disconnected"`
+	IsMuted        bool                    `json:"is_muted"`
+	IsPromoted     bool                    `json:"is_promoted"`
+	IsReady        bool                    `json:"is_ready"`
+}
+
+// SubmissionView is a submission on the board. Who made it and how many votes
+// it got are only included once the round has been revealed.
+type SubmissionView struct {
+	ID     string           `json:"id"`
+	Cards  []*entities.Card `json:"cards"`
+	UserID string           `json:"user_id,omitempty"`
+	Votes  int              `json:"votes,omitempty"`
+}
+
+// GameView is the per-recipient projection of a game that is safe to send
+// over the wire. The collection is reduced to its size, board cards are
+// hidden while players are picking and shown in a stable, owner-free order
+// while the judge is picking. A private game's invite code is only shown to
+// those who can already get in, and its password never leaves the server.
+type GameView struct {
+	ID                   string                   `json:"id"`
+	Name                 string                   `json:"name"`
+	CollectionSize       int                      `json:"collection_size"`
+	WinnerCount          int                      `json:"winner_count"`
+	MaxPlayerCount       int                      `json:"max_player_count"`
+	MinPlayerCount       int                      `json:"min_player_count"`
+	HandSize             int                      `json:"hand_size"`
+	Mode                 valueobjects.GameMode    `json:"mode"`
+	TieRule              valueobjects.TieRule     `json:"tie_rule"`
+	HouseRules           valueobjects.HouseRules  `json:"house_rules"`
+	Timers               valueobjects.Timers      `json:"timers"`
+	AutoStart            bool                     `json:"auto_start"`
+	Visibility           valueobjects.Visibility  `json:"visibility"`
+	InviteCode           string                   `json:"invite_code,omitempty"`
+	HasPassword          bool                     `json:"has_password"`
+	Status               valueobjects.GameStatus  `json:"status"`
+	Players              []*PlayerView            `json:"players"`
+	Spectators           []*PlayerView            `json:"spectators"`
+	Submissions          []*SubmissionView        `json:"submissions"`
+	SubmissionCount      int                      `json:"submission_count"`
+	VoteCount            int                      `json:"vote_count"`
+	WinningSubmissionID  string                   `json:"winning_submission_id"`
+	WinningSubmissionIDs []string                 `json:"winning_submission_ids"`
+	BlackCard            *entities.Card           `json:"black_card"`
+	RoundStatus          valueobjects.RoundStatus `json:"round_status"`
+	CurrentGameRound     int                      `json:"current_game_round"`
+	RoundWinner          *PlayerView              `json:"round_winner"`
+	LastVacatedAt        time.Time                `json:"last_vacated_at"`
+	LastEventAt          time.Time                `json:"last_event_at"`
+	NextAutoProgressAt   time.Time                `json:"next_auto_progress_at"`
+	IsPaused             bool                     `json:"is_paused"`
+	PausedAt             time.Time                `json:"paused_at"`
+	RematchGameID        string                   `json:"rematch_game_id"`
+	CreatedAt            time.Time                `json:"created_at"`
+	UpdatedAt            time.Time                `json:"updated_at"`
+}
+
+// NewGameView builds the view of the game as seen by the user with the given
+// user id. An empty user id yields the public view with no hand at all.
+func NewGameView(game *aggregates.Game, viewerUserID string) *GameView {
+	revealed := isRoundRevealed(game.RoundStatus)
+
+	players := make([]*PlayerView, 0, len(game.Players))
+
+	for _, player := range game.Players {
+		view := NewPlayerView(player, viewerUserID, revealed)
+		view.HasVoted = game.HasVoted(player.UserID)
+
+		players = append(players, view)
+	}
+
+	// Spectators have no hand, so they always get the public view
+	spectators := make([]*PlayerView, 0, len(game.Spectators))
+
+	for _, spectator := range game.Spectators {
+		spectators = append(spectators, NewPlayerView(spectator, viewerUserID, revealed))
+	}
+
+	var roundWinner *PlayerView
+
+	if game.RoundWinner != nil {
+		roundWinner = NewPlayerView(game.RoundWinner, viewerUserID, revealed)
+	}
+
+	winningSubmissionID := ""
+
+	if game.WinningSubmission != nil {
+		winningSubmissionID = game.WinningSubmission.ID
+	}
+
+	// A tied vote can score more than one submission
+	winningSubmissionIDs := []string{}
+
+	for _, submission := range game.Submissions {
+		if owner, err := game.FindSubmissionOwner(submission); err == nil && owner.IsRoundWinner {
+			winningSubmissionIDs = append(winningSubmissionIDs, submission.ID)
+		}
+	}
+
+	inviteCode := ""
+
+	if game.IsVisibleTo(viewerUserID) {
+		inviteCode = game.InviteCode
+	}
+
+	collectionSize := 0
+
+	if game.Collection != nil {
+		collectionSize = len(game.Collection.Cards)
+	}
+
+	return &GameView{
+		ID:                   game.ID,
+		Name:                 game.Name,
+		CollectionSize:       collectionSize,
+		WinnerCount:          game.WinnerCount,
+		MaxPlayerCount:       game.MaxPlayerCount,
+		MinPlayerCount:       game.RequiredPlayerCount(),
+		HandSize:             game.TargetHandSize(),
+		Mode:                 game.Mode,
+		TieRule:              game.TieRule,
+		HouseRules:           game.HouseRules,
+		Timers:               game.Timers,
+		AutoStart:            game.AutoStart,
+		Visibility:           game.Visibility,
+		InviteCode:           inviteCode,
+		HasPassword:          game.PasswordHash != "",
+		Status:               game.Status,
+		Players:              players,
+		Spectators:           spectators,
+		Submissions:          boardSubmissions(game),
+		SubmissionCount:      len(game.Submissions),
+		VoteCount:            len(game.Votes),
+		WinningSubmissionID:  winningSubmissionID,
+		WinningSubmissionIDs: winningSubmissionIDs,
+		BlackCard:            game.BlackCard.Clone(),
+		RoundStatus:          game.RoundStatus,
+		CurrentGameRound:     game.CurrentGameRound,
+		RoundWinner:          roundWinner,
+		LastVacatedAt:        game.LastVacatedAt,
+		LastEventAt:          game.LastEventAt,
+		NextAutoProgressAt:   game.NextAutoProgressAt,
+		IsPaused:             game.IsPaused(),
+		PausedAt:             game.PausedAt,
+		RematchGameID:        game.RematchGameID,
+		CreatedAt:            game.CreatedAt,
+		UpdatedAt:            game.UpdatedAt,
+	}
+}
+
+// NewPlayerView builds the view of a player for the given recipient.
+func NewPlayerView(player *aggregates.Player, viewerUserID string, revealed bool) *PlayerView {
+	isViewer := viewerUserID != "" && player.UserID == viewerUserID
+
+	deck := []*entities.Card{}
+
+	if isViewer {
+		deck = cloneCards(player.Deck)
+	}
+
+	placedCards := []*entities.Card{}
+
+	if isViewer || revealed {
+		placedCards = cloneCards(player.PlacedCards)
+	}
+
+	return &PlayerView{
+		ID:             player.ID,
+		Score:          player.Score,
+		Role:           player.Role,
+		IsOwner:        player.IsOwner,
+		UserID:         player.UserID,
+		Name:           player.Name,
+		Image:          player.Image,
+		Deck:           deck,
+		DeckSize:       len(player.Deck),
+		IsJudge:        player.IsJudge,
+		WasJudge:       player.WasJudge,
+		PlacedCards:    placedCards,
+		HasPlacedCard:  player.HasAlreadyPlayedWhiteCard(),
+		IsRoundWinner:  player.IsRoundWinner,
+		IsGameWinner:   player.IsGameWinner,
+		IsShamed:       player.IsShamed,
+		IsBot:          player.IsBot,
+		HasMulliganed:  player.HasMulliganed,
+		IsDisconnected: player.IsDisconnected,
+		IsMuted:        player.IsMuted,
+		IsPromoted:     player.IsPromoted,
+		IsReady:        player.IsReady,
+	}
+}
+
+// isRoundRevealed reports whether card ownership for the current round may be
+// shown to everyone.
+func isRoundRevealed(status valueobjects.RoundStatus) bool {
+	return status == valueobjects.JudgeChoseWinningCard || status == valueobjects.GameOver
+}
+
+// boardSubmissions returns the submissions on the board for the current round
+// status. They stay face down while players are still picking, and are sorted
+// by id afterwards so play order can't be used to infer owners.
+func boardSubmissions(game *aggregates.Game) []*SubmissionView {
+	if game.RoundStatus == valueobjects.PlayersPickingCard {
+		return []*SubmissionView{}
+	}
+
+	revealed := isRoundRevealed(game.RoundStatus)
+	votes := game.CountVotes()
+
+	submissions := make([]*SubmissionView, 0, len(game.Submissions))
+
+	for _, submission := range game.Submissions {
+		view := &SubmissionView{
+			ID:    submission.ID,
+			Cards: cloneCards(submission.Cards),
+		}
+
+		if revealed {
+			view.UserID = submission.UserID
+			view.Votes = votes[submission.ID]
+		}
+
+		submissions = append(submissions, view)
+	}
+
+	if !revealed {
+		sort.Slice(submissions, func(i, j int) bool {
+			return submissions[i].ID < submissions[j].ID
+		})
+	}
+
+	return submissions
+}
+
+func cloneCards(cards []*entities.Card) []*entities.Card {
+	cloned := make([]*entities.Card, 0, len(cards))
+
+	for _, card := range cards {
+		cloned = append(cloned, card.Clone())
+	}
+
+	return cloned
+}
@@ -0,0 +1,208 @@
+package projections
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/valueobjects"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func testCards(prefix string, count int) []*entities.Card {
+	cards := []*entities.Card{}
+
+	for i := 0; i < count; i++ {
+		cards = append(cards, entities.NewCard(fmt.Sprintf("%s%d", prefix, i), entities.White, fmt.Sprintf("white %s%d", prefix, i)))
+	}
+
+	return cards
+}
+
+// newTestGame is a game where the judge u1 is picking between the cards u2
+// and u3 played.
+func newTestGame() *aggregates.Game {
+	g := &aggregates.Game{
+		ID:             "game-1",
+		Name:           "test",
+		Collection:     aggregates.NewCollection(),
+		MaxPlayerCount: 6,
+		Status:         valueobjects.InProgress,
+		RoundStatus:    valueobjects.JudgePickingWinningCard,
+		BlackCard:      entities.NewCard("b0", entities.Black, "black _____"),
+		Players: []*aggregates.Player{
+			{UserID: "u1", IsOwner: true, IsJudge: true, Deck: testCards("hand-u1-", 5)},
+			{UserID: "u2", Deck: testCards("hand-u2-", 4), PlacedCards: testCards("played-u2-", 1)},
+			{UserID: "u3", Deck: testCards("hand-u3-", 4), PlacedCards: testCards("played-u3-", 1)},
+		},
+	}
+
+	// Submitted in the opposite order to their ids
+	g.Submissions = []*entities.Submission{
+		entities.NewSubmission("s-z", "u3", g.Players[2].PlacedCards),
+		entities.NewSubmission("s-a", "u2", g.Players[1].PlacedCards),
+	}
+
+	return g
+}
+
+func viewedPlayer(t *testing.T, view *GameView, userID string) *PlayerView {
+	t.Helper()
+
+	for _, player := range view.Players {
+		if player.UserID == userID {
+			return player
+		}
+	}
+
+	t.Fatalf("player %s is not in the view", userID)
+
+	return nil
+}
+
+func viewJSON(t *testing.T, view *GameView) string {
+	t.Helper()
+
+	raw, err := json.Marshal(view)
+
+	if err != nil {
+		t.Fatalf("could not marshal view: %v", err)
+	}
+
+	return string(raw)
+}
+
+func TestGameViewOnlyShowsTheViewersHand(t *testing.T) {
+	g := newTestGame()
+
+	for _, viewerUserID := range []string{"u1", "u2", "u3", "u4", ""} {
+		view := NewGameView(g, viewerUserID)
+
+		for _, player := range g.Players {
+			viewed := viewedPlayer(t, view, player.UserID)
+
+			if viewed.DeckSize != len(player.Deck) {
+				t.Fatalf("%s sees %s holding %d cards, expected %d", viewerUserID, player.UserID, viewed.DeckSize, len(player.Deck))
+			}
+
+			if player.UserID == viewerUserID {
+				if len(viewed.Deck) != len(player.Deck) {
+					t.Fatalf("%s cannot see their own hand", viewerUserID)
+				}
+
+				continue
+			}
+
+			if len(viewed.Deck) != 0 {
+				t.Fatalf("%s can see the hand of %s", viewerUserID, player.UserID)
+			}
+
+			// Nothing in the hand may leak through any other field
+			for _, card := range player.Deck {
+				if strings.Contains(viewJSON(t, view), card.ID) {
+					t.Fatalf("%s can see card %s from the hand of %s", viewerUserID, card.ID, player.UserID)
+				}
+			}
+		}
+	}
+}
+
+func TestGameViewDoesNotShareCardsWithTheGame(t *testing.T) {
+	g := newTestGame()
+	view := NewGameView(g, "u2")
+
+	viewedPlayer(t, view, "u2").Deck[0].CardValue = "changed"
+	view.BlackCard.CardValue = "changed"
+
+	if g.Players[1].Deck[0].CardValue == "changed" || g.BlackCard.CardValue == "changed" {
+		t.Fatal("changing the view changed the game")
+	}
+}
+
+func TestGameViewHidesPlacedCardsUntilTheRoundIsRevealed(t *testing.T) {
+	g := newTestGame()
+	view := NewGameView(g, "u3")
+
+	if placed := viewedPlayer(t, view, "u2").PlacedCards; len(placed) != 0 {
+		t.Fatalf("u3 can see what u2 played before the reveal: %v", placed)
+	}
+
+	if !viewedPlayer(t, view, "u2").HasPlacedCard {
+		t.Fatal("u3 cannot see that u2 has played")
+	}
+
+	if placed := viewedPlayer(t, view, "u3").PlacedCards; len(placed) != 1 {
+		t.Fatal("u3 cannot see what they played")
+	}
+
+	g.RoundStatus = valueobjects.JudgeChoseWinningCard
+	view = NewGameView(g, "u3")
+
+	if placed := viewedPlayer(t, view, "u2").PlacedCards; len(placed) != 1 {
+		t.Fatal("u3 cannot see what u2 played after the reveal")
+	}
+}
+
+func TestGameViewKeepsSubmissionsFaceDownWhilePlayersPick(t *testing.T) {
+	g := newTestGame()
+	g.RoundStatus = valueobjects.PlayersPickingCard
+
+	view := NewGameView(g, "u1")
+
+	if len(view.Submissions) != 0 {
+		t.Fatalf("the judge can see submissions while players pick: %v", view.Submissions)
+	}
+
+	if view.SubmissionCount != 2 {
+		t.Fatalf("expected the judge to see 2 submissions were made, got %d", view.SubmissionCount)
+	}
+}
+
+func TestGameViewShowsAnonymousSubmissionsWhileJudging(t *testing.T) {
+	g := newTestGame()
+	view := NewGameView(g, "u1")
+
+	ids := []string{}
+
+	for _, submission := range view.Submissions {
+		if submission.UserID != "" {
+			t.Fatalf("submission %s shows who made it before the reveal", submission.ID)
+		}
+
+		if len(submission.Cards) != 1 {
+			t.Fatalf("submission %s shows %d cards, expected 1", submission.ID, len(submission.Cards))
+		}
+
+		ids = append(ids, submission.ID)
+	}
+
+	// Sorted by id rather than play order, which would give the owners away
+	if !slices.Equal(ids, []string{"s-a", "s-z"}) {
+		t.Fatalf("expected submissions sorted by id, got %v", ids)
+	}
+}
+
+func TestGameViewRevealsSubmissionOwners(t *testing.T) {
+	g := newTestGame()
+	g.RoundStatus = valueobjects.JudgeChoseWinningCard
+	g.Players[1].IsRoundWinner = true
+	g.WinningSubmission = g.Submissions[1]
+
+	view := NewGameView(g, "u3")
+
+	owners := map[string]string{}
+
+	for _, submission := range view.Submissions {
+		owners[submission.ID] = submission.UserID
+	}
+
+	if owners["s-a"] != "u2" || owners["s-z"] != "u3" {
+		t.Fatalf("expected submission owners to be revealed, got %v", owners)
+	}
+
+	if view.WinningSubmissionID != "s-a" {
+		t.Fatalf("expected s-a to have won, got %q", view.WinningSubmissionID)
+	}
+}
@@ -2,12 +2,27 @@ package repositories
 
 import (
 	"cardgame/internal/domain/aggregates"
+	"fmt"
 	"time"
 )
 
+// VersionConflictError is returned when an append expected the game's event
+// stream to be at a different version than the one stored, meaning another
+// command appended first.
+type VersionConflictError struct {
+	GameID          string
+	ExpectedVersion int64
+	ActualVersion   int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version conflict on game %s: expected version %d but stream is at %d", e.GameID, e.ExpectedVersion, e.ActualVersion)
+}
+
 type EventRepository interface {
-	AppendEvent(event *aggregates.GameEvent) error
+	AppendEvents(gameID string, expectedVersion int64, events []*aggregates.GameEvent) error
 	GetEventsForGame(gameID string) ([]aggregates.GameEvent, error)
+	GetGameIDs() ([]string, error)
 	GetEventsSince(gameID string, since time.Time) ([]aggregates.GameEvent, error)
 	GetEventByID(eventID string) (*aggregates.GameEvent, error)
 	DeleteGameEvents(gameID string) error
@@ -0,0 +1,15 @@
+package repositories
+
+import "time"
+
+// TimerRepository stores at most one pending deadline per game. Scheduling a
+// game again moves its deadline.
+type TimerRepository interface {
+	Schedule(gameID string, fireAt time.Time) error
+	Cancel(gameID string) error
+	// ClaimDue returns up to limit games whose deadline is at or before now
+	// and moves their deadline to leaseUntil. A claimed game is returned to
+	// exactly one caller, and comes due again once the lease runs out unless
+	// it is scheduled or cancelled first.
+	ClaimDue(now time.Time, leaseUntil time.Time, limit int) ([]string, error)
+}
@@ -0,0 +1,64 @@
+package validation
+
+// Error codes are part of the websocket protocol. Clients match on them, so
+// they must not change once released.
+const (
+	ErrCodeGameNotFound       = "GAME_NOT_FOUND"
+	ErrCodeGameFull           = "GAME_FULL"
+	ErrCodeNotEnoughPlayers   = "NOT_ENOUGH_PLAYERS"
+	ErrCodeInvalidPlayerCount = "INVALID_PLAYER_COUNT"
+	ErrCodeInvalidHandSize    = "INVALID_HAND_SIZE"
+	ErrCodeWrongGameStatus    = "WRONG_GAME_STATUS"
+	ErrCodeWrongRoundStatus   = "WRONG_ROUND_STATUS"
+	ErrCodePlayerNotInGame    = "PLAYER_NOT_IN_GAME"
+	ErrCodeNotGameOwner       = "NOT_GAME_OWNER"
+	ErrCodeNotJudge           = "NOT_JUDGE"
+	ErrCodeCardNotInHand      = "CARD_NOT_IN_HAND"
+	ErrCodeSubmissionNotFound = "SUBMISSION_NOT_FOUND"
+	ErrCodeWrongCardCount     = "WRONG_CARD_COUNT"
+	ErrCodeDuplicateCard      = "DUPLICATE_CARD"
+	ErrCodeJudgeCannotPlay    = "JUDGE_CANNOT_PLAY"
+	ErrCodeAlreadyPlayed      = "ALREADY_PLAYED"
+	ErrCodeNotAllPlayed       = "NOT_ALL_PLAYERS_PLAYED"
+	ErrCodeNotEnoughCards     = "NOT_ENOUGH_CARDS"
+	ErrCodeEmptyReaction      = "EMPTY_REACTION"
+	ErrCodeHouseRuleDisabled  = "HOUSE_RULE_DISABLED"
+	ErrCodeNotEnoughPoints    = "NOT_ENOUGH_POINTS"
+	ErrCodeMulliganUsed       = "MULLIGAN_USED"
+	ErrCodeInvalidGameMode    = "INVALID_GAME_MODE"
+	ErrCodeInvalidTieRule     = "INVALID_TIE_RULE"
+	ErrCodeAlreadyVoted       = "ALREADY_VOTED"
+	ErrCodeOwnSubmission      = "OWN_SUBMISSION"
+	ErrCodePlayerBanned       = "PLAYER_BANNED"
+	ErrCodePlayerMuted        = "PLAYER_MUTED"
+	ErrCodeAlreadyBanned      = "ALREADY_BANNED"
+	ErrCodeAlreadyMuted       = "ALREADY_MUTED"
+	ErrCodeNotMuted           = "NOT_MUTED"
+	ErrCodeCannotModerateSelf = "CANNOT_MODERATE_SELF"
+	ErrCodeCannotModerateBot  = "CANNOT_MODERATE_BOT"
+	ErrCodeNotSpectating      = "NOT_SPECTATING"
+	ErrCodeAlreadyPromoted    = "ALREADY_PROMOTED"
+	ErrCodeGamePaused         = "GAME_PAUSED"
+	ErrCodeGameNotPaused      = "GAME_NOT_PAUSED"
+	ErrCodeRematchStarted     = "REMATCH_STARTED"
+	ErrCodeInvalidVisibility  = "INVALID_VISIBILITY"
+	ErrCodeInviteRequired     = "INVITE_REQUIRED"
+	ErrCodeInviteNotFound     = "INVITE_NOT_FOUND"
+	ErrCodeWrongPassword      = "WRONG_PASSWORD"
+	ErrCodeGameNotPrivate     = "GAME_NOT_PRIVATE"
+	ErrCodeInvalidName        = "INVALID_NAME"
+	ErrCodeInvalidWinnerCount = "INVALID_WINNER_COUNT"
+	ErrCodeInvalidTimers      = "INVALID_TIMERS"
+	ErrCodePlayersNotReady    = "PLAYERS_NOT_READY"
+	ErrCodeAlreadyReady       = "ALREADY_READY"
+	ErrCodeNotReady           = "NOT_READY"
+	ErrCodeInvalidMessage     = "INVALID_MESSAGE"
+	ErrCodeInvalidPayload     = "INVALID_PAYLOAD"
+	ErrCodeUnknownMessageType = "UNKNOWN_MESSAGE_TYPE"
+	ErrCodeCommandRejected    = "COMMAND_REJECTED"
+	WarnCodeAlreadyJoined     = "ALREADY_JOINED"
+	WarnCodeDeckReshuffle     = "DECK_RESHUFFLE"
+	WarnCodeOwnerLeaving      = "OWNER_LEAVING"
+	WarnCodeJudgeLeaving      = "JUDGE_LEAVING"
+	WarnCodeLastPlayerLeaving = "LAST_PLAYER_LEAVING"
+)
@@ -0,0 +1,818 @@
+package validation
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/valueobjects"
+	"fmt"
+	"strings"
+)
+
+// GameRulesValidator checks actions against the current state of a single
+// game. It only reads the game, so it must be used from whatever owns it.
+type GameRulesValidator struct {
+	game *aggregates.Game
+}
+
+func NewGameRulesValidator(game *aggregates.Game) *GameRulesValidator {
+	return &GameRulesValidator{
+		game: game,
+	}
+}
+
+func (v *GameRulesValidator) ValidatePlayCard(gameID, playerID string, cardIDs []string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkInProgress(&result, gameID) {
+		return result
+	}
+
+	if !v.checkNotPaused(&result) {
+		return result
+	}
+
+	if !v.game.RoundStatus.CanPlayCards() {
+		result.AddError(ErrCodeWrongRoundStatus, fmt.Sprintf("cannot play a card while round status is %s", v.game.RoundStatus), "")
+		return result
+	}
+
+	player := v.findPlayer(&result, playerID)
+
+	if player == nil {
+		return result
+	}
+
+	if player.IsJudge {
+		result.AddError(ErrCodeJudgeCannotPlay, "the judge cannot play a white card", "")
+		return result
+	}
+
+	if player.HasAlreadyPlayedWhiteCard() {
+		result.AddError(ErrCodeAlreadyPlayed, "you have already played a white card this round", "")
+		return result
+	}
+
+	pick := v.game.BlackCard.PickCount()
+
+	if len(cardIDs) != pick {
+		result.AddError(ErrCodeWrongCardCount, fmt.Sprintf("the black card asks for %d white cards, %d were played", pick, len(cardIDs)), "card_ids")
+		return result
+	}
+
+	played := make(map[string]bool)
+
+	for _, cardID := range cardIDs {
+		if played[cardID] {
+			result.AddError(ErrCodeDuplicateCard, fmt.Sprintf("card %s was played more than once", cardID), "card_ids")
+			return result
+		}
+
+		played[cardID] = true
+
+		if _, err := v.game.FindCardByPlayerId(playerID, cardID); err != nil {
+			result.AddError(ErrCodeCardNotInHand, fmt.Sprintf("card %s is not in your hand", cardID), "card_ids")
+			return result
+		}
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateVote(gameID, playerID, submissionID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkInProgress(&result, gameID) {
+		return result
+	}
+
+	if !v.checkNotPaused(&result) {
+		return result
+	}
+
+	if !v.game.RoundStatus.CanPickWinningCard() {
+		result.AddError(ErrCodeWrongRoundStatus, fmt.Sprintf("cannot pick a winning card while round status is %s", v.game.RoundStatus), "")
+		return result
+	}
+
+	player := v.findPlayer(&result, playerID)
+
+	if player == nil {
+		return result
+	}
+
+	if !player.IsJudge {
+		result.AddError(ErrCodeNotJudge, "only the judge can pick the winning card", "")
+		return result
+	}
+
+	hasAllPlayersPlayedWhiteCard, err := v.game.HasAllPlayersPlayedWhiteCard()
+
+	if err != nil || !hasAllPlayersPlayedWhiteCard {
+		result.AddError(ErrCodeNotAllPlayed, "not all players have played a white card", "")
+		return result
+	}
+
+	submission, err := v.game.FindSubmission(submissionID)
+
+	if err != nil {
+		result.AddError(ErrCodeSubmissionNotFound, fmt.Sprintf("submission %s is not on the board", submissionID), "submission_id")
+		return result
+	}
+
+	if _, err := v.game.FindSubmissionOwner(submission); err != nil {
+		result.AddError(ErrCodeSubmissionNotFound, fmt.Sprintf("submission %s was not played by anyone this round", submissionID), "submission_id")
+	}
+
+	return result
+}
+
+// ValidateCastVote checks a player's vote for their favorite submission in a
+// democracy round. ValidateVote covers the judge's pick.
+func (v *GameRulesValidator) ValidateCastVote(gameID, playerID, submissionID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkInProgress(&result, gameID) {
+		return result
+	}
+
+	if !v.checkNotPaused(&result) {
+		return result
+	}
+
+	if !v.game.RoundStatus.CanVote() {
+		result.AddError(ErrCodeWrongRoundStatus, fmt.Sprintf("cannot vote while round status is %s", v.game.RoundStatus), "")
+		return result
+	}
+
+	player := v.findPlayer(&result, playerID)
+
+	if player == nil {
+		return result
+	}
+
+	if v.game.HasVoted(player.UserID) {
+		result.AddError(ErrCodeAlreadyVoted, "you have already voted this round", "")
+		return result
+	}
+
+	submission, err := v.game.FindSubmission(submissionID)
+
+	if err != nil {
+		result.AddError(ErrCodeSubmissionNotFound, fmt.Sprintf("submission %s is not on the board", submissionID), "submission_id")
+		return result
+	}
+
+	if submission.UserID == player.UserID {
+		result.AddError(ErrCodeOwnSubmission, "you cannot vote for your own submission", "submission_id")
+	}
+
+	return result
+}
+
+// ValidateStartRound checks that the game is between rounds, or about to
+// begin, and that the collection can supply another round.
+func (v *GameRulesValidator) ValidateStartRound(gameID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) {
+		return result
+	}
+
+	switch {
+	case v.game.IsInSetup():
+	case v.game.IsInProgress() && v.game.RoundStatus.CanContinueRound():
+	case v.game.IsInProgress():
+		result.AddError(ErrCodeWrongRoundStatus, fmt.Sprintf("cannot start a round while round status is %s", v.game.RoundStatus), "")
+		return result
+	default:
+		result.AddError(ErrCodeWrongGameStatus, fmt.Sprintf("cannot start a round while game status is %s", v.game.Status), "")
+		return result
+	}
+
+	if !v.game.HasCollection() {
+		result.AddError(ErrCodeNotEnoughCards, "the game has no cards", "")
+		return result
+	}
+
+	blackCards, whiteCards := 0, 0
+
+	for _, card := range v.game.Collection.Cards {
+		switch card.Type {
+		case "Black":
+			blackCards++
+		case "White":
+			whiteCards++
+		}
+	}
+
+	if blackCards == 0 || whiteCards < len(v.game.Players) {
+		result.AddError(ErrCodeNotEnoughCards, "the collection does not have enough cards for another round", "")
+		return result
+	}
+
+	if v.game.ShouldShuffle() {
+		result.AddWarning(WarnCodeDeckReshuffle, "the deck has run low and will be reshuffled")
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateJoinGame(gameID, playerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) {
+		return result
+	}
+
+	if !v.checkInvited(&result, playerID) {
+		return result
+	}
+
+	if player, err := v.game.FindPlayerByUserId(playerID); err == nil && player != nil {
+		result.AddWarning(WarnCodeAlreadyJoined, "you are already in this game")
+		return result
+	}
+
+	if v.game.IsFull() {
+		result.AddError(ErrCodeGameFull, fmt.Sprintf("the game is full, %d of %d players", len(v.game.GetHumanPlayers()), v.game.MaxPlayerCount), "")
+	}
+
+	return result
+}
+
+// ValidateWatchGame checks a user watching the game without a seat, which
+// anyone who hasn't been banned may do. Private games need an invite.
+func (v *GameRulesValidator) ValidateWatchGame(gameID, playerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) {
+		return result
+	}
+
+	v.checkInvited(&result, playerID)
+
+	return result
+}
+
+// ValidateAcceptInvite checks a user getting into a private game with its
+// invite code. The password, if the game has one, is checked by the caller.
+func (v *GameRulesValidator) ValidateAcceptInvite(gameID, playerID, inviteCode string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) {
+		return result
+	}
+
+	if v.game.IsBanned(playerID) {
+		result.AddError(ErrCodePlayerBanned, "you have been banned from this game", "")
+		return result
+	}
+
+	if !v.game.IsPrivate() || inviteCode != v.game.InviteCode {
+		result.AddError(ErrCodeInviteNotFound, fmt.Sprintf("invite code %s was not found", inviteCode), "invite_code")
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateRotateInviteCode(gameID, ownerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) || !v.checkOwner(&result, ownerID, "rotate the invite code") {
+		return result
+	}
+
+	if !v.game.IsPrivate() {
+		result.AddError(ErrCodeGameNotPrivate, "only private games have an invite code", "")
+	}
+
+	return result
+}
+
+// checkInvited checks that the user hasn't been banned and, for a private
+// game, is either in it already or has accepted an invite.
+func (v *GameRulesValidator) checkInvited(result *ValidationResult, playerID string) bool {
+	if v.game.IsBanned(playerID) {
+		result.AddError(ErrCodePlayerBanned, "you have been banned from this game", "")
+		return false
+	}
+
+	if !v.game.IsVisibleTo(playerID) {
+		result.AddError(ErrCodeInviteRequired, "this game is private, you need an invite to join it", "")
+		return false
+	}
+
+	return true
+}
+
+func (v *GameRulesValidator) ValidateLeaveGame(gameID, playerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) {
+		return result
+	}
+
+	player := v.findPlayer(&result, playerID)
+
+	if player == nil {
+		return result
+	}
+
+	if len(v.game.GetHumanPlayers()) == 1 {
+		result.AddWarning(WarnCodeLastPlayerLeaving, "the last player is leaving the game")
+	}
+
+	if player.IsOwner {
+		result.AddWarning(WarnCodeOwnerLeaving, "the owner is leaving the game")
+	}
+
+	if player.IsJudge && v.game.IsInProgress() {
+		result.AddWarning(WarnCodeJudgeLeaving, "the judge is leaving mid round")
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateEndGame(gameID string) ValidationResult {
+	result := NewValidationResult()
+
+	v.checkInProgress(&result, gameID)
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateBeginGame(gameID, playerID string, force bool) ValidationResult {
+	result := v.ValidateStartRound(gameID)
+
+	if !result.IsValid {
+		return result
+	}
+
+	if !v.game.IsInSetup() {
+		result.AddError(ErrCodeWrongGameStatus, fmt.Sprintf("cannot begin a game while game status is %s", v.game.Status), "")
+		return result
+	}
+
+	player := v.findPlayer(&result, playerID)
+
+	if player == nil {
+		return result
+	}
+
+	if !player.IsOwner {
+		result.AddError(ErrCodeNotGameOwner, "only the owner can begin the game", "")
+		return result
+	}
+
+	if humanPlayers := len(v.game.GetHumanPlayers()); humanPlayers < v.game.RequiredPlayerCount() {
+		result.AddError(ErrCodeNotEnoughPlayers, fmt.Sprintf("the game needs at least %d players to begin, %d have joined", v.game.RequiredPlayerCount(), humanPlayers), "")
+		return result
+	}
+
+	if unready := len(v.game.GetUnreadyPlayers()); unready > 0 && !force {
+		result.AddError(ErrCodePlayersNotReady, fmt.Sprintf("%d players are not ready yet, begin anyway to start without them", unready), "")
+	}
+
+	return result
+}
+
+// ValidateReady checks a player in the lobby saying they are, or are no
+// longer, ready for the game to begin.
+func (v *GameRulesValidator) ValidateReady(gameID, playerID string, ready bool) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) {
+		return result
+	}
+
+	if !v.game.IsInSetup() {
+		result.AddError(ErrCodeWrongGameStatus, fmt.Sprintf("cannot get ready while game status is %s", v.game.Status), "")
+		return result
+	}
+
+	player := v.findPlayer(&result, playerID)
+
+	if player == nil {
+		return result
+	}
+
+	if ready && player.IsReady {
+		result.AddError(ErrCodeAlreadyReady, "you are already ready", "")
+	}
+
+	if !ready && !player.IsReady {
+		result.AddError(ErrCodeNotReady, "you are not ready yet", "")
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateContinueRound(gameID, playerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkInProgress(&result, gameID) {
+		return result
+	}
+
+	if !v.checkNotPaused(&result) {
+		return result
+	}
+
+	result = v.ValidateStartRound(gameID)
+
+	if !result.IsValid {
+		return result
+	}
+
+	v.findPlayer(&result, playerID)
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateReaction(gameID, playerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) {
+		return result
+	}
+
+	// Spectators can react too
+	player, err := v.game.FindPlayerOrSpectator(playerID)
+
+	if err != nil {
+		result.AddError(ErrCodePlayerNotInGame, "you are not in this game", "")
+		return result
+	}
+
+	if player.IsMuted {
+		result.AddError(ErrCodePlayerMuted, "you have been muted in this game", "")
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateKickPlayer(gameID, ownerID, playerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkModerator(&result, gameID, ownerID, playerID) {
+		return result
+	}
+
+	v.findModeratedPlayer(&result, playerID)
+
+	return result
+}
+
+// ValidateBanPlayer checks the owner banning a user. The user doesn't need to
+// be in the game, so someone who already left can be kept from coming back.
+func (v *GameRulesValidator) ValidateBanPlayer(gameID, ownerID, playerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkModerator(&result, gameID, ownerID, playerID) {
+		return result
+	}
+
+	if player, err := v.game.FindPlayerByUserId(playerID); err == nil && player.IsBot {
+		result.AddError(ErrCodeCannotModerateBot, "bots cannot be moderated", "player_id")
+		return result
+	}
+
+	if v.game.IsBanned(playerID) {
+		result.AddError(ErrCodeAlreadyBanned, "the player is already banned from this game", "player_id")
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateMutePlayer(gameID, ownerID, playerID string, muted bool) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkModerator(&result, gameID, ownerID, playerID) {
+		return result
+	}
+
+	player := v.findModeratedPlayer(&result, playerID)
+
+	if player == nil {
+		return result
+	}
+
+	if muted && player.IsMuted {
+		result.AddError(ErrCodeAlreadyMuted, "the player is already muted", "player_id")
+		return result
+	}
+
+	if !muted && !player.IsMuted {
+		result.AddError(ErrCodeNotMuted, "the player is not muted", "player_id")
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateRebootUniverse(gameID, playerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) {
+		return result
+	}
+
+	if !v.game.HouseRules.RebootingTheUniverse {
+		result.AddError(ErrCodeHouseRuleDisabled, "rebooting the universe is not allowed in this game", "")
+		return result
+	}
+
+	player := v.checkRedrawHand(&result, gameID, playerID)
+
+	if player == nil {
+		return result
+	}
+
+	if player.Score < 1 {
+		result.AddError(ErrCodeNotEnoughPoints, "you need an awesome point to reboot the universe", "")
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateMulligan(gameID, playerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) {
+		return result
+	}
+
+	if !v.game.HouseRules.Mulligan {
+		result.AddError(ErrCodeHouseRuleDisabled, "mulligans are not allowed in this game", "")
+		return result
+	}
+
+	player := v.checkRedrawHand(&result, gameID, playerID)
+
+	if player == nil {
+		return result
+	}
+
+	if player.HasMulliganed {
+		result.AddError(ErrCodeMulliganUsed, "you have already taken your mulligan this game", "")
+	}
+
+	return result
+}
+
+// checkRedrawHand checks that the player may swap their hand for a new one,
+// which is only while they still have a card to pick.
+func (v *GameRulesValidator) checkRedrawHand(result *ValidationResult, gameID, playerID string) *aggregates.Player {
+	if !v.checkInProgress(result, gameID) {
+		return nil
+	}
+
+	if v.game.RoundStatus != valueobjects.PlayersPickingCard {
+		result.AddError(ErrCodeWrongRoundStatus, fmt.Sprintf("cannot redraw your hand while round status is %s", v.game.RoundStatus), "")
+		return nil
+	}
+
+	player := v.findPlayer(result, playerID)
+
+	if player == nil {
+		return nil
+	}
+
+	if player.HasAlreadyPlayedWhiteCard() {
+		result.AddError(ErrCodeAlreadyPlayed, "you cannot redraw your hand after playing this round", "")
+		return nil
+	}
+
+	return player
+}
+
+func (v *GameRulesValidator) checkGame(result *ValidationResult, gameID string) bool {
+	if v.game == nil || v.game.ID != gameID {
+		result.AddError(ErrCodeGameNotFound, fmt.Sprintf("game %s was not found", gameID), "game_id")
+		return false
+	}
+
+	return true
+}
+
+func (v *GameRulesValidator) checkInProgress(result *ValidationResult, gameID string) bool {
+	if !v.checkGame(result, gameID) {
+		return false
+	}
+
+	if !v.game.IsInProgress() {
+		result.AddError(ErrCodeWrongGameStatus, fmt.Sprintf("game is %s, not in progress", v.game.Status), "")
+		return false
+	}
+
+	return true
+}
+
+// ValidatePromoteSpectator checks the owner giving a spectator the next free
+// seat. Seats already promised to other spectators count as taken.
+func (v *GameRulesValidator) ValidatePromoteSpectator(gameID, ownerID, playerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkModerator(&result, gameID, ownerID, playerID) {
+		return result
+	}
+
+	if v.game.IsFinished() {
+		result.AddError(ErrCodeWrongGameStatus, fmt.Sprintf("cannot promote a spectator while game status is %s", v.game.Status), "")
+		return result
+	}
+
+	spectator, err := v.game.FindSpectator(playerID)
+
+	if err != nil {
+		result.AddError(ErrCodeNotSpectating, fmt.Sprintf("player %s is not watching this game", playerID), "player_id")
+		return result
+	}
+
+	if spectator.IsPromoted {
+		result.AddError(ErrCodeAlreadyPromoted, "the spectator already has the next free seat", "player_id")
+		return result
+	}
+
+	if !v.game.HasOpenSeat() {
+		result.AddError(ErrCodeGameFull, fmt.Sprintf("the game has no free seat, %d of %d are taken", len(v.game.GetHumanPlayers())+len(v.game.GetPromotedSpectators()), v.game.MaxPlayerCount), "")
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidatePauseGame(gameID, ownerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkInProgress(&result, gameID) || !v.checkOwner(&result, ownerID, "pause the game") {
+		return result
+	}
+
+	if v.game.IsPaused() {
+		result.AddError(ErrCodeGamePaused, "the game is already paused", "")
+	}
+
+	return result
+}
+
+func (v *GameRulesValidator) ValidateResumeGame(gameID, ownerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkInProgress(&result, gameID) || !v.checkOwner(&result, ownerID, "resume the game") {
+		return result
+	}
+
+	if !v.game.IsPaused() {
+		result.AddError(ErrCodeGameNotPaused, "the game is not paused", "")
+	}
+
+	return result
+}
+
+// ValidateForceAdvance checks the owner moving the round on without waiting
+// for the players holding it up.
+func (v *GameRulesValidator) ValidateForceAdvance(gameID, ownerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkInProgress(&result, gameID) || !v.checkOwner(&result, ownerID, "move the round on") {
+		return result
+	}
+
+	v.checkNotPaused(&result)
+
+	return result
+}
+
+// ValidateRematch checks the owner starting a rematch of a finished game. Only
+// one rematch can be started from each game.
+func (v *GameRulesValidator) ValidateRematch(gameID, ownerID string) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) || !v.checkOwner(&result, ownerID, "start a rematch") {
+		return result
+	}
+
+	if !v.game.IsFinished() {
+		result.AddError(ErrCodeWrongGameStatus, fmt.Sprintf("cannot start a rematch while game status is %s", v.game.Status), "")
+		return result
+	}
+
+	if v.game.RematchGameID != "" {
+		result.AddError(ErrCodeRematchStarted, "a rematch of this game has already been started", "")
+	}
+
+	return result
+}
+
+// ValidateChangeSettings checks the owner changing the game's settings before
+// it begins. The game must still seat everyone who has already joined.
+func (v *GameRulesValidator) ValidateChangeSettings(gameID, ownerID string, settings aggregates.GameSettings) ValidationResult {
+	result := NewValidationResult()
+
+	if !v.checkGame(&result, gameID) || !v.checkOwner(&result, ownerID, "change the settings") {
+		return result
+	}
+
+	if !v.game.IsInSetup() {
+		result.AddError(ErrCodeWrongGameStatus, fmt.Sprintf("cannot change the settings while game status is %s", v.game.Status), "")
+		return result
+	}
+
+	if strings.TrimSpace(settings.Name) == "" {
+		result.AddError(ErrCodeInvalidName, "the game needs a name", "name")
+	}
+
+	if settings.WinnerCount < 1 {
+		result.AddError(ErrCodeInvalidWinnerCount, "the game needs at least one winner", "winner_count")
+	}
+
+	if settings.MaxPlayerCount < v.game.RequiredPlayerCount() {
+		result.AddError(ErrCodeInvalidPlayerCount, fmt.Sprintf("max player count must be at least %d", v.game.RequiredPlayerCount()), "max_player_count")
+	} else if humanPlayers := len(v.game.GetHumanPlayers()); settings.MaxPlayerCount < humanPlayers {
+		result.AddError(ErrCodeInvalidPlayerCount, fmt.Sprintf("max player count cannot be less than the %d players who have joined", humanPlayers), "max_player_count")
+	}
+
+	if settings.HandSize < aggregates.MinHandSize || settings.HandSize > aggregates.MaxHandSize {
+		result.AddError(ErrCodeInvalidHandSize, fmt.Sprintf("hand size must be between %d and %d", aggregates.MinHandSize, aggregates.MaxHandSize), "hand_size")
+	}
+
+	if !settings.Timers.IsValid() {
+		result.AddError(ErrCodeInvalidTimers, fmt.Sprintf("timers must be between 0 and %d seconds", valueobjects.MaxTimerSeconds), "timers")
+	}
+
+	if !settings.Visibility.IsValid() {
+		result.AddError(ErrCodeInvalidVisibility, fmt.Sprintf("visibility %s is not valid", settings.Visibility), "visibility")
+	}
+
+	if settings.PasswordHash != "" && !settings.Visibility.IsPrivate() {
+		result.AddError(ErrCodeInvalidVisibility, "only private games can have a password", "password")
+	}
+
+	return result
+}
+
+// checkModerator checks that the owner is the one moderating, and that they
+// are not moderating themselves.
+func (v *GameRulesValidator) checkModerator(result *ValidationResult, gameID, ownerID, playerID string) bool {
+	if !v.checkGame(result, gameID) || !v.checkOwner(result, ownerID, "moderate players") {
+		return false
+	}
+
+	if playerID == ownerID {
+		result.AddError(ErrCodeCannotModerateSelf, "you cannot moderate yourself", "player_id")
+		return false
+	}
+
+	return true
+}
+
+// checkOwner checks that the player is the game's owner, who alone may take
+// the given action.
+func (v *GameRulesValidator) checkOwner(result *ValidationResult, ownerID, action string) bool {
+	owner := v.findPlayer(result, ownerID)
+
+	if owner == nil {
+		return false
+	}
+
+	if !owner.IsOwner {
+		result.AddError(ErrCodeNotGameOwner, fmt.Sprintf("only the owner can %s", action), "")
+		return false
+	}
+
+	return true
+}
+
+func (v *GameRulesValidator) checkNotPaused(result *ValidationResult) bool {
+	if v.game.IsPaused() {
+		result.AddError(ErrCodeGamePaused, "the game is paused", "")
+		return false
+	}
+
+	return true
+}
+
+func (v *GameRulesValidator) findModeratedPlayer(result *ValidationResult, playerID string) *aggregates.Player {
+	player, err := v.game.FindPlayerOrSpectator(playerID)
+
+	if err != nil {
+		result.AddError(ErrCodePlayerNotInGame, fmt.Sprintf("player %s is not in this game", playerID), "player_id")
+		return nil
+	}
+
+	if player.IsBot {
+		result.AddError(ErrCodeCannotModerateBot, "bots cannot be moderated", "player_id")
+		return nil
+	}
+
+	return player
+}
+
+func (v *GameRulesValidator) findPlayer(result *ValidationResult, playerID string) *aggregates.Player {
+	player, err := v.game.FindPlayerByUserId(playerID)
+
+	if err != nil {
+		result.AddError(ErrCodePlayerNotInGame, "you are not a player in this game", "")
+		return nil
+	}
+
+	return player
+}
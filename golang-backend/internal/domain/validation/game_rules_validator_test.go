@@ -0,0 +1,465 @@
+package validation
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/valueobjects"
+	"fmt"
+	"testing"
+	"time"
+)
+
+const testGameID = "game-1"
+
+func testCards(prefix string, count int) []*entities.Card {
+	cards := []*entities.Card{}
+
+	for i := 0; i < count; i++ {
+		cards = append(cards, entities.NewCard(fmt.Sprintf("%s%d", prefix, i), entities.White, fmt.Sprintf("white %s%d", prefix, i)))
+	}
+
+	return cards
+}
+
+// newTestGame is a game in the middle of a round. The owner u1 is the judge,
+// u2 and u3 still have to play, and u4 is watching.
+func newTestGame() *aggregates.Game {
+	collection := aggregates.NewCollection()
+
+	for _, card := range testCards("w", 40) {
+		collection.AddCard(card)
+	}
+
+	for i := 0; i < 5; i++ {
+		collection.AddCard(entities.NewCard(fmt.Sprintf("b%d", i), entities.Black, fmt.Sprintf("black _____ %d", i)))
+	}
+
+	return &aggregates.Game{
+		ID:             testGameID,
+		Name:           "test",
+		Collection:     collection,
+		WinnerCount:    3,
+		MaxPlayerCount: 6,
+		HandSize:       5,
+		Visibility:     valueobjects.PublicVisibility,
+		Status:         valueobjects.InProgress,
+		RoundStatus:    valueobjects.PlayersPickingCard,
+		BlackCard:      collection.Cards[40],
+		Players: []*aggregates.Player{
+			{UserID: "u1", IsOwner: true, IsJudge: true, Deck: testCards("a", 5)},
+			{UserID: "u2", Deck: testCards("w", 5)},
+			{UserID: "u3", Deck: testCards("c", 5)},
+		},
+		Spectators: []*aggregates.Player{{UserID: "u4"}},
+	}
+}
+
+// inSetup puts the test game back in its lobby.
+func inSetup(g *aggregates.Game) {
+	g.Status = valueobjects.Setup
+	g.RoundStatus = valueobjects.Waiting
+	g.BlackCard = nil
+	g.Players[0].IsJudge = false
+}
+
+// everyonePlayed has every player but the judge submit their first card and
+// hands the round to the judge.
+func everyonePlayed(g *aggregates.Game) {
+	g.RoundStatus = valueobjects.JudgePickingWinningCard
+
+	for _, player := range g.Players {
+		if player.IsJudge {
+			continue
+		}
+
+		player.PlacedCards = player.Deck[:1]
+		g.Submissions = append(g.Submissions, entities.NewSubmission("s-"+player.UserID, player.UserID, player.PlacedCards))
+	}
+}
+
+func changedSettings(g *aggregates.Game, change func(s *aggregates.GameSettings)) aggregates.GameSettings {
+	settings := g.Settings()
+	change(&settings)
+
+	return settings
+}
+
+func TestGameRulesValidatorErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		setup    func(g *aggregates.Game)
+		validate func(v *GameRulesValidator, g *aggregates.Game) ValidationResult
+	}{
+		{"another game", ErrCodeGameNotFound, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateJoinGame("game-2", "u5")
+		}},
+		{"join a full game", ErrCodeGameFull, func(g *aggregates.Game) { g.MaxPlayerCount = 3 }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateJoinGame(testGameID, "u5")
+		}},
+		{"begin without enough players", ErrCodeNotEnoughPlayers, func(g *aggregates.Game) {
+			inSetup(g)
+			g.Players = g.Players[:2]
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateBeginGame(testGameID, "u1", true)
+		}},
+		{"play before the game begins", ErrCodeWrongGameStatus, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u2", []string{"w0"})
+		}},
+		{"begin twice", ErrCodeWrongRoundStatus, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateBeginGame(testGameID, "u1", true)
+		}},
+		{"play while the judge picks", ErrCodeWrongRoundStatus, func(g *aggregates.Game) { g.RoundStatus = valueobjects.JudgePickingWinningCard }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u2", []string{"w0"})
+		}},
+		{"play without a seat", ErrCodePlayerNotInGame, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u5", []string{"w0"})
+		}},
+		{"react from outside the game", ErrCodePlayerNotInGame, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateReaction(testGameID, "u5")
+		}},
+		{"play while watching", ErrCodePlayerNotInGame, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u4", []string{"w0"})
+		}},
+		{"join while banned", ErrCodePlayerBanned, func(g *aggregates.Game) { g.BannedUserIDs = []string{"u5"} }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateJoinGame(testGameID, "u5")
+		}},
+		{"accept an invite while banned", ErrCodePlayerBanned, func(g *aggregates.Game) {
+			g.Visibility = valueobjects.PrivateVisibility
+			g.InviteCode = "CODE"
+			g.BannedUserIDs = []string{"u5"}
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateAcceptInvite(testGameID, "u5", "CODE")
+		}},
+		{"react while muted", ErrCodePlayerMuted, func(g *aggregates.Game) { g.Players[1].IsMuted = true }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateReaction(testGameID, "u2")
+		}},
+		{"ban twice", ErrCodeAlreadyBanned, func(g *aggregates.Game) { g.BannedUserIDs = []string{"u5"} }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateBanPlayer(testGameID, "u1", "u5")
+		}},
+		{"mute twice", ErrCodeAlreadyMuted, func(g *aggregates.Game) { g.Players[1].IsMuted = true }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateMutePlayer(testGameID, "u1", "u2", true)
+		}},
+		{"unmute someone who can talk", ErrCodeNotMuted, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateMutePlayer(testGameID, "u1", "u2", false)
+		}},
+		{"kick someone who isn't there", ErrCodePlayerNotInGame, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateKickPlayer(testGameID, "u1", "u5")
+		}},
+		{"kick yourself", ErrCodeCannotModerateSelf, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateKickPlayer(testGameID, "u1", "u1")
+		}},
+		{"kick a bot", ErrCodeCannotModerateBot, func(g *aggregates.Game) { g.Players[2].IsBot = true }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateKickPlayer(testGameID, "u1", "u3")
+		}},
+		{"ban a bot", ErrCodeCannotModerateBot, func(g *aggregates.Game) { g.Players[2].IsBot = true }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateBanPlayer(testGameID, "u1", "u3")
+		}},
+		{"mute a bot", ErrCodeCannotModerateBot, func(g *aggregates.Game) { g.Players[2].IsBot = true }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateMutePlayer(testGameID, "u1", "u3", true)
+		}},
+		{"promote a player", ErrCodeNotSpectating, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePromoteSpectator(testGameID, "u1", "u2")
+		}},
+		{"promote twice", ErrCodeAlreadyPromoted, func(g *aggregates.Game) { g.Spectators[0].IsPromoted = true }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePromoteSpectator(testGameID, "u1", "u4")
+		}},
+		{"promote without a free seat", ErrCodeGameFull, func(g *aggregates.Game) { g.MaxPlayerCount = 3 }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePromoteSpectator(testGameID, "u1", "u4")
+		}},
+		{"begin someone else's game", ErrCodeNotGameOwner, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateBeginGame(testGameID, "u2", true)
+		}},
+		{"pick the winner without judging", ErrCodeNotJudge, everyonePlayed, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateVote(testGameID, "u2", "s-u3")
+		}},
+		{"play a card from someone else's hand", ErrCodeCardNotInHand, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u2", []string{"c0"})
+		}},
+		{"pick a submission that isn't there", ErrCodeSubmissionNotFound, everyonePlayed, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateVote(testGameID, "u1", "s-u9")
+		}},
+		{"play too many cards", ErrCodeWrongCardCount, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u2", []string{"w0", "w1"})
+		}},
+		{"play a card twice", ErrCodeDuplicateCard, func(g *aggregates.Game) { g.BlackCard.Pick = 2 }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u2", []string{"w0", "w0"})
+		}},
+		{"judge plays", ErrCodeJudgeCannotPlay, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u1", []string{"a0"})
+		}},
+		{"play twice", ErrCodeAlreadyPlayed, func(g *aggregates.Game) {
+			everyonePlayed(g)
+			g.RoundStatus = valueobjects.PlayersPickingCard
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u2", []string{"w1"})
+		}},
+		{"pick the winner early", ErrCodeNotAllPlayed, func(g *aggregates.Game) { g.RoundStatus = valueobjects.JudgePickingWinningCard }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateVote(testGameID, "u1", "s-u2")
+		}},
+		{"vote while the judge picks", ErrCodeWrongRoundStatus, everyonePlayed, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateCastVote(testGameID, "u2", "s-u3")
+		}},
+		{"vote twice", ErrCodeAlreadyVoted, func(g *aggregates.Game) {
+			everyonePlayed(g)
+			g.RoundStatus = valueobjects.PlayersVoting
+			g.Votes = map[string]string{"u2": "s-u3"}
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateCastVote(testGameID, "u2", "s-u3")
+		}},
+		{"vote for yourself", ErrCodeOwnSubmission, func(g *aggregates.Game) {
+			everyonePlayed(g)
+			g.RoundStatus = valueobjects.PlayersVoting
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateCastVote(testGameID, "u2", "s-u2")
+		}},
+		{"mulligan without the house rule", ErrCodeHouseRuleDisabled, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateMulligan(testGameID, "u2")
+		}},
+		{"mulligan twice", ErrCodeMulliganUsed, func(g *aggregates.Game) {
+			g.HouseRules.Mulligan = true
+			g.Players[1].HasMulliganed = true
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateMulligan(testGameID, "u2")
+		}},
+		{"mulligan after playing", ErrCodeAlreadyPlayed, func(g *aggregates.Game) {
+			g.HouseRules.Mulligan = true
+			everyonePlayed(g)
+			g.RoundStatus = valueobjects.PlayersPickingCard
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateMulligan(testGameID, "u2")
+		}},
+		{"reboot the universe without a point", ErrCodeNotEnoughPoints, func(g *aggregates.Game) { g.HouseRules.RebootingTheUniverse = true }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateRebootUniverse(testGameID, "u2")
+		}},
+		{"pause someone else's game", ErrCodeNotGameOwner, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePauseGame(testGameID, "u2")
+		}},
+		{"play while paused", ErrCodeGamePaused, func(g *aggregates.Game) { g.PausedAt = time.Now() }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u2", []string{"w0"})
+		}},
+		{"force the round on while paused", ErrCodeGamePaused, func(g *aggregates.Game) { g.PausedAt = time.Now() }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateForceAdvance(testGameID, "u1")
+		}},
+		{"resume a running game", ErrCodeGameNotPaused, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateResumeGame(testGameID, "u1")
+		}},
+		{"rematch a running game", ErrCodeWrongGameStatus, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateRematch(testGameID, "u1")
+		}},
+		{"rematch twice", ErrCodeRematchStarted, func(g *aggregates.Game) {
+			g.Status = valueobjects.Finished
+			g.RematchGameID = "game-2"
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateRematch(testGameID, "u1")
+		}},
+		{"join a private game uninvited", ErrCodeInviteRequired, func(g *aggregates.Game) { g.Visibility = valueobjects.PrivateVisibility }, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateJoinGame(testGameID, "u5")
+		}},
+		{"wrong invite code", ErrCodeInviteNotFound, func(g *aggregates.Game) {
+			g.Visibility = valueobjects.PrivateVisibility
+			g.InviteCode = "CODE"
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateAcceptInvite(testGameID, "u5", "OTHER")
+		}},
+		{"invite code of a public game", ErrCodeInviteNotFound, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateAcceptInvite(testGameID, "u5", "")
+		}},
+		{"rotate the code of a public game", ErrCodeGameNotPrivate, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateRotateInviteCode(testGameID, "u1")
+		}},
+		{"hand too small", ErrCodeInvalidHandSize, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateChangeSettings(testGameID, "u1", changedSettings(g, func(s *aggregates.GameSettings) { s.HandSize = aggregates.MinHandSize - 1 }))
+		}},
+		{"hand too big", ErrCodeInvalidHandSize, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateChangeSettings(testGameID, "u1", changedSettings(g, func(s *aggregates.GameSettings) { s.HandSize = aggregates.MaxHandSize + 1 }))
+		}},
+		{"unknown visibility", ErrCodeInvalidVisibility, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateChangeSettings(testGameID, "u1", changedSettings(g, func(s *aggregates.GameSettings) { s.Visibility = "Secret" }))
+		}},
+		{"password on a public game", ErrCodeInvalidVisibility, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateChangeSettings(testGameID, "u1", changedSettings(g, func(s *aggregates.GameSettings) { s.PasswordHash = "hash" }))
+		}},
+		{"blank name", ErrCodeInvalidName, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateChangeSettings(testGameID, "u1", changedSettings(g, func(s *aggregates.GameSettings) { s.Name = "  " }))
+		}},
+		{"no winners", ErrCodeInvalidWinnerCount, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateChangeSettings(testGameID, "u1", changedSettings(g, func(s *aggregates.GameSettings) { s.WinnerCount = 0 }))
+		}},
+		{"timer too long", ErrCodeInvalidTimers, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			seconds := valueobjects.MaxTimerSeconds + 1
+
+			return v.ValidateChangeSettings(testGameID, "u1", changedSettings(g, func(s *aggregates.GameSettings) { s.Timers.PickingSeconds = &seconds }))
+		}},
+		{"change settings after the game began", ErrCodeWrongGameStatus, nil, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateChangeSettings(testGameID, "u1", g.Settings())
+		}},
+		{"begin before everyone is ready", ErrCodePlayersNotReady, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateBeginGame(testGameID, "u1", false)
+		}},
+		{"get ready twice", ErrCodeAlreadyReady, func(g *aggregates.Game) {
+			inSetup(g)
+			g.Players[1].IsReady = true
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateReady(testGameID, "u2", true)
+		}},
+		{"stop being ready without being ready", ErrCodeNotReady, inSetup, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateReady(testGameID, "u2", false)
+		}},
+		{"begin without cards", ErrCodeNotEnoughCards, func(g *aggregates.Game) {
+			inSetup(g)
+			g.Collection = nil
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateStartRound(testGameID)
+		}},
+		{"begin without black cards", ErrCodeNotEnoughCards, func(g *aggregates.Game) {
+			inSetup(g)
+			g.Collection.SetCards(testCards("w", 40))
+		}, func(v *GameRulesValidator, g *aggregates.Game) ValidationResult {
+			return v.ValidateStartRound(testGameID)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+
+			result := tt.validate(NewGameRulesValidator(g), g)
+
+			if result.IsValid || len(result.Errors) != 1 {
+				t.Fatalf("expected a single %s error, got %+v", tt.code, result.Errors)
+			}
+
+			if err := result.Errors[0]; err.Code != tt.code {
+				t.Fatalf("expected a %s error, got %s: %s", tt.code, err.Code, err.Message)
+			}
+		})
+	}
+}
+
+func TestGameRulesValidatorAllowsTheRightPlayers(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(g *aggregates.Game)
+		validate func(v *GameRulesValidator) ValidationResult
+	}{
+		{"play a card", nil, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidatePlayCard(testGameID, "u2", []string{"w0"})
+		}},
+		{"pick the winner", everyonePlayed, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidateVote(testGameID, "u1", "s-u3")
+		}},
+		{"begin the game", inSetup, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidateBeginGame(testGameID, "u1", true)
+		}},
+		{"watch a private game after accepting an invite", func(g *aggregates.Game) {
+			g.Visibility = valueobjects.PrivateVisibility
+			g.InvitedUserIDs = []string{"u5"}
+		}, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidateWatchGame(testGameID, "u5")
+		}},
+		{"begin once everyone is ready", func(g *aggregates.Game) {
+			inSetup(g)
+			g.Players[1].IsReady = true
+			g.Players[2].IsReady = true
+		}, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidateBeginGame(testGameID, "u1", false)
+		}},
+		{"continue after the winner is picked", func(g *aggregates.Game) { g.RoundStatus = valueobjects.JudgeChoseWinningCard }, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidateContinueRound(testGameID, "u2")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+
+			if result := tt.validate(NewGameRulesValidator(g)); !result.IsValid {
+				t.Fatalf("expected the action to be allowed, got %+v", result.Errors)
+			}
+		})
+	}
+}
+
+func TestGameRulesValidatorWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		setup    func(g *aggregates.Game)
+		validate func(v *GameRulesValidator) ValidationResult
+	}{
+		{"join twice", WarnCodeAlreadyJoined, nil, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidateJoinGame(testGameID, "u2")
+		}},
+		{"owner leaves", WarnCodeOwnerLeaving, nil, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidateLeaveGame(testGameID, "u1")
+		}},
+		{"judge leaves", WarnCodeJudgeLeaving, nil, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidateLeaveGame(testGameID, "u1")
+		}},
+		{"last player leaves", WarnCodeLastPlayerLeaving, func(g *aggregates.Game) { g.Players = g.Players[1:2] }, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidateLeaveGame(testGameID, "u2")
+		}},
+		{"deck runs low", WarnCodeDeckReshuffle, func(g *aggregates.Game) {
+			g.RoundStatus = valueobjects.JudgeChoseWinningCard
+			g.Players[1].Deck = g.Players[1].Deck[:1]
+
+			for _, card := range g.Collection.Cards {
+				if card.Type == entities.White {
+					g.UsedCards = append(g.UsedCards, card)
+				}
+			}
+		}, func(v *GameRulesValidator) ValidationResult {
+			return v.ValidateStartRound(testGameID)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+
+			result := tt.validate(NewGameRulesValidator(g))
+
+			if !result.IsValid {
+				t.Fatalf("expected only warnings, got %+v", result.Errors)
+			}
+
+			for _, warning := range result.Warnings {
+				if warning.Code == tt.code {
+					return
+				}
+			}
+
+			t.Fatalf("expected a %s warning, got %+v", tt.code, result.Warnings)
+		})
+	}
+}
+
+func TestValidationResultErr(t *testing.T) {
+	result := NewValidationResult()
+
+	if result.Err() != nil {
+		t.Fatal("expected a valid result to have no error")
+	}
+
+	result.AddError(ErrCodeNotJudge, "first", "")
+	result.AddError(ErrCodeGameFull, "second", "")
+
+	err, ok := result.Err().(*ValidationError)
+
+	if !ok || err.Code != ErrCodeNotJudge {
+		t.Fatalf("expected the first error, got %v", result.Err())
+	}
+}
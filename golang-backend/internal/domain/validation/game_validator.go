@@ -1,10 +1,33 @@
 package validation
 
+import "cardgame/internal/domain/aggregates"
+
+// GameValidator checks whether an action is allowed by the rules of the game.
+// Player ids are the user ids players joined with.
 type GameValidator interface {
-	ValidatePlayCard(gameID, playerID, cardID string) ValidationResult
-	ValidateVote(gameID, playerID, winningCardID string) ValidationResult
+	ValidatePlayCard(gameID, playerID string, cardIDs []string) ValidationResult
+	ValidateVote(gameID, playerID, submissionID string) ValidationResult
+	ValidateCastVote(gameID, playerID, submissionID string) ValidationResult
 	ValidateStartRound(gameID string) ValidationResult
 	ValidateJoinGame(gameID, playerID string) ValidationResult
+	ValidateWatchGame(gameID, playerID string) ValidationResult
 	ValidateLeaveGame(gameID, playerID string) ValidationResult
 	ValidateEndGame(gameID string) ValidationResult
+	ValidateBeginGame(gameID, playerID string, force bool) ValidationResult
+	ValidateContinueRound(gameID, playerID string) ValidationResult
+	ValidateReaction(gameID, playerID string) ValidationResult
+	ValidateRebootUniverse(gameID, playerID string) ValidationResult
+	ValidateMulligan(gameID, playerID string) ValidationResult
+	ValidateKickPlayer(gameID, ownerID, playerID string) ValidationResult
+	ValidateBanPlayer(gameID, ownerID, playerID string) ValidationResult
+	ValidateMutePlayer(gameID, ownerID, playerID string, muted bool) ValidationResult
+	ValidatePromoteSpectator(gameID, ownerID, playerID string) ValidationResult
+	ValidatePauseGame(gameID, ownerID string) ValidationResult
+	ValidateResumeGame(gameID, ownerID string) ValidationResult
+	ValidateForceAdvance(gameID, ownerID string) ValidationResult
+	ValidateRematch(gameID, ownerID string) ValidationResult
+	ValidateAcceptInvite(gameID, playerID, inviteCode string) ValidationResult
+	ValidateRotateInviteCode(gameID, ownerID string) ValidationResult
+	ValidateChangeSettings(gameID, ownerID string, settings aggregates.GameSettings) ValidationResult
+	ValidateReady(gameID, playerID string, ready bool) ValidationResult
 }
@@ -1,7 +1,19 @@
 package validation
 
 type ValidationError struct {
-	Code    string
-	Message string
-	Field   string
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+}
+
+func NewValidationError(code, message, field string) *ValidationError {
+	return &ValidationError{
+		Code:    code,
+		Message: message,
+		Field:   field,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
 }
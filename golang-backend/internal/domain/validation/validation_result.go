@@ -5,3 +5,31 @@ type ValidationResult struct {
 	Errors   []ValidationError
 	Warnings []ValidationWarning
 }
+
+func NewValidationResult() ValidationResult {
+	return ValidationResult{
+		IsValid:  true,
+		Errors:   []ValidationError{},
+		Warnings: []ValidationWarning{},
+	}
+}
+
+func (r *ValidationResult) AddError(code, message, field string) {
+	r.IsValid = false
+	r.Errors = append(r.Errors, ValidationError{Code: code, Message: message, Field: field})
+}
+
+func (r *ValidationResult) AddWarning(code, message string) {
+	r.Warnings = append(r.Warnings, ValidationWarning{Code: code, Message: message})
+}
+
+// Err returns the first error of an invalid result, or nil when it is valid.
+func (r ValidationResult) Err() error {
+	if r.IsValid || len(r.Errors) == 0 {
+		return nil
+	}
+
+	err := r.Errors[0]
+
+	return &err
+}
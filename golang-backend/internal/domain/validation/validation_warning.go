@@ -1,6 +1,6 @@
 package validation
 
 type ValidationWarning struct {
-	Code    string
-	Message string
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
@@ -0,0 +1,82 @@
+package valueobjects
+
+import "fmt"
+
+// GameMode decides how a round's winner is chosen.
+type GameMode string
+
+const (
+	// CzarMode has a rotating judge pick the winning submission.
+	CzarMode GameMode = "Czar"
+	// DemocracyMode has no judge. Everyone votes for their favorite
+	// submission other than their own.
+	DemocracyMode GameMode = "Democracy"
+)
+
+// NewGameMode parses a game mode. An empty mode is the czar mode every game
+// was played with before modes existed.
+func NewGameMode(mode string) (GameMode, error) {
+	if mode == "" {
+		return CzarMode, nil
+	}
+
+	gameMode := GameMode(mode)
+
+	if !gameMode.IsValid() {
+		return "", fmt.Errorf("invalid game mode: %s", mode)
+	}
+
+	return gameMode, nil
+}
+
+func (m GameMode) IsValid() bool {
+	return m == CzarMode || m == DemocracyMode
+}
+
+func (m GameMode) String() string {
+	return string(m)
+}
+
+func (m GameMode) IsDemocracy() bool {
+	return m == DemocracyMode
+}
+
+// TieRule decides who scores when submissions tie for the most votes.
+type TieRule string
+
+const (
+	// TieAllWin gives a point to every tied submission.
+	TieAllWin TieRule = "AllWin"
+	// TieNoPoints gives nobody a point.
+	TieNoPoints TieRule = "NoPoints"
+	// TieRandom gives a point to one tied submission chosen at random.
+	TieRandom TieRule = "Random"
+)
+
+// NewTieRule parses a tie rule. An empty rule means every tied submission wins.
+func NewTieRule(rule string) (TieRule, error) {
+	if rule == "" {
+		return TieAllWin, nil
+	}
+
+	tieRule := TieRule(rule)
+
+	if !tieRule.IsValid() {
+		return "", fmt.Errorf("invalid tie rule: %s", rule)
+	}
+
+	return tieRule, nil
+}
+
+func (t TieRule) IsValid() bool {
+	switch t {
+	case TieAllWin, TieNoPoints, TieRandom:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t TieRule) String() string {
+	return string(t)
+}
@@ -0,0 +1,14 @@
+package valueobjects
+
+// HouseRules are the optional rules a game is created with. They all default
+// to off.
+type HouseRules struct {
+	// RandoCardrissian adds a bot player that plays random white cards every
+	// round and is judged like anyone else.
+	RandoCardrissian bool `json:"rando_cardrissian"`
+	// RebootingTheUniverse lets a player trade in a point to discard their
+	// hand and draw a new one.
+	RebootingTheUniverse bool `json:"rebooting_the_universe"`
+	// Mulligan lets every player redraw their hand once per game for free.
+	Mulligan bool `json:"mulligan"`
+}
@@ -0,0 +1,72 @@
+package valueobjects
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// inviteCodeNumbers is how many numbers an invite code can end in. Together
+// with the words it gives about 1.8 billion codes, too many to guess.
+const inviteCodeNumbers = 900000
+
+var inviteCodeAdjectives = []string{
+	"AMBER", "BOLD", "BRAVE", "BRIGHT", "BRISK", "CALM", "CLEVER", "COSMIC",
+	"CRISP", "DARING", "EAGER", "FANCY", "FIERY", "FROSTY", "GENTLE", "GIANT",
+	"GOLDEN", "HAPPY", "HUMBLE", "JOLLY", "LIVELY", "LUCKY", "MELLOW", "MIGHTY",
+	"NIMBLE", "NOBLE", "PLUCKY", "PROUD", "QUICK", "QUIET", "RAPID", "ROYAL",
+	"RUSTY", "SILENT", "SILVER", "SLY", "SNOWY", "SUNNY", "SWIFT", "TIDY",
+	"VELVET", "VIVID", "WILD", "WISE", "WITTY", "ZESTY",
+}
+
+var inviteCodeAnimals = []string{
+	"BADGER", "BEAVER", "BISON", "COBRA", "CONDOR", "COYOTE", "CRANE", "DINGO",
+	"EAGLE", "FALCON", "FERRET", "GECKO", "GOOSE", "HERON", "HIPPO", "IBEX",
+	"JACKAL", "KOALA", "LEMUR", "LLAMA", "LYNX", "MARMOT", "MOOSE", "NEWT",
+	"OCELOT", "OTTER", "PANDA", "PANTHER", "PELICAN", "PUFFIN", "QUOKKA",
+	"RAVEN", "SALMON", "SEAL", "SPARROW", "TAPIR", "TIGER", "TOUCAN", "TURTLE",
+	"WALRUS", "WEASEL", "WOMBAT", "YAK", "ZEBRA",
+}
+
+// NewInviteCode makes a short invite code that is easy to read out, such as
+// BRAVE-OTTER-420917. Codes are not guaranteed to be unique.
+func NewInviteCode() (string, error) {
+	adjective, err := randomIndex(len(inviteCodeAdjectives))
+
+	if err != nil {
+		return "", err
+	}
+
+	animal, err := randomIndex(len(inviteCodeAnimals))
+
+	if err != nil {
+		return "", err
+	}
+
+	number, err := randomIndex(inviteCodeNumbers)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%s-%d", inviteCodeAdjectives[adjective], inviteCodeAnimals[animal], number+100000), nil
+}
+
+// randomIndex picks a number in [0, n) that cannot be predicted from codes
+// handed out before.
+func randomIndex(n int) (int, error) {
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to make invite code: %w", err)
+	}
+
+	return int(index.Int64()), nil
+}
+
+// NormalizeInviteCode tidies up an invite code as typed by a user so it can
+// be compared with the code it was given as.
+func NormalizeInviteCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,34 @@
+package valueobjects
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNewInviteCode(t *testing.T) {
+	format := regexp.MustCompile(`^[A-Z]+-[A-Z]+-[1-9][0-9]{5}$`)
+	seen := map[string]bool{}
+
+	for i := 0; i < 1000; i++ {
+		code, err := NewInviteCode()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !format.MatchString(code) {
+			t.Fatalf("invite code %s is not WORD-WORD-NUMBER", code)
+		}
+
+		if NormalizeInviteCode(" "+code+" ") != code {
+			t.Fatalf("invite code %s changes when normalized", code)
+		}
+
+		seen[code] = true
+	}
+
+	// Out of about 1.8 billion codes, a thousand should hardly ever repeat
+	if len(seen) < 995 {
+		t.Fatalf("expected invite codes to hardly repeat, got %d different in 1000", len(seen))
+	}
+}
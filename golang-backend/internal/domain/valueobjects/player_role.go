@@ -7,6 +7,7 @@ type PlayerRole string
 const (
 	Participant PlayerRole = "Participant"
 	Owner       PlayerRole = "Owner"
+	Spectator   PlayerRole = "Spectator"
 )
 
 func NewPlayerRole(role string) (PlayerRole, error) {
@@ -20,7 +21,7 @@ func NewPlayerRole(role string) (PlayerRole, error) {
 }
 
 func (r PlayerRole) IsValid() bool {
-	return r == Participant || r == Owner
+	return r == Participant || r == Owner || r == Spectator
 }
 
 func (r PlayerRole) String() string {
@@ -34,3 +35,7 @@ func (r PlayerRole) IsParticipant() bool {
 func (r PlayerRole) IsOwner() bool {
 	return r == Owner
 }
+
+func (r PlayerRole) IsSpectator() bool {
+	return r == Spectator
+}
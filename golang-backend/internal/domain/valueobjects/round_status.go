@@ -8,6 +8,7 @@ const (
 	Waiting                 RoundStatus = "Waiting"
 	PlayersPickingCard      RoundStatus = "PlayersPickingCard"
 	JudgePickingWinningCard RoundStatus = "JudgePickingWinningCard"
+	PlayersVoting           RoundStatus = "PlayersVoting" // Democracy games vote instead of having a judge pick
 	JudgeChoseWinningCard   RoundStatus = "JudgeChoseWinningCard"
 	GameOver                RoundStatus = "GameOver"
 )
@@ -18,7 +19,7 @@ func (r RoundStatus) String() string {
 
 func (r RoundStatus) IsValid() bool {
 	switch r {
-	case Waiting, PlayersPickingCard, JudgePickingWinningCard, JudgeChoseWinningCard, GameOver:
+	case Waiting, PlayersPickingCard, JudgePickingWinningCard, PlayersVoting, JudgeChoseWinningCard, GameOver:
 		return true
 	default:
 		return false
@@ -39,9 +40,10 @@ func (r RoundStatus) CanTransitionTo(target RoundStatus) bool {
 	case Waiting:
 		return target == PlayersPickingCard
 	case PlayersPickingCard:
-		return target == JudgePickingWinningCard
-	case JudgePickingWinningCard:
-		return target == JudgeChoseWinningCard
+		return target == JudgePickingWinningCard || target == PlayersVoting
+	case JudgePickingWinningCard, PlayersVoting:
+		// Back to picking when the round is voided
+		return target == JudgeChoseWinningCard || target == PlayersPickingCard
 	case JudgeChoseWinningCard:
 		return target == PlayersPickingCard || target == GameOver
 	case GameOver:
@@ -57,7 +59,7 @@ func (r RoundStatus) IsTerminal() bool {
 
 func (r RoundStatus) IsActive() bool {
 	switch r {
-	case PlayersPickingCard, JudgePickingWinningCard, JudgeChoseWinningCard:
+	case PlayersPickingCard, JudgePickingWinningCard, PlayersVoting, JudgeChoseWinningCard:
 		return true
 	default:
 		return false
@@ -65,7 +67,7 @@ func (r RoundStatus) IsActive() bool {
 }
 
 func (r RoundStatus) RequiresPlayerAction() bool {
-	return r == PlayersPickingCard
+	return r == PlayersPickingCard || r == PlayersVoting
 }
 
 func (r RoundStatus) RequiresJudgeAction() bool {
@@ -80,6 +82,16 @@ func (r RoundStatus) CanPickWinningCard() bool {
 	return r == JudgePickingWinningCard
 }
 
+func (r RoundStatus) CanVote() bool {
+	return r == PlayersVoting
+}
+
+// CanVoidRound reports whether the round can still be restarted, which is
+// until a winner has been chosen.
+func (r RoundStatus) CanVoidRound() bool {
+	return r == PlayersPickingCard || r == JudgePickingWinningCard || r == PlayersVoting
+}
+
 func (r RoundStatus) CanContinueRound() bool {
 	return r == JudgeChoseWinningCard
 }
@@ -89,9 +101,9 @@ func (r RoundStatus) GetValidTransitions() []RoundStatus {
 	case Waiting:
 		return []RoundStatus{PlayersPickingCard}
 	case PlayersPickingCard:
-		return []RoundStatus{JudgePickingWinningCard}
-	case JudgePickingWinningCard:
-		return []RoundStatus{JudgeChoseWinningCard}
+		return []RoundStatus{JudgePickingWinningCard, PlayersVoting}
+	case JudgePickingWinningCard, PlayersVoting:
+		return []RoundStatus{JudgeChoseWinningCard, PlayersPickingCard}
 	case JudgeChoseWinningCard:
 		return []RoundStatus{PlayersPickingCard, GameOver}
 	case GameOver:
@@ -116,6 +128,8 @@ func (r RoundStatus) GetDescription() string {
 		return "Players are picking their cards"
 	case JudgePickingWinningCard:
 		return "Judge is picking the winning card"
+	case PlayersVoting:
+		return "Players are voting for the winning card"
 	case JudgeChoseWinningCard:
 		return "Judge has chosen the winning card"
 	case GameOver:
@@ -0,0 +1,70 @@
+package valueobjects
+
+import (
+	"fmt"
+	"testing"
+)
+
+var allRoundStatuses = []RoundStatus{
+	Waiting,
+	PlayersPickingCard,
+	JudgePickingWinningCard,
+	PlayersVoting,
+	JudgeChoseWinningCard,
+	GameOver,
+}
+
+func TestRoundStatusTransitions(t *testing.T) {
+	allowed := map[RoundStatus][]RoundStatus{
+		Waiting:                 {PlayersPickingCard},
+		PlayersPickingCard:      {JudgePickingWinningCard, PlayersVoting},
+		JudgePickingWinningCard: {JudgeChoseWinningCard, PlayersPickingCard},
+		PlayersVoting:           {JudgeChoseWinningCard, PlayersPickingCard},
+		JudgeChoseWinningCard:   {PlayersPickingCard, GameOver},
+		GameOver:                {},
+	}
+
+	for _, from := range allRoundStatuses {
+		for _, to := range allRoundStatuses {
+			want := false
+
+			for _, target := range allowed[from] {
+				want = want || target == to
+			}
+
+			t.Run(fmt.Sprintf("%s to %s", from, to), func(t *testing.T) {
+				if got := from.CanTransitionTo(to); got != want {
+					t.Fatalf("expected CanTransitionTo to be %v, got %v", want, got)
+				}
+
+				if err := from.ValidateTransition(to); (err == nil) != want {
+					t.Fatalf("expected ValidateTransition to allow it to be %v, got %v", want, err)
+				}
+			})
+		}
+	}
+}
+
+func TestRoundStatusValidTransitionsMatchTheTable(t *testing.T) {
+	for _, from := range allRoundStatuses {
+		for _, to := range from.GetValidTransitions() {
+			if !from.CanTransitionTo(to) {
+				t.Fatalf("%s lists %s as a valid transition but does not allow it", from, to)
+			}
+		}
+	}
+}
+
+func TestUnknownRoundStatusCannotTransition(t *testing.T) {
+	unknown := RoundStatus("Unknown")
+
+	for _, to := range allRoundStatuses {
+		if unknown.CanTransitionTo(to) {
+			t.Fatalf("expected an unknown status not to move to %s", to)
+		}
+	}
+
+	if _, err := NewRoundStatus("Unknown"); err == nil {
+		t.Fatal("expected an unknown round status to be rejected")
+	}
+}
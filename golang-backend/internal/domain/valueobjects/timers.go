@@ -0,0 +1,70 @@
+package valueobjects
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timers are a game's own round timeouts, in seconds. A timeout left unset
+// falls back to the server's, and zero leaves players as long as they like.
+type Timers struct {
+	// PickingSeconds is how long players have to play their cards.
+	PickingSeconds *int `json:"picking_seconds,omitempty"`
+	// JudgingSeconds is how long the judge, or the voters, have to pick the
+	// winning card.
+	JudgingSeconds *int `json:"judging_seconds,omitempty"`
+	// RoundPauseSeconds is how long the result of a round is shown before
+	// the next round starts.
+	RoundPauseSeconds *int `json:"round_pause_seconds,omitempty"`
+}
+
+// MaxTimerSeconds is the longest a game's own timeout can be. Players who
+// want longer can turn the timeout off with zero.
+const MaxTimerSeconds = 60 * 60
+
+// IsValid reports whether every timeout is between zero and MaxTimerSeconds.
+func (t Timers) IsValid() bool {
+	for _, seconds := range []*int{t.PickingSeconds, t.JudgingSeconds, t.RoundPauseSeconds} {
+		if seconds != nil && (*seconds < 0 || *seconds > MaxTimerSeconds) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Timeout returns the given game timeout, or the fallback when it is unset.
+func Timeout(seconds *int, fallback time.Duration) time.Duration {
+	if seconds == nil {
+		return fallback
+	}
+
+	return time.Duration(*seconds) * time.Second
+}
+
+func (t Timers) String() string {
+	format := func(seconds *int) string {
+		if seconds == nil {
+			return "default"
+		}
+
+		return fmt.Sprintf("%ds", *seconds)
+	}
+
+	return fmt.Sprintf("picking=%s judging=%s round_pause=%s", format(t.PickingSeconds), format(t.JudgingSeconds), format(t.RoundPauseSeconds))
+}
+
+// Equal reports whether both timers have the same timeouts.
+func (t Timers) Equal(other Timers) bool {
+	equal := func(a, b *int) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+
+		return *a == *b
+	}
+
+	return equal(t.PickingSeconds, other.PickingSeconds) &&
+		equal(t.JudgingSeconds, other.JudgingSeconds) &&
+		equal(t.RoundPauseSeconds, other.RoundPauseSeconds)
+}
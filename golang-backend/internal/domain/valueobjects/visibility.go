@@ -0,0 +1,42 @@
+package valueobjects
+
+import "fmt"
+
+// Visibility decides who can find and join a game.
+type Visibility string
+
+const (
+	// PublicVisibility lists the game for everyone and lets anyone join.
+	PublicVisibility Visibility = "Public"
+	// PrivateVisibility hides the game from everyone but those in it. Others
+	// can only join with its invite code, and its password if it has one.
+	PrivateVisibility Visibility = "Private"
+)
+
+// NewVisibility parses a visibility. An empty visibility is public, as every
+// game was before private games existed.
+func NewVisibility(visibility string) (Visibility, error) {
+	if visibility == "" {
+		return PublicVisibility, nil
+	}
+
+	gameVisibility := Visibility(visibility)
+
+	if !gameVisibility.IsValid() {
+		return "", fmt.Errorf("invalid visibility: %s", visibility)
+	}
+
+	return gameVisibility, nil
+}
+
+func (v Visibility) IsValid() bool {
+	return v == PublicVisibility || v == PrivateVisibility
+}
+
+func (v Visibility) String() string {
+	return string(v)
+}
+
+func (v Visibility) IsPrivate() bool {
+	return v == PrivateVisibility
+}
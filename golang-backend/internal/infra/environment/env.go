@@ -17,6 +17,9 @@ type Env struct {
 	DatabaseDSN              string `mapstructure:"DATABASE_DSN"`
 	TemporalHostPort         string `mapstructure:"TEMPORAL_HOST_PORT"`
 	TemporalNamespace        string `mapstructure:"TEMPORAL_NAMESPACE"`
+	TemporalClientCertPath   string `mapstructure:"TEMPORAL_CLIENT_CERT_PATH"`
+	TemporalClientKeyPath    string `mapstructure:"TEMPORAL_CLIENT_KEY_PATH"`
+	TemporalTaskQueue        string `mapstructure:"TEMPORAL_TASK_QUEUE"`
 	ChatGPTAPIKey            string `mapstructure:"CHATGPT_API_KEY"`
 	JWTVerifySecret          string `mapstructure:"JWT_VERIFY_SECRET"`
 	RedisHost                string `mapstructure:"REDIS_HOST"`
@@ -24,11 +27,23 @@ type Env struct {
 	RedisPassword            string `mapstructure:"REDIS_PASSWORD"`
 	RedisDB                  int    `mapstructure:"REDIS_DB"`
 	LocalDevBypass           bool   `mapstructure:"LOCAL_DEV_BYPASS"`
+	MinPlayerCount           int    `mapstructure:"MIN_PLAYER_COUNT"`
+	AutoStartWhenFull        bool   `mapstructure:"AUTO_START_WHEN_FULL"`
+	PickingTimeoutSeconds    int    `mapstructure:"PICKING_TIMEOUT_SECONDS"`
+	JudgingTimeoutSeconds    int    `mapstructure:"JUDGING_TIMEOUT_SECONDS"`
+	RoundPauseSeconds        int    `mapstructure:"ROUND_PAUSE_SECONDS"`
+	DisconnectGraceSeconds   int    `mapstructure:"DISCONNECT_GRACE_SECONDS"`
 }
 
 func NewEnv() *Env {
 	env := Env{}
 
+	// Rounds are timed unless the .env says otherwise. Setting a timeout to 0
+	// turns it off, leaving players as long as they like.
+	viper.SetDefault("PICKING_TIMEOUT_SECONDS", 90)
+	viper.SetDefault("JUDGING_TIMEOUT_SECONDS", 60)
+	viper.SetDefault("ROUND_PAUSE_SECONDS", 10)
+
 	// Try to read from .env file first
 	viper.SetConfigFile(".env")
 	err := viper.ReadInConfig()
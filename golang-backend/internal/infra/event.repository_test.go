@@ -0,0 +1,186 @@
+package infra
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisEventRepository(t *testing.T) (*RedisEventRepository, *redis.Client) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	t.Cleanup(func() { client.Close() })
+
+	return NewRedisEventRepository(client), client
+}
+
+// eventRepositories are every EventRepository, which must all behave the same.
+var eventRepositories = map[string]func(t *testing.T) repositories.EventRepository{
+	"memory": func(t *testing.T) repositories.EventRepository { return NewInMemoryEventRepository() },
+	"redis": func(t *testing.T) repositories.EventRepository {
+		events, _ := newTestRedisEventRepository(t)
+		return events
+	},
+}
+
+// testEvents makes count events for a game with versions following from.
+func testEvents(gameID string, from int64, count int) []*aggregates.GameEvent {
+	events := []*aggregates.GameEvent{}
+
+	for i := 0; i < count; i++ {
+		event := aggregates.NewGameEvent(gameID, aggregates.EventJoinedGame, []byte(fmt.Sprintf(`{"game_id":%q}`, gameID)))
+		event.Version = from + int64(i) + 1
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func mustGetEvents(t *testing.T, events repositories.EventRepository, gameID string) []aggregates.GameEvent {
+	t.Helper()
+
+	stored, err := events.GetEventsForGame(gameID)
+
+	if err != nil {
+		t.Fatalf("could not get events: %v", err)
+	}
+
+	return stored
+}
+
+func TestEventRepositoryAppendsEventsInOrder(t *testing.T) {
+	for name, newEvents := range eventRepositories {
+		t.Run(name, func(t *testing.T) {
+			events := newEvents(t)
+			appended := append(testEvents("game-1", 0, 2), testEvents("game-1", 2, 1)...)
+
+			if err := events.AppendEvents("game-1", 0, appended[:2]); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := events.AppendEvents("game-1", 2, appended[2:]); err != nil {
+				t.Fatal(err)
+			}
+
+			stored := mustGetEvents(t, events, "game-1")
+
+			if len(stored) != len(appended) {
+				t.Fatalf("expected %d events, got %d", len(appended), len(stored))
+			}
+
+			for i, event := range stored {
+				if event.ID != appended[i].ID || event.Version != int64(i+1) {
+					t.Fatalf("event %d is %s at version %d, expected %s at version %d", i, event.ID, event.Version, appended[i].ID, i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestEventRepositoryRejectsAppendsAtAStaleVersion(t *testing.T) {
+	for name, newEvents := range eventRepositories {
+		t.Run(name, func(t *testing.T) {
+			events := newEvents(t)
+
+			if err := events.AppendEvents("game-1", 0, testEvents("game-1", 0, 2)); err != nil {
+				t.Fatal(err)
+			}
+
+			// Another writer read the stream before the first two events
+			err := events.AppendEvents("game-1", 1, testEvents("game-1", 1, 1))
+
+			var conflict *repositories.VersionConflictError
+
+			if !errors.As(err, &conflict) {
+				t.Fatalf("expected a version conflict, got %v", err)
+			}
+
+			if conflict.ExpectedVersion != 1 || conflict.ActualVersion != 2 {
+				t.Fatalf("expected a conflict between versions 1 and 2, got %+v", conflict)
+			}
+
+			if stored := mustGetEvents(t, events, "game-1"); len(stored) != 2 {
+				t.Fatalf("conflicting append stored events: %d events", len(stored))
+			}
+		})
+	}
+}
+
+func TestEventRepositoryRejectsEventsThatDoNotFollowOn(t *testing.T) {
+	tests := map[string][]*aggregates.GameEvent{
+		"skipped version":  testEvents("game-1", 1, 1),
+		"other game":       testEvents("game-2", 0, 1),
+		"repeated version": append(testEvents("game-1", 0, 1), testEvents("game-1", 0, 1)...),
+	}
+
+	for name, newEvents := range eventRepositories {
+		for test, appended := range tests {
+			t.Run(name+"/"+test, func(t *testing.T) {
+				events := newEvents(t)
+
+				if err := events.AppendEvents("game-1", 0, appended); err == nil {
+					t.Fatal("expected the append to be rejected")
+				}
+
+				if stored := mustGetEvents(t, events, "game-1"); len(stored) != 0 {
+					t.Fatalf("rejected append stored %d events", len(stored))
+				}
+			})
+		}
+	}
+}
+
+func TestRedisEventRepositoryVersionsEventsStoredBeforeVersioning(t *testing.T) {
+	events, client := newTestRedisEventRepository(t)
+
+	for _, id := range []string{"legacy-1", "legacy-2"} {
+		raw := fmt.Sprintf(`{"id":%q,"game_id":"game-1","type":%q}`, id, aggregates.EventJoinedGame)
+
+		if err := client.RPush(context.Background(), "game:events:game-1", raw).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stored := mustGetEvents(t, events, "game-1")
+
+	if len(stored) != 2 || stored[0].Version != 1 || stored[1].Version != 2 {
+		t.Fatalf("expected legacy events at versions 1 and 2, got %+v", stored)
+	}
+
+	// Appends carry on from the legacy events
+	if err := events.AppendEvents("game-1", 2, testEvents("game-1", 2, 1)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRedisEventRepositoryNamesTheEventItCannotDecode(t *testing.T) {
+	events, client := newTestRedisEventRepository(t)
+
+	if err := events.AppendEvents("game-1", 0, testEvents("game-1", 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.RPush(context.Background(), "game:events:game-1", "not json").Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := events.GetEventsForGame("game-1")
+
+	if err == nil {
+		t.Fatal("expected an event that cannot be decoded to fail the read")
+	}
+
+	if !strings.Contains(err.Error(), "event 1 of game game-1") {
+		t.Fatalf("expected the error to name the game and the index, got %v", err)
+	}
+}
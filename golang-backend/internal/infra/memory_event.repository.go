@@ -0,0 +1,167 @@
+package infra
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/repositories"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// InMemoryEventRepository keeps event streams in process. Events are lost on
+// restart, so it is meant for tests and local runs without Redis.
+type InMemoryEventRepository struct {
+	mu        sync.Mutex
+	events    map[string][]aggregates.GameEvent
+	usedCards map[string]map[string]bool
+}
+
+func NewInMemoryEventRepository() *InMemoryEventRepository {
+	return &InMemoryEventRepository{
+		events:    map[string][]aggregates.GameEvent{},
+		usedCards: map[string]map[string]bool{},
+	}
+}
+
+// AppendEvents adds events to the game's stream, provided the stream is still
+// at expectedVersion, the same as RedisEventRepository.AppendEvents.
+func (r *InMemoryEventRepository) AppendEvents(gameID string, expectedVersion int64, events []*aggregates.GameEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	for i, event := range events {
+		if event.GameID != gameID {
+			return fmt.Errorf("failed to append event %s: belongs to game %s, not %s", event.ID, event.GameID, gameID)
+		}
+
+		if event.Version != expectedVersion+int64(i)+1 {
+			return fmt.Errorf("failed to append event %s: version %d is not %d", event.ID, event.Version, expectedVersion+int64(i)+1)
+		}
+	}
+
+	current := int64(len(r.events[gameID]))
+
+	if current != expectedVersion {
+		return &repositories.VersionConflictError{
+			GameID:          gameID,
+			ExpectedVersion: expectedVersion,
+			ActualVersion:   current,
+		}
+	}
+
+	for _, event := range events {
+		r.events[gameID] = append(r.events[gameID], *event)
+	}
+
+	return nil
+}
+
+func (r *InMemoryEventRepository) GetEventsForGame(gameID string) ([]aggregates.GameEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]aggregates.GameEvent{}, r.events[gameID]...), nil
+}
+
+func (r *InMemoryEventRepository) GetGameIDs() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	gameIDs := make([]string, 0, len(r.events))
+
+	for gameID := range r.events {
+		gameIDs = append(gameIDs, gameID)
+	}
+
+	return gameIDs, nil
+}
+
+func (r *InMemoryEventRepository) GetEventsSince(gameID string, since time.Time) ([]aggregates.GameEvent, error) {
+	allEvents, _ := r.GetEventsForGame(gameID)
+
+	var filteredEvents []aggregates.GameEvent
+
+	for _, event := range allEvents {
+		if event.CreatedAt.After(since) {
+			filteredEvents = append(filteredEvents, event)
+		}
+	}
+
+	return filteredEvents, nil
+}
+
+func (r *InMemoryEventRepository) GetEventByID(eventID string) (*aggregates.GameEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, events := range r.events {
+		for _, event := range events {
+			if event.ID == eventID {
+				return &event, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("event not found: %s", eventID)
+}
+
+func (r *InMemoryEventRepository) DeleteGameEvents(gameID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.events, gameID)
+
+	return nil
+}
+
+func (r *InMemoryEventRepository) AddUsedCard(gameID, cardID string) error {
+	return r.AddUsedCards(gameID, []string{cardID})
+}
+
+func (r *InMemoryEventRepository) GetUsedCards(gameID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cardIDs := []string{}
+
+	for cardID := range r.usedCards[gameID] {
+		cardIDs = append(cardIDs, cardID)
+	}
+
+	return cardIDs, nil
+}
+
+func (r *InMemoryEventRepository) IsCardUsed(gameID, cardID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.usedCards[gameID][cardID], nil
+}
+
+func (r *InMemoryEventRepository) ClearUsedCards(gameID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.usedCards, gameID)
+
+	return nil
+}
+
+func (r *InMemoryEventRepository) AddUsedCards(gameID string, cardIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.usedCards[gameID] == nil {
+		r.usedCards[gameID] = map[string]bool{}
+	}
+
+	for _, cardID := range cardIDs {
+		r.usedCards[gameID][cardID] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package infra
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// InMemoryGameRepository keeps game snapshots in process. Like the Redis
+// repository it stores games as JSON, so a stored snapshot never shares
+// state with a live game. It is meant for tests and local runs without Redis.
+type InMemoryGameRepository struct {
+	mu    sync.Mutex
+	games map[string][]byte
+}
+
+func NewInMemoryGameRepository() *InMemoryGameRepository {
+	return &InMemoryGameRepository{
+		games: map[string][]byte{},
+	}
+}
+
+func (r *InMemoryGameRepository) Create(game *aggregates.Game) (*aggregates.Game, error) {
+	if game.CreatedAt.IsZero() {
+		game.CreatedAt = time.Now()
+	}
+
+	return r.Update(game)
+}
+
+func (r *InMemoryGameRepository) GetByID(id string) (*aggregates.Game, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	gameJSON, exists := r.games[id]
+
+	if !exists {
+		return nil, nil
+	}
+
+	var game aggregates.Game
+
+	if err := json.Unmarshal(gameJSON, &game); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game: %w", err)
+	}
+
+	return &game, nil
+}
+
+func (r *InMemoryGameRepository) Update(game *aggregates.Game) (*aggregates.Game, error) {
+	game.UpdatedAt = time.Now()
+
+	gameJSON, err := json.Marshal(game)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal game: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.games[game.ID] = gameJSON
+
+	return game, nil
+}
+
+func (r *InMemoryGameRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.games, id)
+
+	return nil
+}
+
+func (r *InMemoryGameRepository) GetAllGames() ([]*aggregates.Game, error) {
+	r.mu.Lock()
+	ids := make([]string, 0, len(r.games))
+
+	for id := range r.games {
+		ids = append(ids, id)
+	}
+	r.mu.Unlock()
+
+	games := []*aggregates.Game{}
+
+	for _, id := range ids {
+		game, err := r.GetByID(id)
+
+		if err != nil || game == nil {
+			continue
+		}
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
@@ -0,0 +1,75 @@
+package infra
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// InMemoryTimerRepository keeps timers in process. Timers are lost on
+// restart, so it is meant for tests and local runs without Redis.
+type InMemoryTimerRepository struct {
+	mu     sync.Mutex
+	timers map[string]time.Time
+}
+
+func NewInMemoryTimerRepository() *InMemoryTimerRepository {
+	return &InMemoryTimerRepository{
+		timers: map[string]time.Time{},
+	}
+}
+
+func (r *InMemoryTimerRepository) Schedule(gameID string, fireAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.timers[gameID] = fireAt
+
+	return nil
+}
+
+func (r *InMemoryTimerRepository) Cancel(gameID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.timers, gameID)
+
+	return nil
+}
+
+func (r *InMemoryTimerRepository) ClaimDue(now time.Time, leaseUntil time.Time, limit int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	due := []string{}
+
+	for gameID, fireAt := range r.timers {
+		if !fireAt.After(now) {
+			due = append(due, gameID)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return r.timers[due[i]].Before(r.timers[due[j]])
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	for _, gameID := range due {
+		r.timers[gameID] = leaseUntil
+	}
+
+	return due, nil
+}
+
+// FireAt returns when the game's timer fires, if it has one.
+func (r *InMemoryTimerRepository) FireAt(gameID string) (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fireAt, exists := r.timers[gameID]
+
+	return fireAt, exists
+}
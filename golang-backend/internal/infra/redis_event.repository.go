@@ -2,10 +2,11 @@ package infra
 
 import (
 	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/repositories"
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,45 +23,86 @@ func NewRedisEventRepository(client *redis.Client) *RedisEventRepository {
 	}
 }
 
-// AppendEvent adds a new event to the game's event stream
-func (r *RedisEventRepository) AppendEvent(event *aggregates.GameEvent) error {
+// appendEventsScript pushes events onto a game's stream only if the stream is
+// still at the expected version. It returns {1, newVersion} on success and
+// {0, currentVersion} on a conflict.
+//
+// KEYS[1] = game:events:{gameID}, KEYS[2] = game:last_event:{gameID},
+// KEYS[3..] = event:{eventID} for each event
+// ARGV[1] = expected version, ARGV[2] = last event unix timestamp,
+// ARGV[3..] = event JSON for each event
+var appendEventsScript = redis.NewScript(`
+local current = redis.call('LLEN', KEYS[1])
+if current ~= tonumber(ARGV[1]) then
+	return {0, current}
+end
+for i = 3, #ARGV do
+	redis.call('RPUSH', KEYS[1], ARGV[i])
+	redis.call('SET', KEYS[i], ARGV[i])
+end
+redis.call('SET', KEYS[2], ARGV[2])
+return {1, current + #ARGV - 2}
+`)
+
+// AppendEvents atomically adds events to the game's event stream, provided the
+// stream is still at expectedVersion. Events must carry consecutive versions
+// starting at expectedVersion+1. A *repositories.VersionConflictError is
+// returned when another writer appended first.
+func (r *RedisEventRepository) AppendEvents(gameID string, expectedVersion int64, events []*aggregates.GameEvent) error {
 	ctx := context.Background()
 
-	// Generate ID if not provided
-	if event.ID == "" {
-		event.ID = uuid.NewString()
+	if len(events) == 0 {
+		return nil
 	}
 
-	// Set creation time if not provided
-	if event.CreatedAt.IsZero() {
-		event.CreatedAt = time.Now()
+	keys := []string{
+		fmt.Sprintf("game:events:%s", gameID),
+		fmt.Sprintf("game:last_event:%s", gameID),
 	}
+	eventJSONs := []interface{}{}
 
-	// Serialize the event
-	eventJSON, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
-	}
+	for i, event := range events {
+		// Generate ID if not provided
+		if event.ID == "" {
+			event.ID = uuid.NewString()
+		}
 
-	// Store event in Redis list (game:events:{gameID})
-	eventKey := fmt.Sprintf("game:events:%s", event.GameID)
-	err = r.client.RPush(ctx, eventKey, eventJSON).Err()
-	if err != nil {
-		return fmt.Errorf("failed to append event to Redis: %w", err)
+		// Set creation time if not provided
+		if event.CreatedAt.IsZero() {
+			event.CreatedAt = time.Now()
+		}
+
+		if event.GameID != gameID {
+			return fmt.Errorf("failed to append event %s: belongs to game %s, not %s", event.ID, event.GameID, gameID)
+		}
+
+		if event.Version != expectedVersion+int64(i)+1 {
+			return fmt.Errorf("failed to append event %s: version %d is not %d", event.ID, event.Version, expectedVersion+int64(i)+1)
+		}
+
+		// Serialize the event
+		eventJSON, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+
+		keys = append(keys, fmt.Sprintf("event:%s", event.ID))
+		eventJSONs = append(eventJSONs, eventJSON)
 	}
 
-	// Also store event by ID for quick lookup
-	eventIDKey := fmt.Sprintf("event:%s", event.ID)
-	err = r.client.Set(ctx, eventIDKey, eventJSON, 0).Err()
+	args := append([]interface{}{expectedVersion, events[len(events)-1].CreatedAt.Unix()}, eventJSONs...)
+
+	result, err := appendEventsScript.Run(ctx, r.client, keys, args...).Int64Slice()
 	if err != nil {
-		return fmt.Errorf("failed to store event by ID: %w", err)
+		return fmt.Errorf("failed to append events to Redis: %w", err)
 	}
 
-	// Update the game's last event timestamp
-	lastEventKey := fmt.Sprintf("game:last_event:%s", event.GameID)
-	err = r.client.Set(ctx, lastEventKey, event.CreatedAt.Unix(), 0).Err()
-	if err != nil {
-		log.Printf("Warning: failed to update last event timestamp: %v", err)
+	if result[0] == 0 {
+		return &repositories.VersionConflictError{
+			GameID:          gameID,
+			ExpectedVersion: expectedVersion,
+			ActualVersion:   result[1],
+		}
 	}
 
 	return nil
@@ -76,11 +118,14 @@ func (r *RedisEventRepository) GetEventsForGame(gameID string) ([]aggregates.Gam
 	}
 
 	var events []aggregates.GameEvent
-	for _, eventJSON := range eventJSONs {
+	for i, eventJSON := range eventJSONs {
 		var event aggregates.GameEvent
 		if err := json.Unmarshal([]byte(eventJSON), &event); err != nil {
-			log.Printf("Warning: failed to unmarshal event: %v", err)
-			continue
+			return nil, fmt.Errorf("failed to decode event %d of game %s: %w", i, gameID, err)
+		}
+		// Events stored before versioning take their position in the stream
+		if event.Version == 0 {
+			event.Version = int64(i + 1)
 		}
 		events = append(events, event)
 	}
@@ -88,6 +133,33 @@ func (r *RedisEventRepository) GetEventsForGame(gameID string) ([]aggregates.Gam
 	return events, nil
 }
 
+// GetGameIDs returns the ID of every game that has an event stream
+func (r *RedisEventRepository) GetGameIDs() ([]string, error) {
+	ctx := context.Background()
+
+	var gameIDs []string
+	var cursor uint64
+
+	for {
+		keys, nextCursor, err := r.client.Scan(ctx, cursor, "game:events:*", 100).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan Redis event keys: %w", err)
+		}
+
+		for _, key := range keys {
+			gameIDs = append(gameIDs, strings.TrimPrefix(key, "game:events:"))
+		}
+
+		cursor = nextCursor
+
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return gameIDs, nil
+}
+
 // GetEventsSince retrieves events since a specific timestamp
 func (r *RedisEventRepository) GetEventsSince(gameID string, since time.Time) ([]aggregates.GameEvent, error) {
 	allEvents, err := r.GetEventsForGame(gameID)
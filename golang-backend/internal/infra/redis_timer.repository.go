@@ -0,0 +1,80 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const timersKey = "game:timers"
+
+type RedisTimerRepository struct {
+	client *redis.Client
+}
+
+func NewRedisTimerRepository(client *redis.Client) *RedisTimerRepository {
+	return &RedisTimerRepository{
+		client: client,
+	}
+}
+
+// claimDueTimersScript moves the due members of the timer set to the end of
+// their lease in one step, so two pollers never claim the same game.
+//
+// KEYS[1] = game:timers
+// ARGV[1] = now in unix milliseconds, ARGV[2] = most timers to claim,
+// ARGV[3] = end of the lease in unix milliseconds
+var claimDueTimersScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, gameID in ipairs(due) do
+	redis.call('ZADD', KEYS[1], 'XX', ARGV[3], gameID)
+end
+return due
+`)
+
+// timerScore is the fire time in unix milliseconds, rounded up so a timer is
+// never claimed before its deadline.
+func timerScore(fireAt time.Time) float64 {
+	score := fireAt.UnixMilli()
+
+	if fireAt.Sub(time.UnixMilli(score)) > 0 {
+		score++
+	}
+
+	return float64(score)
+}
+
+func (r *RedisTimerRepository) Schedule(gameID string, fireAt time.Time) error {
+	ctx := context.Background()
+
+	err := r.client.ZAdd(ctx, timersKey, redis.Z{Score: timerScore(fireAt), Member: gameID}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to schedule timer for game %s: %w", gameID, err)
+	}
+
+	return nil
+}
+
+func (r *RedisTimerRepository) Cancel(gameID string) error {
+	ctx := context.Background()
+
+	err := r.client.ZRem(ctx, timersKey, gameID).Err()
+	if err != nil {
+		return fmt.Errorf("failed to cancel timer for game %s: %w", gameID, err)
+	}
+
+	return nil
+}
+
+func (r *RedisTimerRepository) ClaimDue(now time.Time, leaseUntil time.Time, limit int) ([]string, error) {
+	ctx := context.Background()
+
+	gameIDs, err := claimDueTimersScript.Run(ctx, r.client, []string{timersKey}, now.UnixMilli(), limit, int64(timerScore(leaseUntil))).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due timers: %w", err)
+	}
+
+	return gameIDs, nil
+}
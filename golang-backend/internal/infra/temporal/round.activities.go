@@ -0,0 +1,39 @@
+package bootstrap
+
+import (
+	"cardgame/internal/services"
+	"context"
+)
+
+// RoundActivities are the steps of the round workflow. They call into the
+// coordinator, which checks the deadline has really passed, so a late or
+// repeated activity does nothing.
+type RoundActivities struct {
+	gameCoordinator *services.GameCoordinator
+}
+
+func NewRoundActivities(gameCoordinator *services.GameCoordinator) *RoundActivities {
+	return &RoundActivities{
+		gameCoordinator: gameCoordinator,
+	}
+}
+
+func (a *RoundActivities) GetRoundDeadline(ctx context.Context, gameID string) (services.RoundDeadline, error) {
+	return a.gameCoordinator.GetRoundDeadline(gameID)
+}
+
+func (a *RoundActivities) RemoveDisconnectedPlayers(ctx context.Context, gameID string) error {
+	return a.gameCoordinator.RemoveDisconnectedPlayers(gameID)
+}
+
+func (a *RoundActivities) PlayIdleCards(ctx context.Context, gameID string) error {
+	return a.gameCoordinator.AutoPlayCards(gameID)
+}
+
+func (a *RoundActivities) PickWinner(ctx context.Context, gameID string) error {
+	return a.gameCoordinator.AutoPickWinner(gameID)
+}
+
+func (a *RoundActivities) ContinueRound(ctx context.Context, gameID string) error {
+	return a.gameCoordinator.AutoContinueRound(gameID)
+}
@@ -0,0 +1,84 @@
+package bootstrap
+
+import (
+	"cardgame/internal/services"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+// TemporalRoundScheduler is a services.DeadlineScheduler that hands deadlines
+// to each game's RoundLifecycleWorkflow, starting it if needed.
+type TemporalRoundScheduler struct {
+	client    client.Client
+	taskQueue string
+}
+
+func NewTemporalRoundScheduler(client client.Client, taskQueue string) *TemporalRoundScheduler {
+	if taskQueue == "" {
+		taskQueue = DefaultRoundTaskQueue
+	}
+
+	return &TemporalRoundScheduler{
+		client:    client,
+		taskQueue: taskQueue,
+	}
+}
+
+// Schedule signals the game's workflow to load its new deadline. The workflow
+// reads the deadline from the game, so fireAt only matters to the caller.
+func (s *TemporalRoundScheduler) Schedule(gameID string, fireAt time.Time) error {
+	ctx := context.Background()
+
+	options := client.StartWorkflowOptions{
+		ID:        RoundWorkflowID(gameID),
+		TaskQueue: s.taskQueue,
+	}
+
+	_, err := s.client.SignalWithStartWorkflow(ctx, options.ID, RoundPhaseChangedSignal, nil, options, RoundLifecycleWorkflow, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to signal round workflow for game %s: %w", gameID, err)
+	}
+
+	return nil
+}
+
+// Cancel signals the game's workflow, if it is running, so it sees that the
+// game no longer has a deadline.
+func (s *TemporalRoundScheduler) Cancel(gameID string) error {
+	ctx := context.Background()
+
+	err := s.client.SignalWorkflow(ctx, RoundWorkflowID(gameID), "", RoundPhaseChangedSignal, nil)
+
+	var notFound *serviceerror.NotFound
+
+	if errors.As(err, &notFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to signal round workflow for game %s: %w", gameID, err)
+	}
+
+	return nil
+}
+
+// NewRoundWorker returns a worker that runs round workflows and their
+// activities for the coordinator. Callers start and stop it.
+func NewRoundWorker(client client.Client, taskQueue string, gameCoordinator *services.GameCoordinator) worker.Worker {
+	if taskQueue == "" {
+		taskQueue = DefaultRoundTaskQueue
+	}
+
+	w := worker.New(client, taskQueue, worker.Options{})
+
+	w.RegisterWorkflow(RoundLifecycleWorkflow)
+	w.RegisterActivity(NewRoundActivities(gameCoordinator))
+
+	return w
+}
@@ -0,0 +1,125 @@
+package bootstrap
+
+import (
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// DefaultRoundTaskQueue is used when TEMPORAL_TASK_QUEUE is not set.
+	DefaultRoundTaskQueue = "game-rounds"
+	// RoundPhaseChangedSignal tells a game's round workflow to reload its
+	// deadline.
+	RoundPhaseChangedSignal = "RoundPhaseChanged"
+	// roundWorkflowPhaseLimit is how many phases a run goes through before it
+	// continues as new, which keeps its history short.
+	roundWorkflowPhaseLimit = 200
+)
+
+// RoundWorkflowID is the ID of the round workflow for a game. There is at
+// most one running per game.
+func RoundWorkflowID(gameID string) string {
+	return "game-round-" + gameID
+}
+
+// RoundLifecycleWorkflow drives a game through picking, judging and
+// continuing. Each phase waits until its deadline, then runs the activity
+// that moves the game along, after removing any players whose reconnect grace
+// period ran out. A RoundPhaseChangedSignal means the players got
+// there first and the new deadline is loaded. The workflow ends with the game.
+func RoundLifecycleWorkflow(ctx workflow.Context, gameID string) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+			// Rules checks fail the same way on every attempt
+			NonRetryableErrorTypes: []string{"ValidationError"},
+		},
+	})
+
+	phaseChanged := workflow.GetSignalChannel(ctx, RoundPhaseChangedSignal)
+
+	var activities *RoundActivities
+
+	for phase := 0; phase < roundWorkflowPhaseLimit; phase++ {
+		var deadline services.RoundDeadline
+
+		if err := workflow.ExecuteActivity(ctx, activities.GetRoundDeadline, gameID).Get(ctx, &deadline); err != nil {
+			return err
+		}
+
+		// Disconnected players still have to be removed from games that are
+		// not in progress
+		if !deadline.InProgress && deadline.FireAt.IsZero() {
+			return nil
+		}
+
+		expired := waitForDeadline(ctx, phaseChanged, deadline.FireAt)
+
+		// Signals sent while waiting are covered by the reload
+		for phaseChanged.ReceiveAsync(nil) {
+		}
+
+		if !expired {
+			continue
+		}
+
+		if err := workflow.ExecuteActivity(ctx, activities.RemoveDisconnectedPlayers, gameID).Get(ctx, nil); err != nil {
+			workflow.GetLogger(ctx).Warn("Failed to remove disconnected players", "GameID", gameID, "Error", err)
+		}
+
+		var progress any
+
+		switch deadline.Status {
+		case valueobjects.PlayersPickingCard:
+			progress = activities.PlayIdleCards
+		case valueobjects.JudgePickingWinningCard, valueobjects.PlayersVoting:
+			progress = activities.PickWinner
+		case valueobjects.JudgeChoseWinningCard:
+			progress = activities.ContinueRound
+		default:
+			continue
+		}
+
+		if err := workflow.ExecuteActivity(ctx, progress, gameID).Get(ctx, nil); err != nil {
+			workflow.GetLogger(ctx).Warn("Failed to move the round along", "GameID", gameID, "Error", err)
+		}
+	}
+
+	return workflow.NewContinueAsNewError(ctx, RoundLifecycleWorkflow, gameID)
+}
+
+// waitForDeadline blocks until the deadline passes or the phase changes, and
+// reports whether the deadline passed. Untimed phases only end with a signal.
+func waitForDeadline(ctx workflow.Context, phaseChanged workflow.ReceiveChannel, fireAt time.Time) bool {
+	expired := false
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	selector := workflow.NewSelector(ctx)
+
+	selector.AddReceive(phaseChanged, func(c workflow.ReceiveChannel, more bool) {
+		c.Receive(ctx, nil)
+	})
+
+	if !fireAt.IsZero() {
+		wait := fireAt.Sub(workflow.Now(ctx))
+
+		if wait < 0 {
+			wait = 0
+		}
+
+		selector.AddFuture(workflow.NewTimer(timerCtx, wait), func(f workflow.Future) {
+			expired = f.Get(ctx, nil) == nil
+		})
+	}
+
+	selector.Select(ctx)
+
+	return expired
+}
@@ -0,0 +1,229 @@
+package bootstrap
+
+import (
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+// roundWorkflowTest runs the round workflow against mocked activities. The
+// deadlines func answers each GetRoundDeadline in turn, and every activity the
+// workflow runs is recorded with how far into the test it ran.
+type roundWorkflowTest struct {
+	env   *testsuite.TestWorkflowEnvironment
+	start time.Time
+	calls []string
+	at    []time.Duration
+}
+
+func newRoundWorkflowTest(t *testing.T, deadlines func(call int, start time.Time) services.RoundDeadline) *roundWorkflowTest {
+	t.Helper()
+
+	var suite testsuite.WorkflowTestSuite
+
+	rt := &roundWorkflowTest{env: suite.NewTestWorkflowEnvironment()}
+	rt.start = rt.env.Now()
+
+	activities := &RoundActivities{}
+	rt.env.RegisterActivity(activities)
+
+	record := func(name string) {
+		rt.calls = append(rt.calls, name)
+		rt.at = append(rt.at, rt.env.Now().Sub(rt.start))
+	}
+
+	loads := 0
+
+	rt.env.OnActivity(activities.GetRoundDeadline, mock.Anything, "game").Return(func(ctx context.Context, gameID string) (services.RoundDeadline, error) {
+		loads++
+		record("GetRoundDeadline")
+
+		deadline := deadlines(loads, rt.start)
+		deadline.GameID = gameID
+
+		return deadline, nil
+	})
+
+	for name, activity := range map[string]func(context.Context, string) error{
+		"RemoveDisconnectedPlayers": activities.RemoveDisconnectedPlayers,
+		"PlayIdleCards":             activities.PlayIdleCards,
+		"PickWinner":                activities.PickWinner,
+		"ContinueRound":             activities.ContinueRound,
+	} {
+		rt.env.OnActivity(activity, mock.Anything, "game").Return(func(ctx context.Context, gameID string) error {
+			record(name)
+			return nil
+		})
+	}
+
+	return rt
+}
+
+func (rt *roundWorkflowTest) run(t *testing.T) error {
+	t.Helper()
+
+	rt.env.ExecuteWorkflow(RoundLifecycleWorkflow, "game")
+
+	if !rt.env.IsWorkflowCompleted() {
+		t.Fatal("expected the workflow to finish")
+	}
+
+	return rt.env.GetWorkflowError()
+}
+
+func (rt *roundWorkflowTest) count(name string) int {
+	count := 0
+
+	for _, call := range rt.calls {
+		if call == name {
+			count++
+		}
+	}
+
+	return count
+}
+
+func phase(status valueobjects.RoundStatus, fireAt time.Time) services.RoundDeadline {
+	return services.RoundDeadline{Status: status, InProgress: true, FireAt: fireAt}
+}
+
+func TestRoundWorkflowMovesEachPhaseAlongAtItsDeadline(t *testing.T) {
+	rt := newRoundWorkflowTest(t, func(call int, start time.Time) services.RoundDeadline {
+		switch call {
+		case 1:
+			return phase(valueobjects.PlayersPickingCard, start.Add(30*time.Second))
+		case 2:
+			return phase(valueobjects.JudgePickingWinningCard, start.Add(90*time.Second))
+		case 3:
+			return phase(valueobjects.JudgeChoseWinningCard, start.Add(100*time.Second))
+		default:
+			return services.RoundDeadline{}
+		}
+	})
+
+	if err := rt.run(t); err != nil {
+		t.Fatalf("workflow failed: %v", err)
+	}
+
+	expected := []string{
+		"GetRoundDeadline", "RemoveDisconnectedPlayers", "PlayIdleCards",
+		"GetRoundDeadline", "RemoveDisconnectedPlayers", "PickWinner",
+		"GetRoundDeadline", "RemoveDisconnectedPlayers", "ContinueRound",
+		"GetRoundDeadline",
+	}
+
+	if !slices.Equal(rt.calls, expected) {
+		t.Fatalf("expected activities %v, got %v", expected, rt.calls)
+	}
+
+	for i, firedAt := range []time.Duration{30 * time.Second, 90 * time.Second, 100 * time.Second} {
+		if at := rt.at[3*i+2]; at != firedAt {
+			t.Fatalf("%s ran after %s, expected %s", rt.calls[3*i+2], at, firedAt)
+		}
+	}
+}
+
+func TestRoundWorkflowReloadsDeadlineWhenPhaseChanges(t *testing.T) {
+	rt := newRoundWorkflowTest(t, func(call int, start time.Time) services.RoundDeadline {
+		switch call {
+		case 1:
+			return phase(valueobjects.PlayersPickingCard, start.Add(time.Minute))
+		case 2:
+			// The players finished picking, and the judge has no time limit
+			return phase(valueobjects.JudgePickingWinningCard, time.Time{})
+		case 3:
+			return phase(valueobjects.PlayersVoting, start.Add(3*time.Minute))
+		default:
+			return services.RoundDeadline{}
+		}
+	})
+
+	rt.env.RegisterDelayedCallback(func() {
+		rt.env.SignalWorkflow(RoundPhaseChangedSignal, nil)
+	}, 10*time.Second)
+
+	rt.env.RegisterDelayedCallback(func() {
+		rt.env.SignalWorkflow(RoundPhaseChangedSignal, nil)
+	}, 2*time.Minute)
+
+	if err := rt.run(t); err != nil {
+		t.Fatalf("workflow failed: %v", err)
+	}
+
+	expected := []string{
+		"GetRoundDeadline", "GetRoundDeadline", "GetRoundDeadline",
+		"RemoveDisconnectedPlayers", "PickWinner", "GetRoundDeadline",
+	}
+
+	if !slices.Equal(rt.calls, expected) {
+		t.Fatalf("expected activities %v, got %v", expected, rt.calls)
+	}
+
+	if rt.at[1] != 10*time.Second || rt.at[2] != 2*time.Minute || rt.at[4] != 3*time.Minute {
+		t.Fatalf("activities ran at the wrong times: %v", rt.at)
+	}
+}
+
+func TestRoundWorkflowKeepsGoingWhenAnActivityFails(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+
+	env := suite.NewTestWorkflowEnvironment()
+	activities := &RoundActivities{}
+	env.RegisterActivity(activities)
+
+	loads := 0
+
+	env.OnActivity(activities.GetRoundDeadline, mock.Anything, "game").Return(func(ctx context.Context, gameID string) (services.RoundDeadline, error) {
+		loads++
+
+		if loads > 2 {
+			return services.RoundDeadline{}, nil
+		}
+
+		return phase(valueobjects.PlayersPickingCard, env.Now().Add(time.Second)), nil
+	})
+
+	env.OnActivity(activities.RemoveDisconnectedPlayers, mock.Anything, "game").Return(errors.New("store down"))
+	env.OnActivity(activities.PlayIdleCards, mock.Anything, "game").Return(errors.New("store down"))
+
+	env.ExecuteWorkflow(RoundLifecycleWorkflow, "game")
+
+	if !env.IsWorkflowCompleted() || env.GetWorkflowError() != nil {
+		t.Fatalf("expected the workflow to outlive failed activities: %v", env.GetWorkflowError())
+	}
+
+	if loads != 3 {
+		t.Fatalf("expected the deadline to be reloaded after each failure, loaded %d times", loads)
+	}
+}
+
+func TestRoundWorkflowContinuesAsNew(t *testing.T) {
+	rt := newRoundWorkflowTest(t, func(call int, start time.Time) services.RoundDeadline {
+		// Every phase is already over
+		return phase(valueobjects.JudgeChoseWinningCard, start)
+	})
+
+	err := rt.run(t)
+
+	var continueAsNew *workflow.ContinueAsNewError
+
+	if !errors.As(err, &continueAsNew) {
+		t.Fatalf("expected the workflow to continue as new, got %v", err)
+	}
+
+	if continueAsNew.WorkflowType.Name != "RoundLifecycleWorkflow" {
+		t.Fatalf("continued as %s", continueAsNew.WorkflowType.Name)
+	}
+
+	if continued := rt.count("ContinueRound"); continued != roundWorkflowPhaseLimit {
+		t.Fatalf("expected %d phases before continuing as new, got %d", roundWorkflowPhaseLimit, continued)
+	}
+}
@@ -3,53 +3,51 @@ package bootstrap
 import (
 	"crypto/tls"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"go.temporal.io/sdk/client"
 )
 
-// Ignore this file for now.
-type NewTemporalInstanceArgs struct {
-	TemporalHostPort  string
-	TemporalNamespace string
+type TemporalInstanceArgs struct {
+	TemporalHostPort       string
+	TemporalNamespace      string
+	TemporalClientCertPath string
+	TemporalClientKeyPath  string
 }
 
-func NewTemporalInstance(args NewTemporalInstanceArgs) client.Client {
-	wd, err := os.Getwd()
-	if err != nil {
-		panic(err)
+func NewTemporalInstanceArgs(hostPort string, namespace string, clientCertPath string, clientKeyPath string) TemporalInstanceArgs {
+	return TemporalInstanceArgs{
+		TemporalHostPort:       hostPort,
+		TemporalNamespace:      namespace,
+		TemporalClientCertPath: clientCertPath,
+		TemporalClientKeyPath:  clientKeyPath,
 	}
+}
 
-	fmt.Fprintln(os.Stdout, "Current working directory:", wd)
-	// Assuming the working directory is the project root
-	clientKeyPath := filepath.Join(wd, "certs/temporal", "cardbeyondhumanity.o83iu.key")
-	clientCertPath := filepath.Join(wd, "certs/temporal", "cardbeyondhumanity.o83iu.crt")
-
-	// Specify the host and port of your Temporal Cloud Namespace
-	// Host and port format: namespace.unique_id.tmprl.cloud:port
-	hostPort := args.TemporalHostPort
-	namespace := args.TemporalNamespace
-	// Use the crypto/tls package to create a cert object
-	cert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
-	if err != nil {
-		err = fmt.Errorf("unable to load cert and key pair: %w", err)
-		panic(err)
+// NewTemporalInstance dials Temporal. Temporal Cloud needs a client certificate
+// and key, a local dev server is dialled without TLS when none are given.
+func NewTemporalInstance(args TemporalInstanceArgs) (client.Client, error) {
+	options := client.Options{
+		HostPort:  args.TemporalHostPort,
+		Namespace: args.TemporalNamespace,
 	}
-	// Add the cert to the tls certificates in the ConnectionOptions of the Client
-	c, err := client.Dial(client.Options{
-		HostPort:  hostPort,
-		Namespace: namespace,
-		ConnectionOptions: client.ConnectionOptions{
+
+	if args.TemporalClientCertPath != "" || args.TemporalClientKeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(args.TemporalClientCertPath, args.TemporalClientKeyPath)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Temporal client certificate: %w", err)
+		}
+
+		options.ConnectionOptions = client.ConnectionOptions{
 			TLS: &tls.Config{Certificates: []tls.Certificate{cert}},
-		},
-	})
+		}
+	}
 
-	if err != nil {
-		err = fmt.Errorf("unable to connect to Temporal Cloud: %w", err)
+	c, err := client.Dial(options)
 
-		panic(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Temporal: %w", err)
 	}
 
-	return c
+	return c, nil
 }
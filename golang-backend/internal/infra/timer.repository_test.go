@@ -0,0 +1,134 @@
+package infra
+
+import (
+	"cardgame/internal/domain/repositories"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisTimerRepository(t *testing.T) *RedisTimerRepository {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	t.Cleanup(func() { client.Close() })
+
+	return NewRedisTimerRepository(client)
+}
+
+// timerRepositories are every TimerRepository, which must all behave the same.
+var timerRepositories = map[string]func(t *testing.T) repositories.TimerRepository{
+	"memory": func(t *testing.T) repositories.TimerRepository { return NewInMemoryTimerRepository() },
+	"redis":  func(t *testing.T) repositories.TimerRepository { return newTestRedisTimerRepository(t) },
+}
+
+func mustClaim(t *testing.T, timers repositories.TimerRepository, now time.Time, limit int) []string {
+	t.Helper()
+
+	gameIDs, err := timers.ClaimDue(now, now.Add(time.Minute), limit)
+
+	if err != nil {
+		t.Fatalf("could not claim timers: %v", err)
+	}
+
+	return gameIDs
+}
+
+func TestTimerRepositoryClaimsDueTimersInOrder(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+
+	for name, newTimers := range timerRepositories {
+		t.Run(name, func(t *testing.T) {
+			timers := newTimers(t)
+
+			timers.Schedule("late", now.Add(-time.Second))
+			timers.Schedule("early", now.Add(-time.Minute))
+			timers.Schedule("exact", now)
+			timers.Schedule("future", now.Add(time.Second))
+
+			if claimed := mustClaim(t, timers, now, 2); !slices.Equal(claimed, []string{"early", "late"}) {
+				t.Fatalf("expected the two earliest timers, got %v", claimed)
+			}
+
+			if claimed := mustClaim(t, timers, now, 10); !slices.Equal(claimed, []string{"exact"}) {
+				t.Fatalf("expected the timer due right now, got %v", claimed)
+			}
+
+			if claimed := mustClaim(t, timers, now, 10); len(claimed) != 0 {
+				t.Fatalf("expected nothing left to claim, got %v", claimed)
+			}
+		})
+	}
+}
+
+func TestTimerRepositoryLeasesClaimedTimers(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	leaseUntil := now.Add(30 * time.Second)
+
+	for name, newTimers := range timerRepositories {
+		t.Run(name, func(t *testing.T) {
+			timers := newTimers(t)
+
+			timers.Schedule("game", now.Add(-time.Second))
+
+			if claimed, _ := timers.ClaimDue(now, leaseUntil, 10); !slices.Equal(claimed, []string{"game"}) {
+				t.Fatalf("expected the game to be claimed, got %v", claimed)
+			}
+
+			if claimed, _ := timers.ClaimDue(leaseUntil.Add(-time.Millisecond), leaseUntil, 10); len(claimed) != 0 {
+				t.Fatalf("claimed timer was claimed again during its lease: %v", claimed)
+			}
+
+			// Nobody moved the game along, so it comes back once the lease runs out
+			if claimed, _ := timers.ClaimDue(leaseUntil, leaseUntil.Add(time.Minute), 10); !slices.Equal(claimed, []string{"game"}) {
+				t.Fatalf("expected the game back after its lease, got %v", claimed)
+			}
+		})
+	}
+}
+
+func TestTimerRepositoryScheduleAndCancelReplaceLease(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	leaseUntil := now.Add(30 * time.Second)
+
+	for name, newTimers := range timerRepositories {
+		t.Run(name, func(t *testing.T) {
+			timers := newTimers(t)
+
+			timers.Schedule("rescheduled", now)
+			timers.Schedule("cancelled", now)
+			timers.ClaimDue(now, leaseUntil, 10)
+
+			timers.Schedule("rescheduled", now.Add(time.Hour))
+			timers.Cancel("cancelled")
+
+			if claimed := mustClaim(t, timers, leaseUntil, 10); len(claimed) != 0 {
+				t.Fatalf("expected no timers once the lease ran out, got %v", claimed)
+			}
+
+			if claimed := mustClaim(t, timers, now.Add(time.Hour), 10); !slices.Equal(claimed, []string{"rescheduled"}) {
+				t.Fatalf("expected the rescheduled game, got %v", claimed)
+			}
+		})
+	}
+}
+
+func TestRedisTimerRepositoryNeverFiresEarly(t *testing.T) {
+	timers := newTestRedisTimerRepository(t)
+	fireAt := time.UnixMilli(1_700_000_000_000).Add(300 * time.Microsecond)
+
+	timers.Schedule("game", fireAt)
+
+	if claimed := mustClaim(t, timers, fireAt.Truncate(time.Millisecond), 10); len(claimed) != 0 {
+		t.Fatalf("timer was claimed before its deadline: %v", claimed)
+	}
+
+	if claimed := mustClaim(t, timers, fireAt.Add(time.Millisecond), 10); !slices.Equal(claimed, []string{"game"}) {
+		t.Fatalf("expected the timer once it was due, got %v", claimed)
+	}
+}
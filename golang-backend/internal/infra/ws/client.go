@@ -7,6 +7,7 @@ import (
 type Client struct {
 	Conn   *websocket.Conn
 	RoomID string
+	UserID string
 	Send   chan []byte
 }
 
@@ -1,33 +1,60 @@
 package ws
 
-import "sync"
+import (
+	"log"
+	"sync"
+)
 
 type Hub struct {
 	mu       sync.RWMutex
 	rooms    map[string]map[*Client]struct{}
-	register chan *Client
+	register chan registration
 	unreg    chan *Client
 	bcast    chan broadcast
+	direct   chan directMessage
+	dismiss  chan dismissal
 }
 
+type registration struct {
+	client *Client
+	done   chan struct{}
+}
+
+// broadcast is one message for a whole room, or a message built for each
+// client in it when build is set.
 type broadcast struct {
 	roomID string
 	data   []byte
+	build  func(c *Client) ([]byte, error)
+}
+
+type directMessage struct {
+	client *Client
+	data   []byte
+}
+
+type dismissal struct {
+	roomID string
+	userID string
+	data   []byte
 }
 
 func NewHub() *Hub {
 	return &Hub{
 		rooms:    map[string]map[*Client]struct{}{},
-		register: make(chan *Client, 64),
+		register: make(chan registration, 64),
 		unreg:    make(chan *Client, 64),
 		bcast:    make(chan broadcast, 256),
+		direct:   make(chan directMessage, 256),
+		dismiss:  make(chan dismissal, 64),
 	}
 }
 
 func (h *Hub) Run() {
 	for {
 		select {
-		case c := <-h.register:
+		case r := <-h.register:
+			c := r.client
 			h.mu.Lock()
 			if _, ok := h.rooms[c.RoomID]; !ok {
 				h.rooms[c.RoomID] = map[*Client]struct{}{}
@@ -35,37 +62,122 @@ func (h *Hub) Run() {
 			h.rooms[c.RoomID][c] = struct{}{}
 			h.mu.Unlock()
 			go c.writePump()
+			close(r.done)
 
 		case c := <-h.unreg:
 			h.mu.Lock()
 			if clients, ok := h.rooms[c.RoomID]; ok {
-				delete(clients, c)
+				if _, registered := clients[c]; registered {
+					delete(clients, c)
+					close(c.Send)
+				}
 				if len(clients) == 0 {
 					delete(h.rooms, c.RoomID)
 				}
 			}
 			h.mu.Unlock()
-			close(c.Send)
 
 		case m := <-h.bcast:
 			h.mu.RLock()
 			if clients, ok := h.rooms[m.roomID]; ok {
 				for c := range clients {
+					data := m.data
+					if m.build != nil {
+						var err error
+						if data, err = m.build(c); err != nil {
+							log.Printf("Error building message for client in room %s: %v", m.roomID, err)
+							continue
+						}
+					}
+					h.send(c, data)
+				}
+			}
+			h.mu.RUnlock()
+
+		case m := <-h.direct:
+			h.mu.RLock()
+			// the client may have left between the message being queued and now
+			if clients, ok := h.rooms[m.client.RoomID]; ok {
+				if _, registered := clients[m.client]; registered {
+					h.send(m.client, m.data)
+				}
+			}
+			h.mu.RUnlock()
+
+		case m := <-h.dismiss:
+			h.mu.Lock()
+			if clients, ok := h.rooms[m.roomID]; ok {
+				for c := range clients {
+					if c.UserID != m.userID {
+						continue
+					}
+					// the write pump sends the last message before closing the connection
 					select {
 					case c.Send <- m.data:
 					default:
-						// slow client, drop connection
-						go func(c *Client) { h.unreg <- c }(c)
 					}
+					delete(clients, c)
+					close(c.Send)
+				}
+				if len(clients) == 0 {
+					delete(h.rooms, m.roomID)
 				}
 			}
-			h.mu.RUnlock()
+			h.mu.Unlock()
 		}
 	}
 }
 
-func (h *Hub) Join(roomID string, c *Client) { h.register <- c }
-func (h *Hub) Leave(c *Client)               { h.unreg <- c }
+func (h *Hub) send(c *Client, data []byte) {
+	select {
+	case c.Send <- data:
+	default:
+		// slow client, drop connection
+		go func(c *Client) { h.unreg <- c }(c)
+	}
+}
+
+// Join adds a client to a room. It returns once the client is in the room,
+// so everything published afterwards reaches it.
+func (h *Hub) Join(roomID string, c *Client) {
+	done := make(chan struct{})
+	h.register <- registration{client: c, done: done}
+	<-done
+}
+
+func (h *Hub) Leave(c *Client) { h.unreg <- c }
 func (h *Hub) Broadcast(roomID string, data []byte) {
 	h.bcast <- broadcast{roomID: roomID, data: data}
 }
+
+// BroadcastEach sends every client in a room its own message. Messages are
+// built on the hub's goroutine, against the clients in the room at the time,
+// and stay in order with everything else broadcast to the room.
+func (h *Hub) BroadcastEach(roomID string, build func(c *Client) ([]byte, error)) {
+	h.bcast <- broadcast{roomID: roomID, build: build}
+}
+
+// Dismiss sends every client a user has in a room one last message and then
+// closes their connections.
+func (h *Hub) Dismiss(roomID string, userID string, data []byte) {
+	h.dismiss <- dismissal{roomID: roomID, userID: userID, data: data}
+}
+
+// SendTo queues a message for a single client.
+func (h *Hub) SendTo(c *Client, data []byte) {
+	h.direct <- directMessage{client: c, data: data}
+}
+
+// Clients returns a snapshot of the clients currently in a room.
+func (h *Hub) Clients(roomID string) []*Client {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	clients := make([]*Client, 0, len(h.rooms[roomID]))
+
+	for c := range h.rooms[roomID] {
+		clients = append(clients, c)
+	}
+
+	return clients
+}
@@ -1,6 +1,10 @@
 package ws
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/gofiber/websocket/v2"
+)
 
 type Publisher struct {
 	Hub *Hub
@@ -9,14 +13,60 @@ type Publisher struct {
 func NewPublisher(h *Hub) *Publisher { return &Publisher{Hub: h} }
 
 func (p *Publisher) PublishToRoom(roomID string, eventType string, payload any) error {
+	b, err := marshalEnvelope(eventType, payload)
+	if err != nil {
+		return err
+	}
+	p.Hub.Broadcast(roomID, b)
+	return nil
+}
+
+// PublishToRoomPerClient sends every client in the room its own payload, built
+// from the user id the client connected as. Payloads are built later, on the
+// hub's goroutine, so whatever build reads must not change afterwards.
+func (p *Publisher) PublishToRoomPerClient(roomID string, eventType string, build func(userID string) any) error {
+	p.Hub.BroadcastEach(roomID, func(c *Client) ([]byte, error) {
+		return marshalEnvelope(eventType, build(c.UserID))
+	})
+	return nil
+}
+
+func marshalEnvelope(eventType string, payload any) ([]byte, error) {
 	envelope := map[string]any{
 		"type":    eventType,
 		"payload": payload,
 	}
-	b, err := json.Marshal(envelope)
+	return json.Marshal(envelope)
+}
+
+// PublishToClient sends a message to a single client, e.g. a reply to a
+// message that client sent.
+func (p *Publisher) PublishToClient(c *Client, eventType string, payload any) error {
+	b, err := marshalEnvelope(eventType, payload)
 	if err != nil {
 		return err
 	}
-	p.Hub.Broadcast(roomID, b)
+	p.Hub.SendTo(c, b)
+	return nil
+}
+
+// DisconnectUser sends every client the user has in the room a final message
+// and closes their connections.
+func (p *Publisher) DisconnectUser(roomID string, userID string, eventType string, payload any) error {
+	b, err := marshalEnvelope(eventType, payload)
+	if err != nil {
+		return err
+	}
+	p.Hub.Dismiss(roomID, userID, b)
 	return nil
 }
+
+// WriteToClient writes a message straight to a client that never joined the
+// hub, e.g. to tell it why it was turned away.
+func (p *Publisher) WriteToClient(c *Client, eventType string, payload any) error {
+	b, err := marshalEnvelope(eventType, payload)
+	if err != nil {
+		return err
+	}
+	return c.Conn.WriteMessage(websocket.TextMessage, b)
+}
@@ -19,6 +19,7 @@ type ChatGPTDeckResponse struct {
 	Cards    []struct {
 		Type  entities.CardType `json:"type"`
 		Value string            `json:"value"`
+		Pick  int               `json:"pick"`
 	} `json:"cards"`
 }
 
@@ -92,6 +93,10 @@ func (s *ChatGPTService) GenerateDeck(subject string) (*aggregates.Collection, e
 								"type":        "string",
 								"description": "The content of the card",
 							},
+							"pick": map[string]interface{}{
+								"type":        "integer",
+								"description": "For Black cards, how many White cards it takes to answer it, from 1 to 3",
+							},
 						},
 						"required": []string{"type", "value"},
 					},
@@ -116,10 +121,11 @@ Requirements:
 - Each card must have:
   - "type": which can only be either "Black" or "White",
   - "value": a string containing the card's content
+  - "pick": for Black cards, the number of blanks to fill (1 to 3)
 
 Example Black cards:
 - "_____ is the key to success"
-- "The best thing about _____ is _____"
+- "The best thing about _____ is _____" (pick 2)
 - "I never thought I'd see _____ in my lifetime"
 
 Example White cards:
@@ -161,9 +167,13 @@ Example White cards:
 	collection := aggregates.NewCollection()
 
 	for _, card := range response.Cards {
-		collection.AddCard(
-			entities.NewCard(uuid.New().String(), card.Type, card.Value),
-		)
+		newCard := entities.NewCard(uuid.New().String(), card.Type, card.Value)
+
+		if card.Type == entities.Black {
+			newCard.Pick = card.Pick
+		}
+
+		collection.AddCard(newCard)
 	}
 
 	return collection, nil
@@ -0,0 +1,19 @@
+package services
+
+import "cardgame/internal/domain/aggregates"
+
+// EventRecorder, Execute, Inspect and Poll give the tests outside the package
+// the same access to a game's actor and the timer poller that the package has.
+type EventRecorder = eventRecorder
+
+func (gc *GameCoordinator) Execute(gameId string, command func(g *aggregates.Game, recorder *EventRecorder) error) error {
+	return gc.execute(gameId, command)
+}
+
+func (gc *GameCoordinator) Inspect(gameId string, inspect func(g *aggregates.Game) error) error {
+	return gc.inspect(gameId, inspect)
+}
+
+func (p *GameTimerPoller) Poll() {
+	p.poll()
+}
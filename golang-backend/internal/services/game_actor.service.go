@@ -0,0 +1,144 @@
+package services
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/repositories"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// gameActor owns a single live game. Every command for the game is sent to
+// its mailbox and run one at a time on the actor's goroutine, so the game is
+// never touched concurrently.
+type gameActor struct {
+	coordinator *GameCoordinator
+	game        *aggregates.Game
+	mailbox     chan actorRequest
+}
+
+type actorRequest struct {
+	command gameCommand
+	inspect func(g *aggregates.Game) error
+	result  chan error
+}
+
+func newGameActor(coordinator *GameCoordinator, game *aggregates.Game) *gameActor {
+	return &gameActor{
+		coordinator: coordinator,
+		game:        game,
+		mailbox:     make(chan actorRequest, 64),
+	}
+}
+
+func (a *gameActor) run() {
+	for request := range a.mailbox {
+		if request.inspect != nil {
+			request.result <- request.inspect(a.game)
+			continue
+		}
+
+		request.result <- a.handle(request.command)
+	}
+}
+
+// submit queues a command and waits for it to finish.
+func (a *gameActor) submit(command gameCommand) error {
+	result := make(chan error, 1)
+
+	a.mailbox <- actorRequest{command: command, result: result}
+
+	return <-result
+}
+
+// read queues a function that only reads the game and waits for it to finish.
+func (a *gameActor) read(inspect func(g *aggregates.Game) error) error {
+	result := make(chan error, 1)
+
+	a.mailbox <- actorRequest{inspect: inspect, result: result}
+
+	return <-result
+}
+
+// handle runs a command on a copy of the game, appends the events it recorded
+// conditionally on the version the game was at, then snapshots and publishes
+// the result. The copy only replaces the actor's game once its events are in
+// the log, so a command that fails part way leaves the game as it was. When
+// another writer appended first the game is rebuilt from the event log and
+// the command is run again.
+func (a *gameActor) handle(command gameCommand) error {
+	gc := a.coordinator
+
+	for attempt := 1; ; attempt++ {
+		g := a.game.Clone()
+		expectedVersion := g.Version
+		phase := currentRoundPhase(g)
+		deadline := gc.roundDeadline(g)
+		nextAutoProgressAt := g.NextAutoProgressAt
+		recorder := newEventRecorder(g)
+
+		if err := command(g, recorder); err != nil {
+			return err
+		}
+
+		if err := gc.recordClock(g, recorder, phase); err != nil {
+			return err
+		}
+
+		err := gc.eventRepository.AppendEvents(g.ID, expectedVersion, recorder.events)
+
+		var conflict *repositories.VersionConflictError
+
+		if errors.As(err, &conflict) && attempt < maxCommandAttempts {
+			log.Printf("Retrying command on game %s after conflict (attempt %d): %v", g.ID, attempt, err)
+
+			if err := a.reload(); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to append events: %w", err)
+		}
+
+		a.game = g
+
+		if len(recorder.events) == 0 {
+			return nil
+		}
+
+		_, err = gc.gameRepository.Update(g)
+
+		if err != nil {
+			return fmt.Errorf("failed to update game: %w", err)
+		}
+
+		gc.publishGameUpdate(g)
+
+		if !gc.roundDeadline(g).FireAt.Equal(deadline.FireAt) {
+			gc.armClock(g)
+		}
+
+		// Resuming a game moves its deadline on without an EventClockUpdate
+		if recorder.hasRecorded(aggregates.EventClockUpdate) || !g.NextAutoProgressAt.Equal(nextAutoProgressAt) {
+			gc.publishClock(g)
+		}
+
+		return nil
+	}
+}
+
+// reload replaces the actor's game with one rebuilt from the event log.
+func (a *gameActor) reload() error {
+	game, err := a.coordinator.gameRehydrator.Rehydrate(a.game.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to reload game %s: %w", a.game.ID, err)
+	}
+
+	a.game = game
+
+	return nil
+}
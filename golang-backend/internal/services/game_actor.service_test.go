@@ -0,0 +1,167 @@
+package services_test
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestActorKeepsGameWhenCommandFails(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+	before := env.game(t, gameID)
+
+	err := env.coordinator.Execute(gameID, func(g *aggregates.Game, recorder *services.EventRecorder) error {
+		if err := recorder.Record(aggregates.EventJoinedGame, aggregates.NewGameEventPayloadJoinedGame(gameID, "u4", "p4", claim("u4"))); err != nil {
+			return err
+		}
+
+		g.Name = "changed"
+
+		return errors.New("command failed")
+	})
+
+	if err == nil {
+		t.Fatal("expected the command to fail")
+	}
+
+	// A command can also fail having changed the game without recording anything
+	err = env.coordinator.Execute(gameID, func(g *aggregates.Game, recorder *services.EventRecorder) error {
+		g.Players = g.Players[:1]
+
+		return errors.New("command failed")
+	})
+
+	if err == nil {
+		t.Fatal("expected the command to fail")
+	}
+
+	after := env.game(t, gameID)
+
+	if after.Version != before.Version || len(after.Players) != 3 || after.Name != before.Name {
+		t.Fatalf("failed command changed the game: version %d, %d players, name %q", after.Version, len(after.Players), after.Name)
+	}
+
+	if events, _ := env.events.GetEventsForGame(gameID); int64(len(events)) != before.Version {
+		t.Fatalf("failed command appended events: %d events for version %d", len(events), before.Version)
+	}
+}
+
+func TestActorKeepsGameWhenRejectedEventFails(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+	before := env.game(t, gameID)
+
+	// The first event applies, the second is rejected part way through
+	err := env.coordinator.Execute(gameID, func(g *aggregates.Game, recorder *services.EventRecorder) error {
+		if err := recorder.Record(aggregates.EventJoinedGame, aggregates.NewGameEventPayloadJoinedGame(gameID, "u4", "p4", claim("u4"))); err != nil {
+			return err
+		}
+
+		return recorder.Record(aggregates.EventDealCards, aggregates.NewGameEventPayloadDealCards(gameID, "u2", []string{"w79", "missing"}))
+	})
+
+	if err == nil {
+		t.Fatal("expected the command to fail")
+	}
+
+	after := env.game(t, gameID)
+	player, _ := after.FindPlayerByUserId("u2")
+
+	if after.Version != before.Version || len(after.Players) != 3 || len(player.Deck) != 0 {
+		t.Fatalf("failed command changed the game: version %d, %d players, %d cards", after.Version, len(after.Players), len(player.Deck))
+	}
+}
+
+func TestActorKeepsGameWhenAppendFails(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{})
+
+	env.events.fail.Store(true)
+
+	if err := env.coordinator.Join(gameID, claim("u2")); err == nil {
+		t.Fatal("expected the join to fail while the event store is down")
+	}
+
+	env.events.fail.Store(false)
+
+	if players := len(env.game(t, gameID).Players); players != 1 {
+		t.Fatalf("join that was never stored left %d players in the game", players)
+	}
+
+	if err := env.coordinator.Join(gameID, claim("u2")); err != nil {
+		t.Fatalf("join once the event store is back: %v", err)
+	}
+}
+
+func TestActorRetriesAfterConflict(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{})
+	before := env.game(t, gameID)
+
+	// Another server appends to the stream behind the actor's back
+	payload, err := json.Marshal(aggregates.NewGameEventPayloadJoinedGame(gameID, "u2", "p2", claim("u2")))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event := aggregates.NewGameEvent(gameID, aggregates.EventJoinedGame, payload)
+	event.Version = before.Version + 1
+
+	if err := env.events.AppendEvents(gameID, before.Version, []*aggregates.GameEvent{event}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := env.coordinator.Join(gameID, claim("u3")); err != nil {
+		t.Fatalf("join after conflict: %v", err)
+	}
+
+	after := env.game(t, gameID)
+
+	if after.Version != before.Version+2 || len(after.Players) != 3 {
+		t.Fatalf("expected the game to pick up the other server's event: version %d, %d players", after.Version, len(after.Players))
+	}
+}
+
+// TestActorConcurrentCommands is meant for the race detector. Commands from
+// many goroutines all go through the actor's mailbox.
+func TestActorConcurrentCommands(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{})
+
+	var wg sync.WaitGroup
+
+	for i := 2; i <= 6; i++ {
+		wg.Add(1)
+
+		go func(userID string) {
+			defer wg.Done()
+
+			if err := env.coordinator.Join(gameID, claim(userID)); err != nil {
+				t.Errorf("could not join %s: %v", userID, err)
+			}
+		}(fmt.Sprintf("u%d", i))
+	}
+
+	wg.Wait()
+
+	if err := env.coordinator.BeginGame(gameID, claim("u1"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	g := env.game(t, gameID)
+
+	if len(g.Players) != 6 || g.RoundStatus != valueobjects.PlayersPickingCard {
+		t.Fatalf("expected 6 players picking cards, got %d in %s", len(g.Players), g.RoundStatus)
+	}
+
+	if replayed := env.replayed(t, gameID); replayed.Version != g.Version || len(replayed.Players) != 6 {
+		t.Fatalf("live game at version %d does not match its event log at %d", g.Version, replayed.Version)
+	}
+}
@@ -0,0 +1,469 @@
+package services
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/domain/valueobjects"
+	"fmt"
+	"log"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// roundPhase identifies the part of a round a deadline was armed for. A new
+// round with the same status is a new phase, as is a voided round that starts
+// over with a new black card.
+type roundPhase struct {
+	status    valueobjects.RoundStatus
+	round     int
+	blackCard string
+}
+
+func currentRoundPhase(g *aggregates.Game) roundPhase {
+	phase := roundPhase{
+		status: g.RoundStatus,
+		round:  g.CurrentGameRound,
+	}
+
+	if g.BlackCard != nil {
+		phase.blackCard = g.BlackCard.ID
+	}
+
+	return phase
+}
+
+// phaseTimeout is how long players get to act in the game's round status,
+// preferring the game's own timers over the server's. Zero means the status
+// is never timed.
+func (r GameRules) phaseTimeout(g *aggregates.Game) time.Duration {
+	switch g.RoundStatus {
+	case valueobjects.PlayersPickingCard:
+		return valueobjects.Timeout(g.Timers.PickingSeconds, r.PickingTimeout)
+	case valueobjects.JudgePickingWinningCard, valueobjects.PlayersVoting:
+		return valueobjects.Timeout(g.Timers.JudgingSeconds, r.JudgingTimeout)
+	case valueobjects.JudgeChoseWinningCard:
+		return valueobjects.Timeout(g.Timers.RoundPauseSeconds, r.RoundPauseTimeout)
+	default:
+		return 0
+	}
+}
+
+// recordClock records the deadline for the game's round phase when a command
+// moved the game into a new one. Deadlines are stored on the game through
+// EventClockUpdate so they survive a restart.
+func (gc *GameCoordinator) recordClock(g *aggregates.Game, recorder *eventRecorder, before roundPhase) error {
+	if currentRoundPhase(g) == before {
+		return nil
+	}
+
+	deadline := time.Time{}
+
+	if timeout := gc.rules.phaseTimeout(g); timeout > 0 && g.IsInProgress() {
+		// A phase that starts while the game is paused starts counting down
+		// once it is resumed
+		startsAt := time.Now()
+
+		if g.IsPaused() {
+			startsAt = g.PausedAt
+		}
+
+		deadline = startsAt.Add(timeout)
+	}
+
+	if deadline.IsZero() && g.NextAutoProgressAt.IsZero() {
+		return nil
+	}
+
+	return recorder.Record(aggregates.EventClockUpdate, aggregates.NewGameEventPayloadClockUpdate(g.ID, deadline))
+}
+
+// RoundDeadline is when the game next needs moving along, either because the
+// current phase of its round runs out or because a disconnected player's
+// grace period does. The phase never runs out while the game is paused.
+type RoundDeadline struct {
+	GameID     string                   `json:"game_id"`
+	Status     valueobjects.RoundStatus `json:"status"`
+	Round      int                      `json:"round"`
+	InProgress bool                     `json:"in_progress"`
+	// FireAt is zero when nothing is timed
+	FireAt time.Time `json:"fire_at"`
+}
+
+func (gc *GameCoordinator) roundDeadline(g *aggregates.Game) RoundDeadline {
+	deadline := RoundDeadline{
+		GameID:     g.ID,
+		Status:     g.RoundStatus,
+		Round:      g.CurrentGameRound,
+		InProgress: g.IsInProgress(),
+	}
+
+	if deadline.InProgress && !g.IsPaused() && gc.rules.phaseTimeout(g) > 0 {
+		deadline.FireAt = g.NextAutoProgressAt
+	}
+
+	for _, player := range g.Players {
+		if !player.IsDisconnected {
+			continue
+		}
+
+		graceEndsAt := player.DisconnectedAt.Add(gc.rules.DisconnectGracePeriod)
+
+		if deadline.FireAt.IsZero() || graceEndsAt.Before(deadline.FireAt) {
+			deadline.FireAt = graceEndsAt
+		}
+	}
+
+	return deadline
+}
+
+// GetRoundDeadline returns when the game's current round phase runs out.
+func (gc *GameCoordinator) GetRoundDeadline(gameId string) (RoundDeadline, error) {
+	var deadline RoundDeadline
+
+	err := gc.inspect(gameId, func(g *aggregates.Game) error {
+		deadline = gc.roundDeadline(g)
+		return nil
+	})
+
+	return deadline, err
+}
+
+// armClock hands the game's current deadline to the deadline scheduler,
+// replacing any deadline armed before. Deadlines that already passed fire
+// straight away.
+func (gc *GameCoordinator) armClock(g *aggregates.Game) {
+	var err error
+
+	if deadline := gc.roundDeadline(g); !deadline.FireAt.IsZero() {
+		err = gc.deadlineScheduler.Schedule(g.ID, deadline.FireAt)
+	} else {
+		err = gc.deadlineScheduler.Cancel(g.ID)
+	}
+
+	if err != nil {
+		log.Printf("Warning: failed to arm the clock for game %s: %v", g.ID, err)
+	}
+}
+
+// rearmClock hands the game's deadline back to the deadline scheduler once it
+// has fired. A deadline that is still due is left alone, so a game that could
+// not be moved along is not fired again straight away.
+func (gc *GameCoordinator) rearmClock(gameId string) error {
+	return gc.inspect(gameId, func(g *aggregates.Game) error {
+		if fireAt := gc.roundDeadline(g).FireAt; !fireAt.IsZero() && !fireAt.After(time.Now()) {
+			return nil
+		}
+
+		gc.armClock(g)
+
+		return nil
+	})
+}
+
+// publishClock tells everyone in the game when the current phase ends, so
+// clients can count down to it.
+func (gc *GameCoordinator) publishClock(g *aggregates.Game) error {
+	return gc.publisher.PublishToRoom(g.ID, string(aggregates.ClockUpdate), aggregates.NewGameEventPayloadClockUpdate(g.ID, g.NextAutoProgressAt))
+}
+
+// AutoProgress moves a game along once its deadline has passed. Players whose
+// reconnect grace period has run out are removed first. Idle players
+// have random cards played for them, an idle judge has a submission picked
+// for them, an unfinished vote is counted as it stands and a finished round
+// is continued.
+func (gc *GameCoordinator) AutoProgress(gameId string) error {
+	return gc.autoProgress(gameId)
+}
+
+// AutoPlayCards plays random cards for idle players once the picking deadline
+// has passed.
+func (gc *GameCoordinator) AutoPlayCards(gameId string) error {
+	return gc.autoProgress(gameId, valueobjects.PlayersPickingCard)
+}
+
+// AutoPickWinner picks the winner for an idle judge, or counts the votes,
+// once the judging deadline has passed.
+func (gc *GameCoordinator) AutoPickWinner(gameId string) error {
+	return gc.autoProgress(gameId, valueobjects.JudgePickingWinningCard, valueobjects.PlayersVoting)
+}
+
+// AutoContinueRound starts the next round once the pause after a round has
+// passed.
+func (gc *GameCoordinator) AutoContinueRound(gameId string) error {
+	return gc.autoProgress(gameId, valueobjects.JudgeChoseWinningCard)
+}
+
+// RemoveDisconnectedPlayers removes every player whose reconnect grace period
+// has run out.
+func (gc *GameCoordinator) RemoveDisconnectedPlayers(gameId string) error {
+	chatMessage := ""
+	leftNames := []string{}
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		chatMessage = ""
+
+		var err error
+
+		leftNames, err = gc.removeExpiredPlayers(g, recorder, &chatMessage)
+
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	gc.publishDepartures(gameId, leftNames, chatMessage)
+
+	return nil
+}
+
+// removeExpiredPlayers removes the players whose reconnect grace period has
+// run out and returns their names.
+func (gc *GameCoordinator) removeExpiredPlayers(g *aggregates.Game, recorder *eventRecorder, chatMessage *string) ([]string, error) {
+	now := time.Now()
+	leftNames := []string{}
+
+	// Removing players reorders the slice being ranged over
+	for _, player := range slices.Clone(g.Players) {
+		if !player.IsDisconnected || now.Before(player.DisconnectedAt.Add(gc.rules.DisconnectGracePeriod)) {
+			continue
+		}
+
+		if err := gc.removePlayer(g, recorder, aggregates.EventPlayerLeft, player.UserID, chatMessage); err != nil {
+			return nil, err
+		}
+
+		leftNames = append(leftNames, player.Name)
+	}
+
+	return leftNames, nil
+}
+
+// publishDepartures announces the players removed when their grace period
+// ran out, followed by whatever else happened to the game.
+func (gc *GameCoordinator) publishDepartures(gameId string, leftNames []string, chatMessage string) {
+	for _, name := range leftNames {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s didn't come back and left the game.", name))
+	}
+
+	if chatMessage != "" {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), chatMessage)
+	}
+}
+
+// autoProgress removes players whose grace period has run out, then moves the
+// game along if its deadline has passed and its round is in one of the given
+// statuses, or in any status when none are given.
+func (gc *GameCoordinator) autoProgress(gameId string, statuses ...valueobjects.RoundStatus) error {
+	chatMessage := ""
+	leftNames := []string{}
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		chatMessage = ""
+		phase := currentRoundPhase(g)
+
+		var err error
+
+		leftNames, err = gc.removeExpiredPlayers(g, recorder, &chatMessage)
+
+		if err != nil {
+			return err
+		}
+
+		// The timer may have been armed for a phase that already ended, or
+		// that a departure just ended
+		if currentRoundPhase(g) != phase || !g.IsInProgress() || g.IsPaused() || g.NextAutoProgressAt.IsZero() || time.Now().Before(g.NextAutoProgressAt) {
+			return nil
+		}
+
+		if len(statuses) > 0 && !slices.Contains(statuses, g.RoundStatus) {
+			return nil
+		}
+
+		return gc.advanceRound(g, recorder, "time's up!", &chatMessage)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	gc.publishDepartures(gameId, leftNames, chatMessage)
+
+	return nil
+}
+
+// PauseGame freezes the round timers and stops cards being played or picked
+// until the owner resumes the game.
+func (gc *GameCoordinator) PauseGame(gameId string, claim *entities.CustomClaim) error {
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidatePauseGame(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		return recorder.Record(aggregates.EventGamePaused, aggregates.NewGameEventPayloadGamePaused(gameId, claim.UserID))
+	})
+
+	if err != nil {
+		return err
+	}
+
+	gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s paused the game.", claim.Name))
+
+	return nil
+}
+
+// ResumeGame picks the game up where it was paused. The round gets back the
+// time it had left.
+func (gc *GameCoordinator) ResumeGame(gameId string, claim *entities.CustomClaim) error {
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidateResumeGame(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		return recorder.Record(aggregates.EventGameResumed, aggregates.NewGameEventPayloadGamePaused(gameId, claim.UserID))
+	})
+
+	if err != nil {
+		return err
+	}
+
+	gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s resumed the game.", claim.Name))
+
+	return nil
+}
+
+// ForceAdvance moves the round on to its next status straight away, the same
+// way it would move on once its time runs out. Rounds that have nowhere to go
+// are refused rather than recorded as moved on.
+func (gc *GameCoordinator) ForceAdvance(gameId string, claim *entities.CustomClaim) error {
+	chatMessage := ""
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		chatMessage = ""
+		phase := currentRoundPhase(g)
+
+		if err := checkRules(gameId, validateWith(g).ValidateForceAdvance(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		err := recorder.Record(aggregates.EventRoundForceAdvanced, aggregates.NewGameEventPayloadRoundForceAdvanced(gameId, claim.UserID, g.RoundStatus))
+
+		if err != nil {
+			return err
+		}
+
+		if err := gc.advanceRound(g, recorder, fmt.Sprintf("%s moved the round on.", claim.Name), &chatMessage); err != nil {
+			return err
+		}
+
+		if currentRoundPhase(g) == phase {
+			return validation.NewValidationError(validation.ErrCodeWrongRoundStatus, fmt.Sprintf("the round cannot be moved on while round status is %s", g.RoundStatus), "")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if chatMessage == "" {
+		chatMessage = fmt.Sprintf("%s moved the round on.", claim.Name)
+	}
+
+	gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), chatMessage)
+
+	return nil
+}
+
+// advanceRound moves the round on to its next status without waiting for
+// anyone. Idle players have random cards played for them, an idle judge has
+// a submission picked for them, an unfinished vote is counted as it stands and
+// a finished round is continued. A round that cannot be finished, because
+// there are not enough white cards to play or nothing to pick from, starts
+// over. The chat message says why.
+func (gc *GameCoordinator) advanceRound(g *aggregates.Game, recorder *eventRecorder, reason string, chatMessage *string) error {
+	switch g.RoundStatus {
+	case valueobjects.PlayersPickingCard:
+		*chatMessage = fmt.Sprintf("%s Random cards were played for anyone still picking.", reason)
+
+		for _, player := range g.GetNonJudgePlayers() {
+			if player.HasAlreadyPlayedWhiteCard() {
+				continue
+			}
+
+			if _, err := gc.playRandomCards(g, recorder, player); err != nil {
+				return err
+			}
+		}
+
+		if g.RoundStatus != valueobjects.PlayersPickingCard {
+			return nil
+		}
+
+		*chatMessage = fmt.Sprintf("%s There weren't enough white cards to go round, so the round starts over.", reason)
+
+		return gc.voidRound(g, recorder)
+	case valueobjects.JudgePickingWinningCard:
+		if len(g.Submissions) == 0 {
+			*chatMessage = fmt.Sprintf("%s Nothing is left on the board, so the round starts over.", reason)
+
+			return gc.voidRound(g, recorder)
+		}
+
+		*chatMessage = fmt.Sprintf("%s A winning card was picked at random.", reason)
+
+		submission := g.Submissions[rand.Intn(len(g.Submissions))]
+
+		err := recorder.Record(aggregates.EventJudgeChoseWinningCard, aggregates.NewGameEventPayloadJudgeChoseWinningCard(g.ID, submission.ID))
+
+		if err != nil {
+			return err
+		}
+
+		return gc.recordWinnerMessage(g, recorder, chatMessage)
+	case valueobjects.PlayersVoting:
+		if len(g.Submissions) == 0 {
+			*chatMessage = fmt.Sprintf("%s Nothing is left on the board, so the round starts over.", reason)
+
+			return gc.voidRound(g, recorder)
+		}
+
+		*chatMessage = fmt.Sprintf("%s The votes were counted as they stand.", reason)
+
+		err := recorder.Record(aggregates.EventVotesCounted, aggregates.NewGameEventPayloadVotesCounted(g.ID, breakTie(g.TieRule, g.GetMostVotedSubmissions())))
+
+		if err != nil {
+			return err
+		}
+
+		return gc.recordWinnerMessage(g, recorder, chatMessage)
+	case valueobjects.JudgeChoseWinningCard:
+		if err := checkRules(g.ID, validateWith(g).ValidateStartRound(g.ID)); err != nil {
+			return err
+		}
+
+		return gc.continueRound(g, recorder, "")
+	default:
+		return nil
+	}
+}
+
+// recordWinnerMessage ends the game if someone has won and swaps the chat
+// message for the winner announcement.
+func (gc *GameCoordinator) recordWinnerMessage(g *aggregates.Game, recorder *eventRecorder, chatMessage *string) error {
+	winnerMessage, err := gc.recordGameWinner(g, recorder)
+
+	if err != nil {
+		return err
+	}
+
+	if winnerMessage != "" {
+		*chatMessage = winnerMessage
+	}
+
+	return nil
+}
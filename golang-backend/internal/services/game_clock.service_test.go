@@ -0,0 +1,228 @@
+package services_test
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"testing"
+	"time"
+)
+
+// beginTimedGame begins a game of u1, u2 and u3 timed by the given rules.
+func beginTimedGame(t *testing.T, rules services.GameRules) (*testEnv, string) {
+	t.Helper()
+
+	env := newTestEnv(t, rules)
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	if err := env.coordinator.BeginGame(gameID, claim("u1"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	return env, gameID
+}
+
+// idlePlayer is a player who has to play a card this round.
+func idlePlayer(t *testing.T, g *aggregates.Game) *aggregates.Player {
+	t.Helper()
+
+	for _, player := range g.GetNonJudgePlayers() {
+		if !player.HasAlreadyPlayedWhiteCard() {
+			return player
+		}
+	}
+
+	t.Fatal("expected a player still picking")
+
+	return nil
+}
+
+// emptyHand leaves the player holding no cards at all.
+func (env *testEnv) emptyHand(t *testing.T, gameID string, userID string) {
+	t.Helper()
+
+	err := env.coordinator.Execute(gameID, func(g *aggregates.Game, recorder *services.EventRecorder) error {
+		return recorder.Record(aggregates.EventHandMulliganed, aggregates.NewGameEventPayloadRedrawHand(gameID, userID, []string{}))
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTimeUpTopsUpShortHandsBeforePlaying(t *testing.T) {
+	env, gameID := beginTimedGame(t, services.GameRules{PickingTimeout: 10 * time.Millisecond})
+	userID := idlePlayer(t, env.game(t, gameID)).UserID
+
+	env.emptyHand(t, gameID, userID)
+	time.Sleep(20 * time.Millisecond)
+
+	if err := env.coordinator.AutoProgress(gameID); err != nil {
+		t.Fatal(err)
+	}
+
+	g := env.game(t, gameID)
+	player, _ := g.FindPlayerByUserId(userID)
+
+	if g.RoundStatus != valueobjects.JudgePickingWinningCard {
+		t.Fatalf("expected the round to move on to judging, round is %s", g.RoundStatus)
+	}
+
+	if len(player.PlacedCards) != g.BlackCard.PickCount() {
+		t.Fatalf("expected %d cards played from the topped up hand, got %d", g.BlackCard.PickCount(), len(player.PlacedCards))
+	}
+
+	assertSameGame(t, g, env.replayed(t, gameID))
+}
+
+// assertArmed fails unless the game's deadline is about after from now, and is
+// the one handed to the timer store.
+func (env *testEnv) assertArmed(t *testing.T, gameID string, after time.Duration) {
+	t.Helper()
+
+	g := env.game(t, gameID)
+	fireAt, armed := env.timers.FireAt(gameID)
+
+	if !armed || !fireAt.Equal(g.NextAutoProgressAt) {
+		t.Fatalf("expected the timer to be armed for %v, got %v %v", g.NextAutoProgressAt, fireAt, armed)
+	}
+
+	if until := time.Until(g.NextAutoProgressAt); until > after || until < after-time.Minute {
+		t.Fatalf("expected the %s deadline in about %s, it is in %s", g.RoundStatus, after, until)
+	}
+}
+
+func TestDeadlineIsArmedForEachPhase(t *testing.T) {
+	env, gameID := beginTimedGame(t, services.GameRules{PickingTimeout: time.Hour, JudgingTimeout: 2 * time.Hour, RoundPauseTimeout: 3 * time.Hour})
+
+	env.assertArmed(t, gameID, time.Hour)
+
+	env.playAll(t, gameID)
+	env.assertArmed(t, gameID, 2*time.Hour)
+
+	g := env.game(t, gameID)
+
+	if err := env.coordinator.PickWinningCard(gameID, claim(judgeOf(g)), g.Submissions[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	env.assertArmed(t, gameID, 3*time.Hour)
+}
+
+func TestUntimedRoundsArmNothing(t *testing.T) {
+	env, gameID := beginTimedGame(t, services.GameRules{})
+
+	if _, armed := env.timers.FireAt(gameID); armed {
+		t.Fatal("expected no timer for an untimed round")
+	}
+
+	if g := env.game(t, gameID); !g.NextAutoProgressAt.IsZero() {
+		t.Fatalf("expected no deadline for an untimed round, got %v", g.NextAutoProgressAt)
+	}
+}
+
+func TestDeadlineDoesNothingBeforeItIsDue(t *testing.T) {
+	env, gameID := beginTimedGame(t, services.GameRules{PickingTimeout: time.Hour})
+	before := env.game(t, gameID)
+
+	if err := env.coordinator.AutoProgress(gameID); err != nil {
+		t.Fatal(err)
+	}
+
+	if after := env.game(t, gameID); after.Version != before.Version {
+		t.Fatalf("deadline that was not due moved the game to version %d", after.Version)
+	}
+}
+
+func TestPickingTimeUpPlaysForIdlePlayers(t *testing.T) {
+	env, gameID := beginTimedGame(t, services.GameRules{PickingTimeout: 10 * time.Millisecond, JudgingTimeout: time.Hour})
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := env.coordinator.AutoProgress(gameID); err != nil {
+		t.Fatal(err)
+	}
+
+	if g := env.game(t, gameID); g.RoundStatus != valueobjects.JudgePickingWinningCard || len(g.Submissions) != 2 {
+		t.Fatalf("expected both players to have played, round is %s with %d submissions", g.RoundStatus, len(g.Submissions))
+	}
+
+	env.assertArmed(t, gameID, time.Hour)
+}
+
+func TestJudgingTimeUpPicksAWinner(t *testing.T) {
+	env, gameID := beginTimedGame(t, services.GameRules{JudgingTimeout: 10 * time.Millisecond})
+
+	env.playAll(t, gameID)
+	time.Sleep(20 * time.Millisecond)
+
+	if err := env.coordinator.AutoProgress(gameID); err != nil {
+		t.Fatal(err)
+	}
+
+	g := env.game(t, gameID)
+
+	if g.RoundStatus != valueobjects.JudgeChoseWinningCard || g.RoundWinner == nil {
+		t.Fatalf("expected a winner to be picked, round is %s", g.RoundStatus)
+	}
+
+	assertSameGame(t, g, env.replayed(t, gameID))
+}
+
+func TestForceAdvanceMovesRoundOn(t *testing.T) {
+	env, gameID := beginTimedGame(t, services.GameRules{PickingTimeout: time.Hour})
+
+	if err := env.coordinator.ForceAdvance(gameID, claim("u1")); err != nil {
+		t.Fatalf("could not move the round on: %v", err)
+	}
+
+	if g := env.game(t, gameID); g.RoundStatus != valueobjects.JudgePickingWinningCard || len(g.Submissions) != 2 {
+		t.Fatalf("expected cards played for both players, round is %s with %d submissions", g.RoundStatus, len(g.Submissions))
+	}
+}
+
+func TestForceAdvanceRefusedWhilePaused(t *testing.T) {
+	env, gameID := beginTimedGame(t, services.GameRules{PickingTimeout: time.Hour})
+
+	if err := env.coordinator.PauseGame(gameID, claim("u1")); err != nil {
+		t.Fatal(err)
+	}
+
+	before := env.game(t, gameID)
+
+	assertCode(t, env.coordinator.ForceAdvance(gameID, claim("u1")), validation.ErrCodeGamePaused)
+
+	if after := env.game(t, gameID); after.Version != before.Version {
+		t.Fatalf("refused force advance moved the game to version %d", after.Version)
+	}
+}
+
+func TestResumeGamePublishesShiftedClock(t *testing.T) {
+	env, gameID := beginTimedGame(t, services.GameRules{PickingTimeout: time.Hour})
+
+	if err := env.coordinator.PauseGame(gameID, claim("u1")); err != nil {
+		t.Fatal(err)
+	}
+
+	paused := env.game(t, gameID).NextAutoProgressAt
+	clockUpdates := env.publisher.count(string(aggregates.ClockUpdate))
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := env.coordinator.ResumeGame(gameID, claim("u1")); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed := env.game(t, gameID).NextAutoProgressAt
+
+	if !resumed.After(paused) {
+		t.Fatalf("expected the deadline to move on from %v, got %v", paused, resumed)
+	}
+
+	if env.publisher.count(string(aggregates.ClockUpdate)) != clockUpdates+1 {
+		t.Fatal("expected the shifted deadline to be published")
+	}
+
+	env.assertArmed(t, gameID, time.Hour)
+}
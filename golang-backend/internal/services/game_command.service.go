@@ -0,0 +1,110 @@
+package services
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/validation"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// maxCommandAttempts bounds how many times a command is re-run after losing
+// an optimistic concurrency race on the event stream.
+const maxCommandAttempts = 3
+
+// gameCommand decides which events should happen to a game and records them.
+// It may be run more than once, so it must not have side effects beyond
+// recording events.
+type gameCommand func(g *aggregates.Game, recorder *eventRecorder) error
+
+// eventRecorder applies events to a game as they are recorded and keeps them
+// so they can be appended to the event log in one go.
+type eventRecorder struct {
+	game   *aggregates.Game
+	events []*aggregates.GameEvent
+}
+
+func newEventRecorder(game *aggregates.Game) *eventRecorder {
+	return &eventRecorder{
+		game:   game,
+		events: []*aggregates.GameEvent{},
+	}
+}
+
+// Record creates the next event in the game's stream and applies it.
+func (r *eventRecorder) Record(eventType aggregates.GameEventType, payload any) error {
+	eventPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+	}
+
+	event := aggregates.NewGameEvent(
+		r.game.ID,
+		eventType,
+		eventPayload,
+	)
+
+	event.Version = r.game.Version + 1
+
+	err = r.game.ApplyEvent(event)
+
+	if err != nil {
+		return fmt.Errorf("failed to apply %s event: %w", eventType, err)
+	}
+
+	r.events = append(r.events, event)
+
+	return nil
+}
+
+// hasRecorded reports whether an event of the given type was recorded.
+func (r *eventRecorder) hasRecorded(eventType aggregates.GameEventType) bool {
+	for _, event := range r.events {
+		if event.Type == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// execute hands a command to the actor that owns the game and waits for it
+// to be applied, persisted and published.
+func (gc *GameCoordinator) execute(gameId string, command gameCommand) error {
+	actor := gc.getActor(gameId)
+
+	if actor == nil {
+		return fmt.Errorf("failed to get game")
+	}
+
+	return actor.submit(command)
+}
+
+// inspect runs a read-only function against the game on its actor. It must
+// not change the game.
+func (gc *GameCoordinator) inspect(gameId string, inspect func(g *aggregates.Game) error) error {
+	actor := gc.getActor(gameId)
+
+	if actor == nil {
+		return fmt.Errorf("failed to get game")
+	}
+
+	return actor.read(inspect)
+}
+
+// validateWith returns the rules validator for a game. Commands call it with
+// the game they were handed so checks always see the actor's current state.
+func validateWith(g *aggregates.Game) validation.GameValidator {
+	return validation.NewGameRulesValidator(g)
+}
+
+// checkRules logs any warnings in a validation result and returns its first
+// error, if there is one.
+func checkRules(gameId string, result validation.ValidationResult) error {
+	for _, warning := range result.Warnings {
+		log.Printf("Validation warning for game %s: %s: %s", gameId, warning.Code, warning.Message)
+	}
+
+	return result.Err()
+}
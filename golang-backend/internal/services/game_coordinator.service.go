@@ -4,11 +4,16 @@ import (
 	"cardgame/internal/domain/aggregates"
 	"cardgame/internal/domain/entities"
 	"cardgame/internal/domain/events"
+	"cardgame/internal/domain/projections"
 	"cardgame/internal/domain/repositories"
 	"cardgame/internal/domain/services"
+	"cardgame/internal/domain/validation"
 	"cardgame/internal/domain/valueobjects"
 	"encoding/json"
 	"fmt"
+	"log"
+	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,305 +21,1162 @@ import (
 
 type Publisher interface {
 	PublishToRoom(roomID string, eventType string, payload any) error
+	PublishToRoomPerClient(roomID string, eventType string, build func(userID string) any) error
+	DisconnectUser(roomID string, userID string, eventType string, payload any) error
 }
 
+// DeadlineScheduler arranges for AutoProgress to be called on a game once its
+// round deadline passes. A game has at most one deadline.
+type DeadlineScheduler interface {
+	Schedule(gameID string, fireAt time.Time) error
+	Cancel(gameID string) error
+}
+
+// GameRules are the server-wide rules new games are created with.
+type GameRules struct {
+	// MinPlayerCount is how many players a game needs before it can begin.
+	MinPlayerCount int
+	// AutoStartWhenFull begins a game as soon as its last seat is taken.
+	AutoStartWhenFull bool
+	// PickingTimeout is how long players have to play their cards. Zero
+	// leaves players as long as they like, as do the other timeouts.
+	PickingTimeout time.Duration
+	// JudgingTimeout is how long the judge, or the voters, have to pick the
+	// winning card.
+	JudgingTimeout time.Duration
+	// RoundPauseTimeout is how long the result of a round is shown before the
+	// next round starts.
+	RoundPauseTimeout time.Duration
+	// DisconnectGracePeriod is how long a disconnected player keeps their
+	// seat before they are removed from the game.
+	DisconnectGracePeriod time.Duration
+}
+
+// DefaultDisconnectGracePeriod is used when no grace period is configured.
+const DefaultDisconnectGracePeriod = 30 * time.Second
+
 type GameCoordinator struct {
 	gameRepository      repositories.GameRepository
 	eventRepository     repositories.EventRepository
 	deckCreationService services.DeckCreationService
+	gameRehydrator      *GameRehydrator
 	publisher           Publisher
-	games               []*aggregates.Game
+	deadlineScheduler   DeadlineScheduler
+	rules               GameRules
+	actorsMu            sync.RWMutex
+	actors              map[string]*gameActor
 }
 
 func NewGameCoordinator(
 	gameRepository repositories.GameRepository,
 	eventRepository repositories.EventRepository,
 	deckCreationService services.DeckCreationService,
+	gameRehydrator *GameRehydrator,
 	publisher Publisher,
+	deadlineScheduler DeadlineScheduler,
+	rules GameRules,
 	games []*aggregates.Game,
 ) *GameCoordinator {
-	return &GameCoordinator{
+	// A round needs a judge and at least one other player
+	if rules.MinPlayerCount < 2 {
+		rules.MinPlayerCount = aggregates.DefaultMinPlayerCount
+	}
+
+	if rules.DisconnectGracePeriod <= 0 {
+		rules.DisconnectGracePeriod = DefaultDisconnectGracePeriod
+	}
+
+	gc := &GameCoordinator{
 		gameRepository:      gameRepository,
 		eventRepository:     eventRepository,
 		deckCreationService: deckCreationService,
+		gameRehydrator:      gameRehydrator,
 		publisher:           publisher,
-		games:               games,
+		deadlineScheduler:   deadlineScheduler,
+		rules:               rules,
+		actors:              map[string]*gameActor{},
+	}
+
+	for _, game := range games {
+		gc.armClock(game)
+		gc.startActor(game)
 	}
+
+	return gc
+}
+
+func (gc *GameCoordinator) getActor(gameId string) *gameActor {
+	gc.actorsMu.RLock()
+	defer gc.actorsMu.RUnlock()
+
+	return gc.actors[gameId]
+}
+
+// startActor hands ownership of the game to a new actor goroutine. The game
+// must not be touched outside of that actor afterwards.
+func (gc *GameCoordinator) startActor(game *aggregates.Game) {
+	actor := newGameActor(gc, game)
+
+	gc.actorsMu.Lock()
+	gc.actors[game.ID] = actor
+	gc.actorsMu.Unlock()
+
+	go actor.run()
+}
+
+// publishGameUpdate sends every client in the game's room its own projection
+// of the game, so hands and card owners never leave the server.
+func (gc *GameCoordinator) publishGameUpdate(game *aggregates.Game) error {
+	return gc.publisher.PublishToRoomPerClient(game.ID, string(aggregates.GameUpdate), func(userID string) any {
+		return projections.NewGameView(game, userID)
+	})
 }
 
-func (gc *GameCoordinator) getGameByID(gameId string) *aggregates.Game {
-	for _, game := range gc.games {
-		if game.ID == gameId {
-			return game
+func (gc *GameCoordinator) Create(name string, deckSubject string, winnerCount int, maxPlayerCount int, handSize int, mode string, tieRule string, houseRules valueobjects.HouseRules, visibility string, password string, claim *entities.CustomClaim) (*projections.GameView, error) {
+	if maxPlayerCount < gc.rules.MinPlayerCount {
+		return nil, validation.NewValidationError(
+			validation.ErrCodeInvalidPlayerCount,
+			fmt.Sprintf("max player count must be at least %d", gc.rules.MinPlayerCount),
+			"max_player_count",
+		)
+	}
+
+	// A hand size of zero deals the default hand
+	if handSize != 0 && (handSize < aggregates.MinHandSize || handSize > aggregates.MaxHandSize) {
+		return nil, validation.NewValidationError(
+			validation.ErrCodeInvalidHandSize,
+			fmt.Sprintf("hand size must be between %d and %d", aggregates.MinHandSize, aggregates.MaxHandSize),
+			"hand_size",
+		)
+	}
+
+	gameMode, err := valueobjects.NewGameMode(mode)
+
+	if err != nil {
+		return nil, validation.NewValidationError(validation.ErrCodeInvalidGameMode, err.Error(), "mode")
+	}
+
+	gameTieRule, err := valueobjects.NewTieRule(tieRule)
+
+	if err != nil {
+		return nil, validation.NewValidationError(validation.ErrCodeInvalidTieRule, err.Error(), "tie_rule")
+	}
+
+	gameVisibility, err := valueobjects.NewVisibility(visibility)
+
+	if err != nil {
+		return nil, validation.NewValidationError(validation.ErrCodeInvalidVisibility, err.Error(), "visibility")
+	}
+
+	if password != "" && !gameVisibility.IsPrivate() {
+		return nil, validation.NewValidationError(validation.ErrCodeInvalidVisibility, "only private games can have a password", "password")
+	}
+
+	inviteCode := ""
+
+	if gameVisibility.IsPrivate() {
+		inviteCode, err = gc.newInviteCode()
+
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	return nil
-}
+	passwordHash, err := hashGamePassword(password)
+
+	if err != nil {
+		return nil, err
+	}
 
-func (gc *GameCoordinator) Create(name string, deckSubject string, winnerCount int, maxPlayerCount int, claim *entities.CustomClaim) (*aggregates.Game, error) {
 	collection, err := gc.deckCreationService.GenerateDeck(deckSubject)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create deck: %w", err)
 	}
 
-	player, err := aggregates.NewPlayer(claim)
+	game, err := gc.createGame(aggregates.NewGameEventPayloadGameCreated(uuid.New().String(), name, collection, winnerCount, maxPlayerCount, gc.rules.MinPlayerCount, handSize, gameMode, gameTieRule, houseRules, gameVisibility, inviteCode, passwordHash, uuid.New().String(), claim), nil)
 
-	player.SetIsOwner(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return projections.NewGameView(game, claim.UserID), nil
+}
+
+// createGame stores a new game along with any events seat records straight
+// after it is created, then hands it to a new actor. seat may be nil.
+func (gc *GameCoordinator) createGame(created aggregates.GameEventPayloadGameCreated, seat gameCommand) (*aggregates.Game, error) {
+	payload, err := json.Marshal(created)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to create player: %w", err)
+		return nil, fmt.Errorf("failed to marshal game created payload: %w", err)
 	}
 
-	players := []*aggregates.Player{
-		player,
+	event := aggregates.NewGameEvent(
+		created.GameID,
+		aggregates.EventGameCreated,
+		payload,
+	)
+
+	event.Version = 1
+
+	game, err := aggregates.NewGameFromEvents([]aggregates.GameEvent{*event})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply game created event: %w", err)
 	}
 
-	gameID := uuid.New().String()
+	recorder := newEventRecorder(game)
 
-	game := aggregates.NewGame(
-		gameID,
-		name,
-		collection,
-		winnerCount,
-		maxPlayerCount,
-		valueobjects.Setup,
-		players,
-		[]*entities.Card{},
-		[]*entities.Card{},
-		nil,
-		valueobjects.Waiting,
-		0,
-		nil,
-		time.Now(),
-		time.Now(),
-		time.Now(),
-		time.Now(),
-		time.Now(),
-		time.Now(),
-	)
+	if seat != nil {
+		if err := seat(game, recorder); err != nil {
+			return nil, err
+		}
+	}
 
-	game, err = gc.gameRepository.Create(game)
+	err = gc.eventRepository.AppendEvents(game.ID, 0, append([]*aggregates.GameEvent{event}, recorder.events...))
 
-	gc.games = append(gc.games, game)
+	if err != nil {
+		return nil, fmt.Errorf("failed to append game created event: %w", err)
+	}
+
+	game, err = gc.gameRepository.Create(game)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create game: %w", err)
 	}
 
+	gc.startActor(game)
+
 	return game, nil
 }
 
-func (gc *GameCoordinator) Join(gameId string, claim *entities.CustomClaim) (*aggregates.Game, error) {
-	// validationResult := gc.gameValidator.ValidateJoinGame(game, gameId, claim.UserID)
+// Admit checks the user may see the game at all, e.g. that they are not
+// banned from it. It changes nothing, Join still has the final say.
+func (gc *GameCoordinator) Admit(gameId string, claim *entities.CustomClaim) error {
+	return gc.inspect(gameId, func(g *aggregates.Game) error {
+		return checkRules(gameId, validateWith(g).ValidateWatchGame(gameId, claim.UserID))
+	})
+}
+
+// Join adds the user to the game, or re-sends the current state when they are
+// already in it. Users who join a full or running game watch it instead.
+func (gc *GameCoordinator) Join(gameId string, claim *entities.CustomClaim) error {
+	joined := false
+	autoStarted := false
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		joined = false
+		autoStarted = false
+
+		if err := checkRules(gameId, validateWith(g).ValidateWatchGame(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		player, err := g.FindPlayerByUserId(claim.UserID)
+
+		if err == nil && player != nil {
+			if player.IsDisconnected {
+				return recorder.Record(aggregates.EventPlayerReconnected, aggregates.NewGameEventPayloadPlayerConnection(gameId, claim.UserID))
+			}
+
+			return nil
+		}
+
+		_, err = g.FindSpectator(claim.UserID)
+		isSpectator := err == nil
+
+		// Full and running games can only be watched
+		if !g.IsInSetup() || g.IsFull() {
+			if isSpectator {
+				return nil
+			}
+
+			joined = true
 
-	// if !validationResult.IsValid {
-	// 	return nil, fmt.Errorf("failed to validate join game: %w", validationResult.Errors)
-	// }
+			return recorder.Record(aggregates.EventSpectatorJoined, aggregates.NewGameEventPayloadJoinedGame(g.ID, claim.UserID, uuid.New().String(), claim))
+		}
+
+		if err := checkRules(gameId, validateWith(g).ValidateJoinGame(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		joined = true
+
+		if isSpectator {
+			err = recorder.Record(aggregates.EventSpectatorSeated, aggregates.NewGameEventPayloadPlayerModerated(g.ID, claim.UserID))
+		} else {
+			err = recorder.Record(aggregates.EventJoinedGame, aggregates.NewGameEventPayloadJoinedGame(g.ID, claim.UserID, uuid.New().String(), claim))
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !gc.rules.AutoStartWhenFull || !g.IsFull() {
+			return nil
+		}
+
+		owner := g.FindOwner()
+
+		// A full lobby begins whether or not everyone said they are ready
+		if owner == nil || validateWith(g).ValidateBeginGame(gameId, owner.UserID, true).Err() != nil {
+			return nil
+		}
 
-	game := gc.getGameByID(gameId)
+		autoStarted = true
 
-	if game == nil {
-		return nil, fmt.Errorf("failed to get game")
+		return gc.begin(g, recorder, owner)
+	})
+
+	if err != nil {
+		return err
 	}
 
-	player, err := game.FindPlayerByUserId(claim.UserID)
+	if autoStarted {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), "The lobby is full. Game has begun.")
+	}
 
-	if err == nil && player != nil {
-		gc.publisher.PublishToRoom(game.ID, string(aggregates.GameUpdate), game)
-		return game, nil
+	// A returning player still needs the current state on their new connection
+	if !joined {
+		return gc.inspect(gameId, gc.publishGameUpdate)
 	}
 
-	eventPayload, err := json.Marshal(aggregates.NewGameEventPayloadJoinedGame(game.ID, claim.UserID, claim))
+	return nil
+}
+
+// Watch has the user watch the game as a spectator, giving up their seat if
+// they have one.
+func (gc *GameCoordinator) Watch(gameId string, claim *entities.CustomClaim) error {
+	chatMessage := ""
+	leftSeat := false
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		chatMessage = ""
+		leftSeat = false
+
+		if err := checkRules(gameId, validateWith(g).ValidateWatchGame(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		if _, err := g.FindSpectator(claim.UserID); err == nil {
+			return nil
+		}
+
+		if _, err := g.FindPlayerByUserId(claim.UserID); err == nil {
+			leftSeat = true
+
+			if err := gc.removePlayer(g, recorder, aggregates.EventPlayerLeft, claim.UserID, &chatMessage); err != nil {
+				return err
+			}
+		}
+
+		return recorder.Record(aggregates.EventSpectatorJoined, aggregates.NewGameEventPayloadJoinedGame(g.ID, claim.UserID, uuid.New().String(), claim))
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal game begins payload: %w", err)
+		return err
 	}
 
-	event := aggregates.NewGameEvent(
-		game.ID,
-		aggregates.EventJoinedGame,
-		eventPayload,
-	)
+	if leftSeat {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s gave up their seat to watch.", claim.Name))
+	}
+
+	if chatMessage != "" {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), chatMessage)
+	}
+
+	return nil
+}
+
+// PromoteSpectator gives a spectator the next free seat. They sit down
+// straight away before the game begins or between rounds, and otherwise once
+// the current round is over.
+func (gc *GameCoordinator) PromoteSpectator(gameId string, claim *entities.CustomClaim, userId string) error {
+	return gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidatePromoteSpectator(gameId, claim.UserID, userId)); err != nil {
+			return err
+		}
+
+		err := recorder.Record(aggregates.EventSpectatorPromoted, aggregates.NewGameEventPayloadPlayerModerated(gameId, userId))
+
+		if err != nil {
+			return err
+		}
+
+		if !g.IsAtRoundBoundary() {
+			return nil
+		}
+
+		return gc.seatPromotedSpectators(g, recorder)
+	})
+}
+
+// seatPromotedSpectators moves promoted spectators into the seats they were
+// promised. They are dealt in with everyone else at the start of the next
+// round.
+func (gc *GameCoordinator) seatPromotedSpectators(g *aggregates.Game, recorder *eventRecorder) error {
+	for _, spectator := range g.GetPromotedSpectators() {
+		if g.IsFull() {
+			return nil
+		}
+
+		err := recorder.Record(aggregates.EventSpectatorSeated, aggregates.NewGameEventPayloadPlayerModerated(g.ID, spectator.UserID))
+
+		if err != nil {
+			return err
+		}
+	}
 
-	err = game.ApplyEvent(event)
+	return nil
+}
+
+// BeginGame has the owner begin the game once every player is ready, or
+// straight away when forced.
+func (gc *GameCoordinator) BeginGame(gameId string, claim *entities.CustomClaim, force bool) error {
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidateBeginGame(gameId, claim.UserID, force)); err != nil {
+			return err
+		}
+
+		player, err := g.FindPlayerByUserId(claim.UserID)
+
+		if err != nil {
+			return fmt.Errorf("failed to find player: %w", err)
+		}
+
+		return gc.begin(g, recorder, player)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to apply joined game event: %w", err)
+		return err
 	}
 
-	gc.publisher.PublishToRoom(game.ID, string(aggregates.GameUpdate), game)
+	gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), "Game has begun.")
 
-	err = gc.eventRepository.AppendEvent(event)
+	return nil
+}
+
+// begin records the events that start the first round. Callers must have
+// checked that the game may begin.
+func (gc *GameCoordinator) begin(g *aggregates.Game, recorder *eventRecorder, startedBy *aggregates.Player) error {
+	if _, err := g.FindPlayerByUserId(aggregates.RandoUserID); g.HouseRules.RandoCardrissian && err != nil {
+		err := recorder.Record(aggregates.EventBotJoined, aggregates.NewGameEventPayloadBotJoined(g.ID, aggregates.RandoUserID, uuid.New().String(), aggregates.RandoName))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err := recorder.Record(aggregates.EventGameBegins, events.NewGameEventPayloadGameBegins(g.ID, startedBy.ID))
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to append joined game event: %w", err)
+		return err
 	}
 
-	_, err = gc.gameRepository.Update(game)
+	err = recorder.Record(aggregates.EventShuffle, aggregates.NewGameEventPayloadShuffle(g.ID, time.Now().UnixNano(), uuid.New().String()))
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to persist game update: %w", err)
+		return err
 	}
 
-	return game, nil
-}
+	if !g.IsDemocracy() {
+		err = recorder.Record(aggregates.EventSetJudge, aggregates.NewGameEventPayloadSetJudge(g.ID, g.Players[0].UserID))
 
-func (gc *GameCoordinator) BeginGame(gameId string, claim *entities.CustomClaim) error {
-	g := gc.getGameByID(gameId)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = gc.fillHands(g, recorder)
 
-	if g == nil {
-		return fmt.Errorf("failed to get game")
+	if err != nil {
+		return err
 	}
 
-	if !g.IsInSetup() {
-		return fmt.Errorf("game %s is not in setup status", gameId)
+	unusedBlackCards := g.GetUnplayedBlackCards()
+
+	if len(unusedBlackCards) == 0 {
+		return fmt.Errorf("game %s has no black cards to draw", g.ID)
 	}
 
-	player, err := g.FindPlayerByUserId(claim.UserID)
+	err = recorder.Record(aggregates.EventDrawBlackCard, aggregates.NewGameEventPayloadDrawBlackCard(g.ID, unusedBlackCards[0].ID))
 
 	if err != nil {
-		return fmt.Errorf("failed to find player: %w", err)
+		return err
 	}
 
-	if !player.IsOwner {
-		return fmt.Errorf("player %s is not the owner of the game", player.ID)
+	return gc.playBots(g, recorder)
+}
+
+// PlayCard submits the player's white cards for the round, in the order they
+// fill the black card's blanks.
+func (gc *GameCoordinator) PlayCard(gameId string, claim *entities.CustomClaim, cardIds []string) error {
+	return gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidatePlayCard(gameId, claim.UserID, cardIds)); err != nil {
+			return err
+		}
+
+		return recorder.Record(aggregates.EventCardPlayed, aggregates.NewGameEventPayloadPlayCard(gameId, uuid.New().String(), cardIds, claim))
+	})
+}
+
+// PickWinningCard awards the round to the player behind the chosen submission.
+func (gc *GameCoordinator) PickWinningCard(gameId string, claim *entities.CustomClaim, submissionId string) error {
+	chatMessage := ""
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		chatMessage = "judge has chosen a winning card."
+
+		if err := checkRules(gameId, validateWith(g).ValidateVote(gameId, claim.UserID, submissionId)); err != nil {
+			return err
+		}
+
+		err := recorder.Record(aggregates.EventJudgeChoseWinningCard, aggregates.NewGameEventPayloadJudgeChoseWinningCard(gameId, submissionId))
+
+		if err != nil {
+			return err
+		}
+
+		return gc.recordWinnerMessage(g, recorder, &chatMessage)
+	})
+
+	if err != nil {
+		return err
 	}
 
-	payload, err := json.Marshal(events.NewGameEventPayloadGameBegins(gameId, player.ID))
+	gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), chatMessage)
+
+	return nil
+}
+
+// Vote casts the player's vote for their favorite submission in a democracy
+// round. The last vote counts the votes and awards the round.
+func (gc *GameCoordinator) Vote(gameId string, claim *entities.CustomClaim, submissionId string) error {
+	chatMessage := ""
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		chatMessage = ""
+
+		if err := checkRules(gameId, validateWith(g).ValidateCastVote(gameId, claim.UserID, submissionId)); err != nil {
+			return err
+		}
+
+		err := recorder.Record(aggregates.EventVoteCast, aggregates.NewGameEventPayloadVoteCast(gameId, claim.UserID, submissionId))
+
+		if err != nil {
+			return err
+		}
+
+		if !g.HasAllPlayersVoted() {
+			return nil
+		}
+
+		return gc.countVotes(g, recorder, &chatMessage)
+	})
 
 	if err != nil {
-		return fmt.Errorf("failed to marshal game begins payload: %w", err)
+		return err
 	}
 
-	event := aggregates.NewGameEvent(
-		gameId,
-		aggregates.EventGameBegins,
-		payload,
-	)
+	if chatMessage != "" {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), chatMessage)
+	}
+
+	return nil
+}
 
-	err = g.ApplyEvent(event)
+// countVotes closes a democracy round once everyone has voted.
+func (gc *GameCoordinator) countVotes(g *aggregates.Game, recorder *eventRecorder, chatMessage *string) error {
+	winningSubmissionIds := breakTie(g.TieRule, g.GetMostVotedSubmissions())
+
+	err := recorder.Record(aggregates.EventVotesCounted, aggregates.NewGameEventPayloadVotesCounted(g.ID, winningSubmissionIds))
 
 	if err != nil {
-		return fmt.Errorf("failed to apply game begins event: %w", err)
+		return err
+	}
+
+	*chatMessage = "the votes are in."
+
+	if len(winningSubmissionIds) == 0 {
+		*chatMessage = "the votes are in. It's a tie, nobody scores."
+	}
+
+	return gc.recordWinnerMessage(g, recorder, chatMessage)
+}
+
+// breakTie decides which of the most voted submissions score under the
+// game's tie rule.
+func breakTie(tieRule valueobjects.TieRule, mostVoted []*entities.Submission) []string {
+	if len(mostVoted) > 1 {
+		switch tieRule {
+		case valueobjects.TieNoPoints:
+			mostVoted = nil
+		case valueobjects.TieRandom:
+			mostVoted = []*entities.Submission{mostVoted[rand.Intn(len(mostVoted))]}
+		}
+	}
+
+	submissionIds := make([]string, 0, len(mostVoted))
+
+	for _, submission := range mostVoted {
+		submissionIds = append(submissionIds, submission.ID)
+	}
+
+	return submissionIds
+}
+
+// recordGameWinner ends the game once a player has enough points and returns
+// the chat message announcing it. Nothing is recorded while nobody has won.
+func (gc *GameCoordinator) recordGameWinner(g *aggregates.Game, recorder *eventRecorder) (string, error) {
+	gameWinner := g.CheckForWinner()
+
+	if gameWinner == nil {
+		return "", nil
+	}
+
+	chatMessage := fmt.Sprintf("🎉 %s has won the game with %d points! 🎉", gameWinner.Name, gameWinner.Score)
+
+	// Losing to random cards is everyone's shame
+	shamedPlayerIDs := []string{}
+
+	if gameWinner.IsBot {
+		for _, player := range g.GetHumanPlayers() {
+			shamedPlayerIDs = append(shamedPlayerIDs, player.UserID)
+		}
+
+		chatMessage = fmt.Sprintf("🔔 %s has won the game with %d points by playing random cards. Shame on all of you. 🔔", gameWinner.Name, gameWinner.Score)
 	}
 
-	err = gc.eventRepository.AppendEvent(event)
+	err := recorder.Record(aggregates.EventGameWinner, aggregates.NewGameEventPayloadGameWinner(g.ID, gameWinner.UserID, gameWinner.Score, shamedPlayerIDs))
 
 	if err != nil {
-		return fmt.Errorf("failed to append game begins event: %w", err)
+		return "", err
 	}
 
-	return nil
+	return chatMessage, nil
 }
 
 func (gc *GameCoordinator) ContinueRound(gameId string, claim *entities.CustomClaim) error {
-	g := gc.getGameByID(gameId)
+	return gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidateContinueRound(gameId, claim.UserID)); err != nil {
+			return err
+		}
 
-	if g == nil {
-		return fmt.Errorf("failed to get game")
-	}
+		return gc.continueRound(g, recorder, claim.UserID)
+	})
+}
 
-	if !g.IsInProgress() {
-		return fmt.Errorf("game %s is not in progress status", gameId)
+// continueRound records the events that start the next round. Callers must
+// have checked that the round may continue. The user id is empty when the
+// server continued the round on its own.
+func (gc *GameCoordinator) continueRound(g *aggregates.Game, recorder *eventRecorder, userId string) error {
+	if err := gc.seatPromotedSpectators(g, recorder); err != nil {
+		return err
 	}
 
 	if g.ShouldShuffle() {
-		shufflePayload, err := json.Marshal(aggregates.NewGameEventPayloadShuffle(gameId, time.Now().UnixNano(), uuid.New().String()))
+		err := recorder.Record(aggregates.EventShuffle, aggregates.NewGameEventPayloadShuffle(g.ID, time.Now().UnixNano(), uuid.New().String()))
 
 		if err != nil {
-			return fmt.Errorf("failed to marshal shuffle payload: %w", err)
+			return err
 		}
+	}
 
-		shuffleEvent := aggregates.NewGameEvent(
-			gameId,
-			aggregates.EventShuffle,
-			shufflePayload,
-		)
+	unusedBlackCards := g.GetUnplayedBlackCards()
 
-		err = g.ApplyEvent(shuffleEvent)
+	if len(unusedBlackCards) == 0 {
+		return fmt.Errorf("game %s has no black cards to draw", g.ID)
+	}
 
-		if err != nil {
-			return fmt.Errorf("failed to create shuffle event: %w", err)
-		}
+	err := recorder.Record(aggregates.EventRoundContinued, aggregates.NewGameEventPayloadGameRoundContinuedWithCards(g.ID, userId, map[string]string{}, unusedBlackCards[0].ID))
+
+	if err != nil {
+		return err
 	}
 
-	playerCards := make(map[string]string)
+	err = gc.fillHands(g, recorder)
 
-	unusedWhiteCards := g.GetUnplayedWhiteCards()
-	unusedBlackCards := g.GetUnplayedBlackCards()
+	if err != nil {
+		return err
+	}
+
+	return gc.playBots(g, recorder)
+}
+
+// fillHands deals every player enough white cards from the top of the draw
+// pile to bring their hand up to the game's hand size. When the pile runs out
+// hands are left short rather than failing the round.
+func (gc *GameCoordinator) fillHands(g *aggregates.Game, recorder *eventRecorder) error {
+	drawPile := g.GetUnplayedWhiteCards()
 
-	// Give each player a white card
 	for _, player := range g.Players {
-		if player.IsJudge {
+		missing := g.TargetHandSize() - len(player.Deck)
+
+		if missing <= 0 || len(drawPile) == 0 {
 			continue
 		}
 
-		playerCards[player.UserID] = unusedWhiteCards[0].ID
-		unusedWhiteCards = unusedWhiteCards[1:]
+		if missing > len(drawPile) {
+			missing = len(drawPile)
+		}
+
+		cardIDs := make([]string, 0, missing)
+
+		for _, card := range drawPile[:missing] {
+			cardIDs = append(cardIDs, card.ID)
+		}
+
+		drawPile = drawPile[missing:]
+
+		err := recorder.Record(aggregates.EventDealCards, aggregates.NewGameEventPayloadDealCards(g.ID, player.UserID, cardIDs))
+
+		if err != nil {
+			return err
+		}
 	}
 
-	payload, err := json.Marshal(aggregates.NewGameEventPayloadGameRoundContinuedWithCards(gameId, claim.UserID, playerCards, unusedBlackCards[0].ID))
+	return nil
+}
+
+// RebootUniverse trades one of the player's points for a fresh hand.
+func (gc *GameCoordinator) RebootUniverse(gameId string, claim *entities.CustomClaim) error {
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidateRebootUniverse(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		return gc.redrawHand(g, recorder, aggregates.EventUniverseRebooted, claim.UserID)
+	})
 
 	if err != nil {
-		return fmt.Errorf("failed to marshal game round continued with cards payload: %w", err)
+		return err
 	}
 
-	event := aggregates.NewGameEvent(
-		gameId,
-		aggregates.EventRoundContinued,
-		payload,
-	)
+	gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s traded in a point to reboot the universe.", claim.Name))
 
-	err = g.ApplyEvent(event)
+	return nil
+}
+
+// Mulligan gives the player a fresh hand for free, once per game.
+func (gc *GameCoordinator) Mulligan(gameId string, claim *entities.CustomClaim) error {
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidateMulligan(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		return gc.redrawHand(g, recorder, aggregates.EventHandMulliganed, claim.UserID)
+	})
 
 	if err != nil {
-		return fmt.Errorf("failed to apply game round continued event: %w", err)
+		return err
 	}
 
-	err = gc.eventRepository.AppendEvent(event)
+	gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s took a mulligan.", claim.Name))
+
+	return nil
+}
+
+// redrawHand records the event that swaps the player's hand for a full new
+// one from the draw pile, shuffling first when the pile is too short.
+func (gc *GameCoordinator) redrawHand(g *aggregates.Game, recorder *eventRecorder, eventType aggregates.GameEventType, userId string) error {
+	drawPile := g.GetUnplayedWhiteCards()
+
+	if len(drawPile) < g.TargetHandSize() {
+		err := recorder.Record(aggregates.EventShuffle, aggregates.NewGameEventPayloadShuffle(g.ID, time.Now().UnixNano(), uuid.New().String()))
+
+		if err != nil {
+			return err
+		}
+
+		drawPile = g.GetUnplayedWhiteCards()
+	}
+
+	handSize := g.TargetHandSize()
+
+	if handSize > len(drawPile) {
+		handSize = len(drawPile)
+	}
+
+	cardIDs := make([]string, 0, handSize)
+
+	for _, card := range drawPile[:handSize] {
+		cardIDs = append(cardIDs, card.ID)
+	}
+
+	return recorder.Record(eventType, aggregates.NewGameEventPayloadRedrawHand(g.ID, userId, cardIDs))
+}
+
+// playBots has every bot that still owes a submission this round play random
+// white cards from its hand. Bots never hold a connection, so their moves are
+// recorded alongside whatever command started the round.
+func (gc *GameCoordinator) playBots(g *aggregates.Game, recorder *eventRecorder) error {
+	if !g.RoundStatus.CanPlayCards() {
+		return nil
+	}
+
+	for _, bot := range g.GetBotPlayers() {
+		if bot.IsJudge || bot.HasAlreadyPlayedWhiteCard() {
+			continue
+		}
+
+		// A bot left without cards holds the round up until its time runs out
+		if _, err := gc.playRandomCards(g, recorder, bot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// playRandomCards plays as many random white cards from the player's hand as
+// the black card asks for, topping up a short hand from the draw pile first.
+// It reports false, playing nothing, when not even the draw pile has enough.
+func (gc *GameCoordinator) playRandomCards(g *aggregates.Game, recorder *eventRecorder, player *aggregates.Player) (bool, error) {
+	pick := g.BlackCard.PickCount()
+
+	if missing := pick - len(player.Deck); missing > 0 {
+		if err := gc.dealCards(g, recorder, player, missing); err != nil {
+			return false, err
+		}
+	}
+
+	if len(player.Deck) < pick {
+		log.Printf("Warning: player %s has %d of the %d white cards needed in game %s", player.UserID, len(player.Deck), pick, g.ID)
+		return false, nil
+	}
+
+	cardIDs := make([]string, 0, pick)
+
+	for _, i := range rand.Perm(len(player.Deck))[:pick] {
+		cardIDs = append(cardIDs, player.Deck[i].ID)
+	}
+
+	claim := &entities.CustomClaim{
+		UserID: player.UserID,
+		Name:   player.Name,
+	}
+
+	return true, recorder.Record(aggregates.EventCardPlayed, aggregates.NewGameEventPayloadPlayCard(g.ID, uuid.New().String(), cardIDs, claim))
+}
+
+// dealCards deals the player up to count white cards from the top of the draw
+// pile, shuffling first when the pile is too short.
+func (gc *GameCoordinator) dealCards(g *aggregates.Game, recorder *eventRecorder, player *aggregates.Player, count int) error {
+	if len(g.GetUnplayedWhiteCards()) < count && g.ShouldShuffle() {
+		err := recorder.Record(aggregates.EventShuffle, aggregates.NewGameEventPayloadShuffle(g.ID, time.Now().UnixNano(), uuid.New().String()))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	drawPile := g.GetUnplayedWhiteCards()
+
+	if count > len(drawPile) {
+		count = len(drawPile)
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	cardIDs := make([]string, 0, count)
+
+	for _, card := range drawPile[:count] {
+		cardIDs = append(cardIDs, card.ID)
+	}
+
+	return recorder.Record(aggregates.EventDealCards, aggregates.NewGameEventPayloadDealCards(g.ID, player.UserID, cardIDs))
+}
+
+// ClickEmoji relays an emoji reaction to everyone in the game. Reactions are
+// not part of the game state, so nothing is recorded.
+func (gc *GameCoordinator) ClickEmoji(gameId string, claim *entities.CustomClaim, emoji string) error {
+	if emoji == "" {
+		return validation.NewValidationError(validation.ErrCodeEmptyReaction, "emoji cannot be empty", "emoji")
+	}
+
+	err := gc.inspect(gameId, func(g *aggregates.Game) error {
+		return checkRules(gameId, validateWith(g).ValidateReaction(gameId, claim.UserID))
+	})
 
 	if err != nil {
-		return fmt.Errorf("failed to append game round continued event: %w", err)
+		return err
 	}
 
-	_, err = gc.gameRepository.Update(g)
+	return gc.publisher.PublishToRoom(gameId, string(aggregates.EmojiClickedMessage), map[string]interface{}{
+		"user_id": claim.UserID,
+		"emoji":   emoji,
+		"game_id": gameId,
+	})
+}
+
+// Leave removes the player, or spectator, from the game straight away.
+func (gc *GameCoordinator) Leave(gameId string, claim *entities.CustomClaim) error {
+	chatMessage := ""
+	spectating := false
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		chatMessage = ""
+
+		spectating = false
+
+		if _, err := g.FindSpectator(claim.UserID); err == nil {
+			spectating = true
+
+			return recorder.Record(aggregates.EventSpectatorLeft, aggregates.NewGameEventPayloadPlayerLeft(gameId, claim.UserID))
+		}
+
+		if err := checkRules(gameId, validateWith(g).ValidateLeaveGame(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		return gc.removePlayer(g, recorder, aggregates.EventPlayerLeft, claim.UserID, &chatMessage)
+	})
+
+	if err != nil || spectating {
+		return err
+	}
+
+	gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s left the game.", claim.Name))
+
+	if chatMessage != "" {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), chatMessage)
+	}
+
+	return nil
+}
+
+// Disconnect marks the player as disconnected when their last connection to
+// the game closes. They keep their seat for the reconnect grace period and
+// are removed once it runs out. Spectators hold no seat, so they are removed
+// straight away.
+func (gc *GameCoordinator) Disconnect(gameId string, claim *entities.CustomClaim) error {
+	return gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if _, err := g.FindSpectator(claim.UserID); err == nil {
+			return recorder.Record(aggregates.EventSpectatorLeft, aggregates.NewGameEventPayloadPlayerLeft(gameId, claim.UserID))
+		}
+
+		player, err := g.FindPlayerByUserId(claim.UserID)
+
+		// Connections that never took a seat have nothing to give up
+		if err != nil || player.IsBot || player.IsDisconnected {
+			return nil
+		}
+
+		return recorder.Record(aggregates.EventPlayerDisconnected, aggregates.NewGameEventPayloadPlayerConnection(gameId, claim.UserID))
+	})
+}
+
+// Kick removes a player from the game at the owner's request and closes
+// their connections to it. They are free to join again.
+func (gc *GameCoordinator) Kick(gameId string, claim *entities.CustomClaim, userId string) error {
+	chatMessage := ""
+	name := ""
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		chatMessage = ""
+
+		if err := checkRules(gameId, validateWith(g).ValidateKickPlayer(gameId, claim.UserID, userId)); err != nil {
+			return err
+		}
+
+		var err error
+
+		name, err = gc.removeFromGame(g, recorder, aggregates.EventPlayerKicked, userId, &chatMessage)
+
+		return err
+	})
 
 	if err != nil {
-		return fmt.Errorf("failed to update game: %w", err)
+		return err
+	}
+
+	gc.dismiss(gameId, userId, false)
+
+	gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s was kicked from the game.", name))
+
+	if chatMessage != "" {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), chatMessage)
+	}
+
+	return nil
+}
+
+// Ban keeps a user from joining the game again, kicking them first if they
+// are still in it.
+func (gc *GameCoordinator) Ban(gameId string, claim *entities.CustomClaim, userId string) error {
+	chatMessage := ""
+	name := ""
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		chatMessage = ""
+		name = ""
+
+		if err := checkRules(gameId, validateWith(g).ValidateBanPlayer(gameId, claim.UserID, userId)); err != nil {
+			return err
+		}
+
+		err := recorder.Record(aggregates.EventPlayerBanned, aggregates.NewGameEventPayloadPlayerModerated(gameId, userId))
+
+		if err != nil {
+			return err
+		}
+
+		// Users who already left only need the ban
+		if _, err := g.FindPlayerOrSpectator(userId); err != nil {
+			return nil
+		}
+
+		name, err = gc.removeFromGame(g, recorder, aggregates.EventPlayerKicked, userId, &chatMessage)
+
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	gc.dismiss(gameId, userId, true)
+
+	if name != "" {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s was banned from the game.", name))
 	}
 
-	gc.publisher.PublishToRoom(gameId, string(aggregates.GameUpdate), g)
+	if chatMessage != "" {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), chatMessage)
+	}
 
 	return nil
 }
 
-func (gc *GameCoordinator) Leave(gameId string, claim *entities.CustomClaim) {
-	// Ensure the websocket client is removed from the room to stop broadcasts
-	// gc.broadcaster.Leave(gameId, client)
+// Mute stops a player from sending reactions to the game.
+func (gc *GameCoordinator) Mute(gameId string, claim *entities.CustomClaim, userId string) error {
+	return gc.setMuted(gameId, claim, userId, true)
+}
+
+func (gc *GameCoordinator) Unmute(gameId string, claim *entities.CustomClaim, userId string) error {
+	return gc.setMuted(gameId, claim, userId, false)
+}
+
+func (gc *GameCoordinator) setMuted(gameId string, claim *entities.CustomClaim, userId string, muted bool) error {
+	eventType := aggregates.EventPlayerUnmuted
+
+	if muted {
+		eventType = aggregates.EventPlayerMuted
+	}
+
+	return gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidateMutePlayer(gameId, claim.UserID, userId, muted)); err != nil {
+			return err
+		}
+
+		return recorder.Record(eventType, aggregates.NewGameEventPayloadPlayerModerated(gameId, userId))
+	})
+}
+
+// dismiss tells a kicked or banned user why they are being dropped and closes
+// their connections to the game.
+func (gc *GameCoordinator) dismiss(gameId string, userId string, banned bool) {
+	err := gc.publisher.DisconnectUser(gameId, userId, string(aggregates.PlayerKickedMessage), map[string]interface{}{
+		"game_id": gameId,
+		"banned":  banned,
+	})
+
+	if err != nil {
+		log.Printf("Warning: failed to disconnect user %s from game %s: %v", userId, gameId, err)
+	}
+}
+
+// removeFromGame removes a player from their seat, or a spectator from the
+// audience, and returns their name.
+func (gc *GameCoordinator) removeFromGame(g *aggregates.Game, recorder *eventRecorder, departure aggregates.GameEventType, userId string, chatMessage *string) (string, error) {
+	member, err := g.FindPlayerOrSpectator(userId)
+
+	if err != nil {
+		return "", err
+	}
+
+	if member.Role.IsSpectator() {
+		return member.Name, recorder.Record(aggregates.EventSpectatorLeft, aggregates.NewGameEventPayloadPlayerLeft(g.ID, userId))
+	}
+
+	return member.Name, gc.removePlayer(g, recorder, departure, userId, chatMessage)
+}
+
+// removePlayer records the player leaving, or being kicked, and repairs
+// whatever they leave behind. Ownership passes on, a judge's round is voided
+// and a round left with nothing on the board starts over. A vote that was only
+// waiting on the player is counted. Their hand is left to the discard pile.
+func (gc *GameCoordinator) removePlayer(g *aggregates.Game, recorder *eventRecorder, departure aggregates.GameEventType, userId string, chatMessage *string) error {
+	player, err := g.FindPlayerByUserId(userId)
+
+	if err != nil {
+		return err
+	}
+
+	wasJudge := player.IsJudge
+	wasOwner := player.IsOwner
+
+	err = recorder.Record(departure, aggregates.NewGameEventPayloadPlayerLeft(g.ID, userId))
+
+	if err != nil {
+		return err
+	}
+
+	if wasOwner {
+		if owner := g.FindNewOwner(); owner != nil {
+			err := recorder.Record(aggregates.EventOwnerChanged, aggregates.NewGameEventPayloadOwnerChanged(g.ID, owner.UserID))
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if !g.IsInProgress() || !g.RoundStatus.CanVoidRound() {
+		return nil
+	}
+
+	switch {
+	case wasJudge:
+		*chatMessage = "the judge left, so the round starts over."
+
+		return gc.voidRound(g, recorder)
+	case g.RoundStatus != valueobjects.PlayersPickingCard && len(g.Submissions) == 0:
+		*chatMessage = "nothing is left on the board, so the round starts over."
+
+		return gc.voidRound(g, recorder)
+	case g.RoundStatus.CanVote() && g.HasAllPlayersVoted():
+		return gc.countVotes(g, recorder, chatMessage)
+	default:
+		return nil
+	}
+}
+
+// voidRound restarts the current round with a new black card.
+func (gc *GameCoordinator) voidRound(g *aggregates.Game, recorder *eventRecorder) error {
+	if g.ShouldShuffle() {
+		err := recorder.Record(aggregates.EventShuffle, aggregates.NewGameEventPayloadShuffle(g.ID, time.Now().UnixNano(), uuid.New().String()))
 
-	// Close the client to stop any pending operations
-	// client.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	unusedBlackCards := g.GetUnplayedBlackCards()
 
-	// TODO: Implement game state updates when player leaves
-	// game, err := gc.gameRepository.GetByID(gameId)
-	// if err != nil {
-	// 	return fmt.Errorf("failed to get game: %w", err)
-	// }
+	if len(unusedBlackCards) == 0 {
+		return fmt.Errorf("game %s has no black cards to draw", g.ID)
+	}
 
-	// player, err := game.FindPlayerByUserId(claim.UserID)
-	// if err != nil {
-	// 	return fmt.Errorf("failed to find player: %w", err)
-	// }
+	err := recorder.Record(aggregates.EventRoundVoided, aggregates.NewGameEventPayloadRoundVoided(g.ID, unusedBlackCards[0].ID))
 
-	// err = game.RemovePlayer(player)
-	// if err != nil {
-	// 	return fmt.Errorf("failed to remove player: %w", err)
-	// }
+	if err != nil {
+		return err
+	}
 
-	// _, err = gc.gameRepository.Update(game)
-	// if err != nil {
-	// 	return fmt.Errorf("failed to update game: %w", err)
-	// }
+	// Bots got their cards back along with everyone else
+	return gc.playBots(g, recorder)
 }
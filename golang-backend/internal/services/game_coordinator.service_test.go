@@ -0,0 +1,753 @@
+package services_test
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"slices"
+	"testing"
+	"time"
+)
+
+// beginGame begins a game of u1, u2 and u3 with the given house rules. They
+// are dealt five cards each and u1 judges the first round.
+func beginGame(t *testing.T, houseRules valueobjects.HouseRules) (*testEnv, string) {
+	t.Helper()
+
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, houseRules, "u2", "u3")
+
+	if err := env.coordinator.BeginGame(gameID, claim("u1"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	return env, gameID
+}
+
+// pickFirst has the judge pick the first submission on the board and returns
+// the user who played it.
+func (env *testEnv) pickFirst(t *testing.T, gameID string) string {
+	t.Helper()
+
+	g := env.game(t, gameID)
+	submission := g.Submissions[0]
+
+	if err := env.coordinator.PickWinningCard(gameID, claim(judgeOf(g)), submission.ID); err != nil {
+		t.Fatalf("%s could not pick a winner: %v", judgeOf(g), err)
+	}
+
+	return submission.UserID
+}
+
+// eventCount is how many events the game has recorded.
+func (env *testEnv) eventCount(t *testing.T, gameID string) int {
+	t.Helper()
+
+	events, err := env.events.GetEventsForGame(gameID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return len(events)
+}
+
+func TestRoundPlaysThroughToTheNextRound(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{})
+
+	env.playAll(t, gameID)
+
+	if status := env.game(t, gameID).RoundStatus; status != valueobjects.JudgePickingWinningCard {
+		t.Fatalf("expected the judge to pick once everyone played, round is %s", status)
+	}
+
+	round := env.game(t, gameID).CurrentGameRound
+	winnerID := env.pickFirst(t, gameID)
+	g := env.game(t, gameID)
+
+	if g.RoundStatus != valueobjects.JudgeChoseWinningCard {
+		t.Fatalf("expected the round to be over, round is %s", g.RoundStatus)
+	}
+
+	if winner, _ := g.FindPlayerByUserId(winnerID); winner.Score != 1 {
+		t.Fatalf("expected %s to win the round, scored %d", winnerID, winner.Score)
+	}
+
+	if err := env.coordinator.ContinueRound(gameID, claim("u2")); err != nil {
+		t.Fatal(err)
+	}
+
+	g = env.game(t, gameID)
+
+	if g.RoundStatus != valueobjects.PlayersPickingCard || g.CurrentGameRound != round+1 {
+		t.Fatalf("expected the next round to start, got round %d in %s", g.CurrentGameRound, g.RoundStatus)
+	}
+
+	if judgeOf(g) == "u1" {
+		t.Fatal("expected the judge to move on")
+	}
+
+	assertSameGame(t, g, env.replayed(t, gameID))
+}
+
+func TestRejectedCommandsRecordNothing(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{})
+	env.drawBlackCard(t, gameID, "b1")
+	before := env.eventCount(t, gameID)
+
+	assertCode(t, env.coordinator.PlayCard(gameID, claim("u1"), []string{"w0"}), validation.ErrCodeJudgeCannotPlay)
+	assertCode(t, env.coordinator.PlayCard(gameID, claim("u2"), []string{"missing"}), validation.ErrCodeCardNotInHand)
+	assertCode(t, env.coordinator.PickWinningCard(gameID, claim("u1"), "missing"), validation.ErrCodeWrongRoundStatus)
+	assertCode(t, env.coordinator.ContinueRound(gameID, claim("u1")), validation.ErrCodeWrongRoundStatus)
+
+	if after := env.eventCount(t, gameID); after != before {
+		t.Fatalf("rejected commands recorded %d events", after-before)
+	}
+}
+
+func TestClickEmojiRelaysReactions(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{})
+
+	assertCode(t, env.coordinator.ClickEmoji(gameID, claim("u2"), ""), validation.ErrCodeEmptyReaction)
+	assertCode(t, env.coordinator.ClickEmoji(gameID, claim("u9"), "🔥"), validation.ErrCodePlayerNotInGame)
+
+	if err := env.coordinator.ClickEmoji(gameID, claim("u2"), "🔥"); err != nil {
+		t.Fatal(err)
+	}
+
+	if sent := env.publisher.count(string(aggregates.EmojiClickedMessage)); sent != 1 {
+		t.Fatalf("expected one reaction to be sent, got %d", sent)
+	}
+}
+
+func TestCreateRefusesFewerSeatsThanTheGameNeeds(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{MinPlayerCount: 4})
+
+	_, err := env.coordinator.Create("test", "subject", 5, 3, 5, "", "", valueobjects.HouseRules{}, "", "", claim("u1"))
+
+	assertCode(t, err, validation.ErrCodeInvalidPlayerCount)
+}
+
+func TestCreateRefusesANegativeHandSize(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+
+	_, err := env.coordinator.Create("test", "subject", 5, 6, -1, "", "", valueobjects.HouseRules{}, "", "", claim("u1"))
+
+	assertCode(t, err, validation.ErrCodeInvalidHandSize)
+}
+
+func TestBeginGameNeedsEnoughPlayers(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2")
+
+	assertCode(t, env.coordinator.BeginGame(gameID, claim("u1"), true), validation.ErrCodeNotEnoughPlayers)
+
+	if err := env.coordinator.Join(gameID, claim("u3")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := env.coordinator.BeginGame(gameID, claim("u1"), true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJoiningAFullGameWatchesIt(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 3, valueobjects.HouseRules{}, "u2", "u3")
+
+	if err := env.coordinator.Join(gameID, claim("u4")); err != nil {
+		t.Fatal(err)
+	}
+
+	g := env.game(t, gameID)
+
+	if len(g.Players) != 3 {
+		t.Fatalf("expected the game to stay at 3 players, got %d", len(g.Players))
+	}
+
+	if _, err := g.FindSpectator("u4"); err != nil {
+		t.Fatal("expected u4 to watch the full game")
+	}
+}
+
+func TestFullLobbyBeginsWhenAutoStartWhenFull(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{AutoStartWhenFull: true})
+	gameID := env.createGame(t, 3, valueobjects.HouseRules{}, "u2")
+
+	if !env.game(t, gameID).IsInSetup() {
+		t.Fatal("expected the game to wait for its last seat")
+	}
+
+	if err := env.coordinator.Join(gameID, claim("u3")); err != nil {
+		t.Fatal(err)
+	}
+
+	if g := env.game(t, gameID); !g.IsInProgress() {
+		t.Fatalf("expected the full game to begin, game is %s", g.Status)
+	}
+}
+
+func assertHandSizes(t *testing.T, g *aggregates.Game, handSize int) {
+	t.Helper()
+
+	for _, player := range g.Players {
+		if len(player.Deck) != handSize {
+			t.Fatalf("expected %s to hold %d cards, got %d", player.UserID, handSize, len(player.Deck))
+		}
+	}
+}
+
+func TestBeginGameDealsFullHands(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+
+	view, err := env.coordinator.Create("test", "subject", 5, 6, 7, "", "", valueobjects.HouseRules{}, "", "", claim("u1"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, userID := range []string{"u2", "u3"} {
+		if err := env.coordinator.Join(view.ID, claim(userID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := env.coordinator.BeginGame(view.ID, claim("u1"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	g := env.game(t, view.ID)
+	assertHandSizes(t, g, 7)
+
+	// Nobody is dealt a card someone else holds
+	dealt := map[string]string{}
+
+	for _, player := range g.Players {
+		for _, card := range player.Deck {
+			if holder, ok := dealt[card.ID]; ok {
+				t.Fatalf("card %s was dealt to both %s and %s", card.ID, holder, player.UserID)
+			}
+
+			dealt[card.ID] = player.UserID
+		}
+	}
+}
+
+func TestHandsAreToppedUpEveryRound(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{})
+
+	env.playAll(t, gameID)
+	env.pickFirst(t, gameID)
+
+	if err := env.coordinator.ContinueRound(gameID, claim("u1")); err != nil {
+		t.Fatal(err)
+	}
+
+	assertHandSizes(t, env.game(t, gameID), 5)
+}
+
+// drawBlackCard replaces the black card of the round being played.
+func (env *testEnv) drawBlackCard(t *testing.T, gameID string, cardID string) {
+	t.Helper()
+
+	err := env.coordinator.Execute(gameID, func(g *aggregates.Game, recorder *services.EventRecorder) error {
+		return recorder.Record(aggregates.EventDrawBlackCard, aggregates.NewGameEventPayloadDrawBlackCard(gameID, cardID))
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPickTwoCardsArePlayedInOrder(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{})
+
+	// Every third black card in the test deck asks for two white cards
+	env.drawBlackCard(t, gameID, "b0")
+
+	hand := cardIDsOf(env.game(t, gameID).Players[1].Deck)
+
+	assertCode(t, env.coordinator.PlayCard(gameID, claim("u2"), hand[:1]), validation.ErrCodeWrongCardCount)
+	assertCode(t, env.coordinator.PlayCard(gameID, claim("u2"), []string{hand[0], hand[0]}), validation.ErrCodeDuplicateCard)
+
+	played := []string{hand[3], hand[1]}
+
+	if err := env.coordinator.PlayCard(gameID, claim("u2"), played); err != nil {
+		t.Fatal(err)
+	}
+
+	g := env.game(t, gameID)
+	submission := g.Submissions[0]
+
+	if got := cardIDsOf(submission.Cards); len(got) != 2 || got[0] != played[0] || got[1] != played[1] {
+		t.Fatalf("expected the cards to fill the blanks in the order %v, got %v", played, got)
+	}
+
+	if len(g.Players[1].Deck) != 3 {
+		t.Fatalf("expected both cards to leave the hand, %d are left", len(g.Players[1].Deck))
+	}
+}
+
+func TestRandoCardrissianPlaysEveryRound(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{RandoCardrissian: true})
+	g := env.game(t, gameID)
+
+	rando, err := g.FindPlayerByUserId(aggregates.RandoUserID)
+
+	if err != nil || !rando.IsBot {
+		t.Fatal("expected Rando to join when the game began")
+	}
+
+	if !rando.HasAlreadyPlayedWhiteCard() {
+		t.Fatal("expected Rando to play as soon as the round started")
+	}
+
+	// Rando doesn't take a seat from the humans
+	if g.IsFull() || len(g.GetHumanPlayers()) != 3 {
+		t.Fatalf("expected 3 humans in 6 seats, got %d", len(g.GetHumanPlayers()))
+	}
+
+	env.playAll(t, gameID)
+
+	if g := env.game(t, gameID); g.RoundStatus != valueobjects.JudgePickingWinningCard || len(g.Submissions) != 3 {
+		t.Fatalf("expected Rando's cards on the board with the others, got %d submissions in %s", len(g.Submissions), g.RoundStatus)
+	}
+
+	env.pickFirst(t, gameID)
+
+	if err := env.coordinator.ContinueRound(gameID, claim("u1")); err != nil {
+		t.Fatal(err)
+	}
+
+	if rando, _ := env.game(t, gameID).FindPlayerByUserId(aggregates.RandoUserID); !rando.IsJudge && !rando.HasAlreadyPlayedWhiteCard() {
+		t.Fatal("expected Rando to play again in the next round")
+	}
+}
+
+func TestMulliganSwapsTheHandOncePerGame(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{Mulligan: true})
+	before := cardIDsOf(env.game(t, gameID).Players[1].Deck)
+
+	if err := env.coordinator.Mulligan(gameID, claim("u2")); err != nil {
+		t.Fatal(err)
+	}
+
+	after := cardIDsOf(env.game(t, gameID).Players[1].Deck)
+
+	if len(after) != 5 {
+		t.Fatalf("expected a full new hand, got %d cards", len(after))
+	}
+
+	for _, cardID := range after {
+		if slices.Contains(before, cardID) {
+			t.Fatalf("expected a new hand, still holding %s", cardID)
+		}
+	}
+
+	assertCode(t, env.coordinator.Mulligan(gameID, claim("u2")), validation.ErrCodeMulliganUsed)
+}
+
+func TestRebootingTheUniverseCostsAPoint(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{RebootingTheUniverse: true})
+
+	assertCode(t, env.coordinator.RebootUniverse(gameID, claim("u2")), validation.ErrCodeNotEnoughPoints)
+
+	env.playAll(t, gameID)
+
+	// u2 judges the next round, and judges can't redraw
+	winnerID := "u3"
+
+	for _, submission := range env.game(t, gameID).Submissions {
+		if submission.UserID == winnerID {
+			if err := env.coordinator.PickWinningCard(gameID, claim("u1"), submission.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if err := env.coordinator.ContinueRound(gameID, claim("u1")); err != nil {
+		t.Fatal(err)
+	}
+
+	if judge := judgeOf(env.game(t, gameID)); judge == winnerID {
+		t.Fatalf("expected u2 to judge, got %s", judge)
+	}
+
+	if err := env.coordinator.RebootUniverse(gameID, claim(winnerID)); err != nil {
+		t.Fatal(err)
+	}
+
+	if winner, _ := env.game(t, gameID).FindPlayerByUserId(winnerID); winner.Score != 0 || len(winner.Deck) != 5 {
+		t.Fatalf("expected a new hand for a point, %s has %d points and %d cards", winnerID, winner.Score, len(winner.Deck))
+	}
+}
+
+func TestRedrawingHandsNeedsTheHouseRule(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{})
+
+	assertCode(t, env.coordinator.Mulligan(gameID, claim("u2")), validation.ErrCodeHouseRuleDisabled)
+	assertCode(t, env.coordinator.RebootUniverse(gameID, claim("u2")), validation.ErrCodeHouseRuleDisabled)
+}
+
+// beginDemocracy begins a democracy game of u1, u2 and u3 where everyone has
+// played.
+func beginDemocracy(t *testing.T, tieRule valueobjects.TieRule) (*testEnv, string) {
+	t.Helper()
+
+	env := newTestEnv(t, services.GameRules{})
+
+	view, err := env.coordinator.Create("test", "subject", 5, 6, 5, string(valueobjects.DemocracyMode), string(tieRule), valueobjects.HouseRules{}, "", "", claim("u1"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, userID := range []string{"u2", "u3"} {
+		if err := env.coordinator.Join(view.ID, claim(userID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := env.coordinator.BeginGame(view.ID, claim("u1"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	env.playAll(t, view.ID)
+
+	return env, view.ID
+}
+
+// submissionOf is the id of the submission the user played this round.
+func submissionOf(t *testing.T, g *aggregates.Game, userID string) string {
+	t.Helper()
+
+	for _, submission := range g.Submissions {
+		if submission.UserID == userID {
+			return submission.ID
+		}
+	}
+
+	t.Fatalf("%s has not played", userID)
+
+	return ""
+}
+
+func TestDemocracyRoundIsWonByVote(t *testing.T) {
+	env, gameID := beginDemocracy(t, valueobjects.TieAllWin)
+	g := env.game(t, gameID)
+
+	if judgeOf(g) != "" || len(g.Submissions) != 3 || g.RoundStatus != valueobjects.PlayersVoting {
+		t.Fatalf("expected everyone to play and then vote, got %d submissions in %s", len(g.Submissions), g.RoundStatus)
+	}
+
+	assertCode(t, env.coordinator.Vote(gameID, claim("u2"), submissionOf(t, g, "u2")), validation.ErrCodeOwnSubmission)
+
+	for _, vote := range [][2]string{{"u1", "u2"}, {"u2", "u3"}, {"u3", "u2"}} {
+		if err := env.coordinator.Vote(gameID, claim(vote[0]), submissionOf(t, g, vote[1])); err != nil {
+			t.Fatalf("%s could not vote: %v", vote[0], err)
+		}
+
+		if vote[0] == "u1" {
+			assertCode(t, env.coordinator.Vote(gameID, claim("u1"), submissionOf(t, g, "u3")), validation.ErrCodeAlreadyVoted)
+		}
+	}
+
+	g = env.game(t, gameID)
+
+	if g.RoundStatus != valueobjects.JudgeChoseWinningCard {
+		t.Fatalf("expected the last vote to end the round, round is %s", g.RoundStatus)
+	}
+
+	if winner, _ := g.FindPlayerByUserId("u2"); winner.Score != 1 {
+		t.Fatalf("expected u2 to win with two votes, scored %d", winner.Score)
+	}
+
+	assertSameGame(t, g, env.replayed(t, gameID))
+}
+
+func TestDemocracyTieRules(t *testing.T) {
+	tests := []struct {
+		tieRule valueobjects.TieRule
+		points  int
+	}{
+		{valueobjects.TieAllWin, 3},
+		{valueobjects.TieNoPoints, 0},
+		{valueobjects.TieRandom, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.tieRule), func(t *testing.T) {
+			env, gameID := beginDemocracy(t, tt.tieRule)
+			g := env.game(t, gameID)
+
+			// A vote each is a three way tie
+			for _, vote := range [][2]string{{"u1", "u2"}, {"u2", "u3"}, {"u3", "u1"}} {
+				if err := env.coordinator.Vote(gameID, claim(vote[0]), submissionOf(t, g, vote[1])); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			points := 0
+
+			for _, player := range env.game(t, gameID).Players {
+				points += player.Score
+			}
+
+			if points != tt.points {
+				t.Fatalf("expected %d points to be given out, got %d", tt.points, points)
+			}
+		})
+	}
+}
+
+func TestOwnerLeavingPassesTheGameOn(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	if err := env.coordinator.Leave(gameID, claim("u1")); err != nil {
+		t.Fatal(err)
+	}
+
+	g := env.game(t, gameID)
+
+	if _, err := g.FindPlayerByUserId("u1"); err == nil {
+		t.Fatal("expected u1 to be gone")
+	}
+
+	if owner := g.FindOwner(); owner == nil || owner.UserID != "u2" {
+		t.Fatalf("expected u2 to own the game, got %v", owner)
+	}
+}
+
+func TestJudgeLeavingStartsTheRoundOver(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{})
+
+	env.playAll(t, gameID)
+
+	blackCard := env.game(t, gameID).BlackCard.ID
+
+	if err := env.coordinator.Leave(gameID, claim("u1")); err != nil {
+		t.Fatal(err)
+	}
+
+	g := env.game(t, gameID)
+
+	if g.RoundStatus != valueobjects.PlayersPickingCard || len(g.Submissions) != 0 || g.BlackCard.ID == blackCard {
+		t.Fatalf("expected a new round with a new black card, got %d submissions in %s", len(g.Submissions), g.RoundStatus)
+	}
+
+	if judgeOf(g) == "" {
+		t.Fatal("expected someone else to judge")
+	}
+
+	for _, player := range g.Players {
+		if len(player.Deck) != 5 {
+			t.Fatalf("expected %s to get their cards back, holding %d", player.UserID, len(player.Deck))
+		}
+	}
+}
+
+func TestDisconnectedPlayersKeepTheirSeatUntilTheGracePeriodEnds(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{DisconnectGracePeriod: time.Hour})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	if err := env.coordinator.Disconnect(gameID, claim("u2")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := env.coordinator.RemoveDisconnectedPlayers(gameID); err != nil {
+		t.Fatal(err)
+	}
+
+	player, err := env.game(t, gameID).FindPlayerByUserId("u2")
+
+	if err != nil || !player.IsDisconnected {
+		t.Fatal("expected u2 to keep their seat while disconnected")
+	}
+
+	if err := env.coordinator.Join(gameID, claim("u2")); err != nil {
+		t.Fatal(err)
+	}
+
+	if player, _ := env.game(t, gameID).FindPlayerByUserId("u2"); player.IsDisconnected {
+		t.Fatal("expected u2 to be back")
+	}
+}
+
+func TestDisconnectedPlayersAreRemovedAfterTheGracePeriod(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{DisconnectGracePeriod: time.Nanosecond})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	if err := env.coordinator.Disconnect(gameID, claim("u2")); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(time.Millisecond)
+
+	if err := env.coordinator.RemoveDisconnectedPlayers(gameID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.game(t, gameID).FindPlayerByUserId("u2"); err == nil {
+		t.Fatal("expected u2 to lose their seat")
+	}
+}
+
+func TestKickedPlayersCanComeBack(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	assertCode(t, env.coordinator.Kick(gameID, claim("u2"), "u3"), validation.ErrCodeNotGameOwner)
+	assertCode(t, env.coordinator.Kick(gameID, claim("u1"), "u1"), validation.ErrCodeCannotModerateSelf)
+
+	if err := env.coordinator.Kick(gameID, claim("u1"), "u2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.game(t, gameID).FindPlayerByUserId("u2"); err == nil {
+		t.Fatal("expected u2 to be kicked")
+	}
+
+	if !slices.Contains(env.publisher.kicked, "u2") {
+		t.Fatal("expected u2 to be disconnected")
+	}
+
+	if err := env.coordinator.Join(gameID, claim("u2")); err != nil {
+		t.Fatalf("expected u2 to be able to join again: %v", err)
+	}
+}
+
+func TestBannedUsersCannotComeBack(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	if err := env.coordinator.Ban(gameID, claim("u1"), "u2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.game(t, gameID).FindPlayerByUserId("u2"); err == nil {
+		t.Fatal("expected u2 to be removed")
+	}
+
+	assertCode(t, env.coordinator.Join(gameID, claim("u2")), validation.ErrCodePlayerBanned)
+	assertCode(t, env.coordinator.Ban(gameID, claim("u1"), "u2"), validation.ErrCodeAlreadyBanned)
+
+	// Users can be banned before they ever join
+	if err := env.coordinator.Ban(gameID, claim("u1"), "u9"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCode(t, env.coordinator.Join(gameID, claim("u9")), validation.ErrCodePlayerBanned)
+}
+
+func TestMutedPlayersCannotReact(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	if err := env.coordinator.Mute(gameID, claim("u1"), "u2"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCode(t, env.coordinator.ClickEmoji(gameID, claim("u2"), "🔥"), validation.ErrCodePlayerMuted)
+	assertCode(t, env.coordinator.Mute(gameID, claim("u1"), "u2"), validation.ErrCodeAlreadyMuted)
+
+	if err := env.coordinator.Unmute(gameID, claim("u1"), "u2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := env.coordinator.ClickEmoji(gameID, claim("u2"), "🔥"); err != nil {
+		t.Fatalf("expected u2 to react once unmuted: %v", err)
+	}
+
+	assertCode(t, env.coordinator.Unmute(gameID, claim("u1"), "u2"), validation.ErrCodeNotMuted)
+}
+
+func TestJoiningARunningGameWatchesIt(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{})
+
+	if err := env.coordinator.Join(gameID, claim("u4")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.game(t, gameID).FindSpectator("u4"); err != nil {
+		t.Fatal("expected u4 to watch the running game")
+	}
+
+	assertCode(t, env.coordinator.PlayCard(gameID, claim("u4"), []string{"w0"}), validation.ErrCodePlayerNotInGame)
+
+	// Spectators can still react
+	if err := env.coordinator.ClickEmoji(gameID, claim("u4"), "🔥"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPromotedSpectatorsSitDownAfterTheRound(t *testing.T) {
+	env, gameID := beginGame(t, valueobjects.HouseRules{})
+
+	if err := env.coordinator.Join(gameID, claim("u4")); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCode(t, env.coordinator.PromoteSpectator(gameID, claim("u1"), "u2"), validation.ErrCodeNotSpectating)
+
+	if err := env.coordinator.PromoteSpectator(gameID, claim("u1"), "u4"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCode(t, env.coordinator.PromoteSpectator(gameID, claim("u1"), "u4"), validation.ErrCodeAlreadyPromoted)
+
+	if _, err := env.game(t, gameID).FindPlayerByUserId("u4"); err == nil {
+		t.Fatal("expected u4 to wait for the round to end")
+	}
+
+	env.playAll(t, gameID)
+	env.pickFirst(t, gameID)
+
+	if err := env.coordinator.ContinueRound(gameID, claim("u1")); err != nil {
+		t.Fatal(err)
+	}
+
+	g := env.game(t, gameID)
+	player, err := g.FindPlayerByUserId("u4")
+
+	if err != nil {
+		t.Fatal("expected u4 to sit down in the next round")
+	}
+
+	if len(player.Deck) != 5 {
+		t.Fatalf("expected u4 to be dealt in, holding %d cards", len(player.Deck))
+	}
+
+	if _, err := g.FindSpectator("u4"); err == nil {
+		t.Fatal("expected u4 to stop watching")
+	}
+}
+
+func TestWatchGivesUpTheSeat(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	if err := env.coordinator.Watch(gameID, claim("u3")); err != nil {
+		t.Fatal(err)
+	}
+
+	g := env.game(t, gameID)
+
+	if _, err := g.FindPlayerByUserId("u3"); err == nil {
+		t.Fatal("expected u3 to give up their seat")
+	}
+
+	if _, err := g.FindSpectator("u3"); err != nil {
+		t.Fatal("expected u3 to watch")
+	}
+
+	// Spectators in the lobby take a seat again by joining
+	if err := env.coordinator.Join(gameID, claim("u3")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.game(t, gameID).FindPlayerByUserId("u3"); err != nil {
+		t.Fatal("expected u3 to sit down again")
+	}
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/projections"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/domain/valueobjects"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// inviteCodeAttempts bounds how many invite codes are tried before giving up
+// on finding one that no other game uses.
+const inviteCodeAttempts = 5
+
+// ResolveInvite finds the private game an invite code is for, as the user
+// would see it before joining.
+func (gc *GameCoordinator) ResolveInvite(inviteCode string, claim *entities.CustomClaim) (*projections.GameView, error) {
+	code := valueobjects.NormalizeInviteCode(inviteCode)
+
+	gameId, err := gc.findGameByInviteCode(code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var view *projections.GameView
+
+	err = gc.inspect(gameId, func(g *aggregates.Game) error {
+		if err := checkRules(gameId, validateWith(g).ValidateAcceptInvite(gameId, claim.UserID, code)); err != nil {
+			return err
+		}
+
+		view = projections.NewGameView(g, claim.UserID)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// AcceptInvite lets the user into the private game an invite code is for,
+// once they have given its password if it has one. They join it as usual
+// when they connect to it.
+func (gc *GameCoordinator) AcceptInvite(inviteCode string, password string, claim *entities.CustomClaim) (*projections.GameView, error) {
+	code := valueobjects.NormalizeInviteCode(inviteCode)
+
+	gameId, err := gc.findGameByInviteCode(code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash := ""
+
+	err = gc.inspect(gameId, func(g *aggregates.Game) error {
+		passwordHash = g.PasswordHash
+
+		return checkRules(gameId, validateWith(g).ValidateAcceptInvite(gameId, claim.UserID, code))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// bcrypt is slow on purpose, so the password is checked before the game
+	// is taken. The owner may change it in the meantime, in which case the
+	// password that was checked no longer counts.
+	if passwordHash != "" && bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)) != nil {
+		return nil, validation.NewValidationError(validation.ErrCodeWrongPassword, "the password is wrong", "password")
+	}
+
+	err = gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidateAcceptInvite(gameId, claim.UserID, code)); err != nil {
+			return err
+		}
+
+		if g.PasswordHash != passwordHash {
+			return validation.NewValidationError(validation.ErrCodeWrongPassword, "the password was changed, try again", "password")
+		}
+
+		if g.IsVisibleTo(claim.UserID) {
+			return nil
+		}
+
+		return recorder.Record(aggregates.EventInviteAccepted, aggregates.NewGameEventPayloadInviteAccepted(gameId, claim.UserID, code))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var view *projections.GameView
+
+	err = gc.inspect(gameId, func(g *aggregates.Game) error {
+		view = projections.NewGameView(g, claim.UserID)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// RotateInviteCode gives a private game a new invite code. Anyone who already
+// accepted an invite can still get in.
+func (gc *GameCoordinator) RotateInviteCode(gameId string, claim *entities.CustomClaim) error {
+	code, err := gc.newInviteCode()
+
+	if err != nil {
+		return err
+	}
+
+	err = gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidateRotateInviteCode(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		return recorder.Record(aggregates.EventInviteCodeRotated, aggregates.NewGameEventPayloadInviteCodeRotated(gameId, claim.UserID, code))
+	})
+
+	if err != nil {
+		return err
+	}
+
+	gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s made a new invite code. The old one no longer works.", claim.Name))
+
+	return nil
+}
+
+// newInviteCode makes an invite code that no other game is using.
+func (gc *GameCoordinator) newInviteCode() (string, error) {
+	for attempt := 0; attempt < inviteCodeAttempts; attempt++ {
+		code, err := valueobjects.NewInviteCode()
+
+		if err != nil {
+			return "", err
+		}
+
+		_, err = gc.findGameByInviteCode(code)
+
+		var validationError *validation.ValidationError
+
+		if errors.As(err, &validationError) {
+			return code, nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("failed to find an unused invite code after %d attempts", inviteCodeAttempts)
+}
+
+// findGameByInviteCode returns the id of the private game the invite code is
+// for, or an INVITE_NOT_FOUND error.
+func (gc *GameCoordinator) findGameByInviteCode(code string) (string, error) {
+	games, err := gc.gameRepository.GetAllGames()
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get games: %w", err)
+	}
+
+	for _, game := range games {
+		if game.IsPrivate() && game.InviteCode == code {
+			return game.ID, nil
+		}
+	}
+
+	return "", validation.NewValidationError(validation.ErrCodeInviteNotFound, fmt.Sprintf("invite code %s was not found", code), "invite_code")
+}
+
+// hashGamePassword hashes a private game's password. Games without a password
+// have an empty hash.
+func hashGamePassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to hash game password: %w", err)
+	}
+
+	return string(hash), nil
+}
@@ -0,0 +1,120 @@
+package services_test
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAdmitTurnsAwayUsersWhoMayNotSeeTheGame(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2")
+
+	if err := env.coordinator.Ban(gameID, claim("u1"), "u2"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCode(t, env.coordinator.Admit(gameID, claim("u2")), validation.ErrCodePlayerBanned)
+
+	if err := env.coordinator.Admit(gameID, claim("u3")); err != nil {
+		t.Fatalf("expected anyone else to be let into a public game: %v", err)
+	}
+
+	private, err := env.coordinator.Create("private", "subject", 100, 6, 5, "", "", valueobjects.HouseRules{}, string(valueobjects.PrivateVisibility), "", claim("u1"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertCode(t, env.coordinator.Admit(private.ID, claim("u3")), validation.ErrCodeInviteRequired)
+
+	if err := env.coordinator.Admit(private.ID, claim("u1")); err != nil {
+		t.Fatalf("expected the owner to be let in: %v", err)
+	}
+
+	if err := env.coordinator.Admit("missing", claim("u1")); err == nil {
+		t.Fatal("expected a missing game to turn everyone away")
+	}
+}
+
+func TestAcceptInviteLetsUserIntoPrivateGame(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+
+	private, err := env.coordinator.Create("private", "subject", 100, 6, 5, "", "", valueobjects.HouseRules{}, string(valueobjects.PrivateVisibility), "", claim("u1"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code := env.game(t, private.ID).InviteCode
+
+	if _, err := env.coordinator.ResolveInvite("WRONG-CODE-100000", claim("u2")); err == nil {
+		t.Fatal("expected an unknown code not to resolve")
+	}
+
+	if _, err := env.coordinator.AcceptInvite(code, "", claim("u2")); err != nil {
+		t.Fatalf("could not accept invite: %v", err)
+	}
+
+	if err := env.coordinator.Admit(private.ID, claim("u2")); err != nil {
+		t.Fatalf("expected the invited user to be let in: %v", err)
+	}
+}
+
+func TestAcceptInviteRejectsPasswordChangedWhileChecking(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+
+	private, err := env.coordinator.Create("private", "subject", 100, 6, 5, "", "", valueobjects.HouseRules{}, string(valueobjects.PrivateVisibility), "old", claim("u1"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code := env.game(t, private.ID).InviteCode
+	newHash, _ := bcrypt.GenerateFromPassword([]byte("new"), bcrypt.MinCost)
+
+	// Hold the actor so the invite is looked up, and then the password
+	// changed, before the old password has been checked
+	release := make(chan struct{})
+
+	go env.coordinator.Execute(private.ID, func(g *aggregates.Game, recorder *services.EventRecorder) error {
+		<-release
+		return nil
+	})
+
+	time.Sleep(10 * time.Millisecond)
+
+	accepted := make(chan error)
+
+	go func() {
+		_, err := env.coordinator.AcceptInvite(code, "old", claim("u2"))
+		accepted <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	go env.coordinator.Execute(private.ID, func(g *aggregates.Game, recorder *services.EventRecorder) error {
+		settings := g.Settings()
+		settings.PasswordHash = string(newHash)
+
+		return recorder.Record(aggregates.EventSettingsChanged, aggregates.NewGameEventPayloadSettingsChanged(private.ID, "u1", settings))
+	})
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	assertCode(t, <-accepted, validation.ErrCodeWrongPassword)
+
+	if env.game(t, private.ID).IsVisibleTo("u2") {
+		t.Fatal("the old password let the user in after it was changed")
+	}
+
+	if _, err := env.coordinator.AcceptInvite(code, "new", claim("u2")); err != nil {
+		t.Fatalf("could not accept invite with the new password: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"fmt"
+)
+
+// Ready marks the player as ready for the game to begin. Games set to start
+// on their own begin once every player is ready and enough have joined.
+func (gc *GameCoordinator) Ready(gameId string, claim *entities.CustomClaim) error {
+	return gc.setReady(gameId, claim, true)
+}
+
+// Unready takes back the player saying they are ready.
+func (gc *GameCoordinator) Unready(gameId string, claim *entities.CustomClaim) error {
+	return gc.setReady(gameId, claim, false)
+}
+
+func (gc *GameCoordinator) setReady(gameId string, claim *entities.CustomClaim, ready bool) error {
+	eventType := aggregates.EventPlayerUnready
+
+	if ready {
+		eventType = aggregates.EventPlayerReady
+	}
+
+	autoStarted := false
+
+	err := gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		autoStarted = false
+
+		if err := checkRules(gameId, validateWith(g).ValidateReady(gameId, claim.UserID, ready)); err != nil {
+			return err
+		}
+
+		err := recorder.Record(eventType, aggregates.NewGameEventPayloadPlayerReady(gameId, claim.UserID))
+
+		if err != nil {
+			return err
+		}
+
+		if !ready || !g.AutoStart {
+			return nil
+		}
+
+		owner := g.FindOwner()
+
+		if owner == nil || validateWith(g).ValidateBeginGame(gameId, owner.UserID, false).Err() != nil {
+			return nil
+		}
+
+		autoStarted = true
+
+		return gc.begin(g, recorder, owner)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if autoStarted {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), "Everyone is ready. Game has begun.")
+	} else if ready {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s is ready.", claim.Name))
+	}
+
+	return nil
+}
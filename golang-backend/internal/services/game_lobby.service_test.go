@@ -0,0 +1,84 @@
+package services_test
+
+import (
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"testing"
+)
+
+func TestBeginGameWaitsForEveryoneToBeReady(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	assertCode(t, env.coordinator.BeginGame(gameID, claim("u1"), false), validation.ErrCodePlayersNotReady)
+
+	for _, userID := range []string{"u2", "u3"} {
+		if err := env.coordinator.Ready(gameID, claim(userID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertCode(t, env.coordinator.Ready(gameID, claim("u2")), validation.ErrCodeAlreadyReady)
+
+	if err := env.coordinator.Unready(gameID, claim("u3")); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCode(t, env.coordinator.Unready(gameID, claim("u3")), validation.ErrCodeNotReady)
+	assertCode(t, env.coordinator.BeginGame(gameID, claim("u1"), false), validation.ErrCodePlayersNotReady)
+
+	if err := env.coordinator.Ready(gameID, claim("u3")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := env.coordinator.BeginGame(gameID, claim("u1"), false); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCode(t, env.coordinator.Ready(gameID, claim("u2")), validation.ErrCodeWrongGameStatus)
+}
+
+func TestAutoStartBeginsOnceEveryoneIsReady(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+	autoStart := true
+
+	if _, err := env.coordinator.ChangeSettings(gameID, claim("u1"), services.GameSettingsUpdate{AutoStart: &autoStart}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := env.coordinator.Ready(gameID, claim("u2")); err != nil {
+		t.Fatal(err)
+	}
+
+	if !env.game(t, gameID).IsInSetup() {
+		t.Fatal("expected the game to wait for u3")
+	}
+
+	if err := env.coordinator.Ready(gameID, claim("u3")); err != nil {
+		t.Fatal(err)
+	}
+
+	if g := env.game(t, gameID); !g.IsInProgress() {
+		t.Fatalf("expected the game to begin once everyone was ready, game is %s", g.Status)
+	}
+}
+
+func TestAutoStartWaitsForEnoughPlayers(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2")
+	autoStart := true
+
+	if _, err := env.coordinator.ChangeSettings(gameID, claim("u1"), services.GameSettingsUpdate{AutoStart: &autoStart}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := env.coordinator.Ready(gameID, claim("u2")); err != nil {
+		t.Fatal(err)
+	}
+
+	if !env.game(t, gameID).IsInSetup() {
+		t.Fatal("expected the game to wait for a third player")
+	}
+}
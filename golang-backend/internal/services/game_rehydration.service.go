@@ -0,0 +1,376 @@
+package services
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/repositories"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GameRehydrator rebuilds games from their event log. Snapshots in the game
+// repository are only used to skip replaying events that were already applied.
+type GameRehydrator struct {
+	gameRepository  repositories.GameRepository
+	eventRepository repositories.EventRepository
+}
+
+func NewGameRehydrator(
+	gameRepository repositories.GameRepository,
+	eventRepository repositories.EventRepository,
+) *GameRehydrator {
+	return &GameRehydrator{
+		gameRepository:  gameRepository,
+		eventRepository: eventRepository,
+	}
+}
+
+type Discrepancy struct {
+	Field    string `json:"field"`
+	Snapshot string `json:"snapshot"`
+	Replayed string `json:"replayed"`
+}
+
+type ConsistencyReport struct {
+	GameID        string        `json:"game_id"`
+	Consistent    bool          `json:"consistent"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// RehydrateAll rebuilds every game that has an event stream or a snapshot.
+// A game whose snapshot disagrees with its event log is rebuilt from the log
+// alone. Games that fail to rebuild are logged and skipped.
+func (r *GameRehydrator) RehydrateAll() ([]*aggregates.Game, error) {
+	gameIDs, err := r.eventRepository.GetGameIDs()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game ids from event log: %w", err)
+	}
+
+	snapshots, err := r.gameRepository.GetAllGames()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game snapshots: %w", err)
+	}
+
+	seen := make(map[string]bool)
+
+	for _, gameID := range gameIDs {
+		seen[gameID] = true
+	}
+
+	// Games created before the event log recorded creation only exist as snapshots
+	for _, snapshot := range snapshots {
+		if !seen[snapshot.ID] {
+			seen[snapshot.ID] = true
+			gameIDs = append(gameIDs, snapshot.ID)
+		}
+	}
+
+	games := []*aggregates.Game{}
+
+	for _, gameID := range gameIDs {
+		game, err := r.Rehydrate(gameID)
+
+		if err != nil {
+			log.Printf("Warning: failed to rehydrate game %s: %v", gameID, err)
+			continue
+		}
+
+		report, err := r.CheckConsistency(gameID)
+
+		if err != nil {
+			log.Printf("Warning: failed to check consistency of game %s: %v", gameID, err)
+		} else if !report.Consistent {
+			for _, discrepancy := range report.Discrepancies {
+				log.Printf("Warning: game %s snapshot disagrees with event log on %s: snapshot=%q replayed=%q", gameID, discrepancy.Field, discrepancy.Snapshot, discrepancy.Replayed)
+			}
+
+			game, err = r.Rebuild(gameID)
+
+			if err != nil {
+				log.Printf("Warning: failed to rebuild game %s from its event log: %v", gameID, err)
+				continue
+			}
+		}
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// Rehydrate rebuilds a single game. When the stored snapshot points at an
+// event in the log, only the events after it are replayed on top of it.
+// Otherwise, or when those events do not apply to the snapshot, the game is
+// replayed from its creation event.
+func (r *GameRehydrator) Rehydrate(gameID string) (*aggregates.Game, error) {
+	events, err := r.eventRepository.GetEventsForGame(gameID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events for game %s: %w", gameID, err)
+	}
+
+	snapshot, err := r.gameRepository.GetByID(gameID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot for game %s: %w", gameID, err)
+	}
+
+	if snapshot != nil {
+		if tail, ok := eventsAfter(events, snapshot.LastEventID); ok {
+			// Snapshots taken before events were versioned sit at their last event's position
+			if snapshot.Version == 0 {
+				snapshot.Version = int64(len(events) - len(tail))
+			}
+
+			err := applyEvents(snapshot, tail)
+
+			if err == nil {
+				return snapshot, nil
+			}
+
+			log.Printf("Warning: snapshot of game %s does not match its event log, rebuilding it: %v", gameID, err)
+
+			return r.Rebuild(gameID)
+		}
+	}
+
+	if len(events) == 0 || events[0].Type != aggregates.EventGameCreated {
+		if snapshot != nil {
+			log.Printf("Warning: game %s has no creation event, falling back to its snapshot", gameID)
+			snapshot.Version = int64(len(events))
+			return snapshot, nil
+		}
+
+		return nil, fmt.Errorf("game %s has neither a snapshot nor a creation event", gameID)
+	}
+
+	return aggregates.NewGameFromEvents(events)
+}
+
+// Rebuild replays a game from its event log alone, ignoring its snapshot,
+// and stores the result as the game's new snapshot.
+func (r *GameRehydrator) Rebuild(gameID string) (*aggregates.Game, error) {
+	events, err := r.eventRepository.GetEventsForGame(gameID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events for game %s: %w", gameID, err)
+	}
+
+	game, err := aggregates.NewGameFromEvents(events)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.gameRepository.Update(game); err != nil {
+		return nil, fmt.Errorf("failed to replace snapshot of game %s: %w", gameID, err)
+	}
+
+	return game, nil
+}
+
+// applyEvents applies events to a game in order, stopping at the first one
+// that does not apply.
+func applyEvents(game *aggregates.Game, events []aggregates.GameEvent) error {
+	for i := range events {
+		if err := game.ApplyEvent(&events[i]); err != nil {
+			return fmt.Errorf("failed to apply event %s to game %s: %w", events[i].ID, game.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// CheckConsistency replays the event log up to the event the stored snapshot
+// was taken at and reports every field where the two disagree.
+func (r *GameRehydrator) CheckConsistency(gameID string) (*ConsistencyReport, error) {
+	snapshot, err := r.gameRepository.GetByID(gameID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot for game %s: %w", gameID, err)
+	}
+
+	if snapshot == nil {
+		return nil, fmt.Errorf("game %s has no snapshot to check", gameID)
+	}
+
+	events, err := r.eventRepository.GetEventsForGame(gameID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events for game %s: %w", gameID, err)
+	}
+
+	report := &ConsistencyReport{
+		GameID:        gameID,
+		Consistent:    true,
+		Discrepancies: []Discrepancy{},
+	}
+
+	prefix, ok := eventsUpTo(events, snapshot.LastEventID)
+
+	if !ok {
+		report.Consistent = false
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Field:    "last_event_id",
+			Snapshot: snapshot.LastEventID,
+			Replayed: "not found in event log",
+		})
+
+		return report, nil
+	}
+
+	replayed, err := aggregates.NewGameFromEvents(prefix)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay game %s: %w", gameID, err)
+	}
+
+	snapshotFields := gameFingerprint(snapshot)
+	replayedFields := gameFingerprint(replayed)
+
+	fields := make([]string, 0, len(snapshotFields))
+
+	for field := range snapshotFields {
+		fields = append(fields, field)
+	}
+
+	for field := range replayedFields {
+		if _, ok := snapshotFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		if snapshotFields[field] != replayedFields[field] {
+			report.Consistent = false
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Field:    field,
+				Snapshot: snapshotFields[field],
+				Replayed: replayedFields[field],
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// eventsAfter returns the events that come after the event with the given id.
+func eventsAfter(events []aggregates.GameEvent, eventID string) ([]aggregates.GameEvent, bool) {
+	if eventID == "" {
+		return nil, false
+	}
+
+	for i, event := range events {
+		if event.ID == eventID {
+			return events[i+1:], true
+		}
+	}
+
+	return nil, false
+}
+
+// eventsUpTo returns the events up to and including the event with the given id.
+func eventsUpTo(events []aggregates.GameEvent, eventID string) ([]aggregates.GameEvent, bool) {
+	for i, event := range events {
+		if event.ID == eventID {
+			return events[:i+1], true
+		}
+	}
+
+	return nil, false
+}
+
+// gameFingerprint flattens the parts of a game that are derived from events
+// into comparable strings. Timestamps touched by the repository are left out.
+func gameFingerprint(g *aggregates.Game) map[string]string {
+	fields := map[string]string{
+		"name":               g.Name,
+		"winner_count":       strconv.Itoa(g.WinnerCount),
+		"max_player_count":   strconv.Itoa(g.MaxPlayerCount),
+		"mode":               g.Mode.String(),
+		"status":             g.Status.String(),
+		"round_status":       g.RoundStatus.String(),
+		"current_game_round": strconv.Itoa(g.CurrentGameRound),
+		"submissions":        submissionIDs(g.Submissions),
+		"vote_count":         strconv.Itoa(len(g.Votes)),
+		"used_cards":         cardIDs(g.UsedCards),
+		"player_count":       strconv.Itoa(len(g.Players)),
+		"banned_user_ids":    strings.Join(g.BannedUserIDs, ","),
+		"is_paused":          strconv.FormatBool(g.IsPaused()),
+		"rematch_game_id":    g.RematchGameID,
+		"invite_code":        g.InviteCode,
+		"invited_user_ids":   strings.Join(g.InvitedUserIDs, ","),
+		"hand_size":          strconv.Itoa(g.HandSize),
+		"timers":             g.Timers.String(),
+		"house_rules":        fmt.Sprintf("%+v", g.HouseRules),
+		"visibility":         g.Visibility.String(),
+		"has_password":       strconv.FormatBool(g.PasswordHash != ""),
+		"auto_start":         strconv.FormatBool(g.AutoStart),
+	}
+
+	if g.BlackCard != nil {
+		fields["black_card"] = g.BlackCard.ID
+	}
+
+	if g.Collection != nil {
+		fields["collection"] = cardIDs(g.Collection.Cards)
+	}
+
+	if g.RoundWinner != nil {
+		fields["round_winner"] = g.RoundWinner.UserID
+	}
+
+	for i, spectator := range g.Spectators {
+		prefix := fmt.Sprintf("spectators[%d].", i)
+
+		fields[prefix+"user_id"] = spectator.UserID
+		fields[prefix+"is_promoted"] = strconv.FormatBool(spectator.IsPromoted)
+	}
+
+	for i, player := range g.Players {
+		prefix := fmt.Sprintf("players[%d].", i)
+
+		fields[prefix+"id"] = player.ID
+		fields[prefix+"user_id"] = player.UserID
+		fields[prefix+"score"] = strconv.Itoa(player.Score)
+		fields[prefix+"deck"] = cardIDs(player.Deck)
+		fields[prefix+"is_owner"] = strconv.FormatBool(player.IsOwner)
+		fields[prefix+"is_judge"] = strconv.FormatBool(player.IsJudge)
+		fields[prefix+"was_judge"] = strconv.FormatBool(player.WasJudge)
+		fields[prefix+"is_game_winner"] = strconv.FormatBool(player.IsGameWinner)
+		fields[prefix+"is_disconnected"] = strconv.FormatBool(player.IsDisconnected)
+		fields[prefix+"is_ready"] = strconv.FormatBool(player.IsReady)
+		fields[prefix+"is_muted"] = strconv.FormatBool(player.IsMuted)
+
+		fields[prefix+"placed_cards"] = cardIDs(player.PlacedCards)
+	}
+
+	return fields
+}
+
+func cardIDs(cards []*entities.Card) string {
+	ids := make([]string, 0, len(cards))
+
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+
+	return strings.Join(ids, ",")
+}
+
+func submissionIDs(submissions []*entities.Submission) string {
+	ids := make([]string, 0, len(submissions))
+
+	for _, submission := range submissions {
+		ids = append(ids, submission.ID+":"+cardIDs(submission.Cards))
+	}
+
+	return strings.Join(ids, ",")
+}
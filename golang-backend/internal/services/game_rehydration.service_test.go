@@ -0,0 +1,177 @@
+package services_test
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"testing"
+)
+
+// staleSnapshot seats two players and begins a game on from a snapshot taken
+// when it was created, then puts that snapshot back, as if the server stopped
+// before saving.
+func staleSnapshot(t *testing.T) (*testEnv, string, *aggregates.Game) {
+	t.Helper()
+
+	env := newTestEnv(t, services.GameRules{})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{})
+
+	snapshot, err := env.games.GetByID(gameID)
+
+	if err != nil || snapshot == nil {
+		t.Fatalf("expected a snapshot: %v", err)
+	}
+
+	for _, userID := range []string{"u2", "u3"} {
+		if err := env.coordinator.Join(gameID, claim(userID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := env.coordinator.BeginGame(gameID, claim("u1"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	return env, gameID, snapshot
+}
+
+func TestRehydrateReplaysEventsAfterSnapshot(t *testing.T) {
+	env, gameID, snapshot := staleSnapshot(t)
+
+	env.games.Update(snapshot)
+
+	game, err := services.NewGameRehydrator(env.games, env.events).Rehydrate(gameID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSameGame(t, env.replayed(t, gameID), game)
+}
+
+func TestRehydrateWithoutSnapshotReplaysEverything(t *testing.T) {
+	env, gameID, _ := staleSnapshot(t)
+
+	env.games.Delete(gameID)
+
+	game, err := services.NewGameRehydrator(env.games, env.events).Rehydrate(gameID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSameGame(t, env.replayed(t, gameID), game)
+}
+
+func TestRehydrateRebuildsWhenEventsDoNotApplyToSnapshot(t *testing.T) {
+	env, gameID, snapshot := staleSnapshot(t)
+
+	// Nobody can join a game the snapshot has no players for
+	snapshot.Players = nil
+	env.games.Update(snapshot)
+
+	game, err := services.NewGameRehydrator(env.games, env.events).Rehydrate(gameID)
+
+	if err != nil {
+		t.Fatalf("expected the game to be rebuilt from its events: %v", err)
+	}
+
+	assertSameGame(t, env.replayed(t, gameID), game)
+
+	stored, _ := env.games.GetByID(gameID)
+	assertSameGame(t, game, stored)
+}
+
+func TestRehydrateAllRebuildsInconsistentSnapshots(t *testing.T) {
+	env, gameID, _ := staleSnapshot(t)
+	other := env.createGame(t, 6, valueobjects.HouseRules{}, "u2")
+
+	snapshot, _ := env.games.GetByID(gameID)
+	snapshot.Players[1].Score = 7
+	env.games.Update(snapshot)
+
+	games, err := services.NewGameRehydrator(env.games, env.events).RehydrateAll()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(games) != 2 {
+		t.Fatalf("expected both games, got %d", len(games))
+	}
+
+	for _, game := range games {
+		switch game.ID {
+		case gameID:
+			assertSameGame(t, env.replayed(t, gameID), game)
+		case other:
+			assertSameGame(t, env.replayed(t, other), game)
+		default:
+			t.Fatalf("unexpected game %s", game.ID)
+		}
+	}
+}
+
+func TestCheckConsistency(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(snapshot *aggregates.Game)
+		fields []string
+	}{
+		{
+			name:   "untouched snapshot",
+			tamper: func(snapshot *aggregates.Game) {},
+		},
+		{
+			name:   "changed score",
+			tamper: func(snapshot *aggregates.Game) { snapshot.Players[2].Score = 3 },
+			fields: []string{"players[2].score"},
+		},
+		{
+			name:   "lost round",
+			tamper: func(snapshot *aggregates.Game) { snapshot.RoundStatus = valueobjects.Waiting },
+			fields: []string{"round_status"},
+		},
+		{
+			name:   "event missing from the log",
+			tamper: func(snapshot *aggregates.Game) { snapshot.LastEventID = "unknown" },
+			fields: []string{"last_event_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, gameID, _ := staleSnapshot(t)
+
+			snapshot, _ := env.games.GetByID(gameID)
+			tt.tamper(snapshot)
+			env.games.Update(snapshot)
+
+			report, err := services.NewGameRehydrator(env.games, env.events).CheckConsistency(gameID)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if report.Consistent != (len(tt.fields) == 0) || len(report.Discrepancies) != len(tt.fields) {
+				t.Fatalf("expected discrepancies in %v, got %+v", tt.fields, report.Discrepancies)
+			}
+
+			for i, field := range tt.fields {
+				if report.Discrepancies[i].Field != field {
+					t.Fatalf("expected discrepancies in %v, got %+v", tt.fields, report.Discrepancies)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckConsistencyWithoutSnapshot(t *testing.T) {
+	env, gameID, _ := staleSnapshot(t)
+
+	env.games.Delete(gameID)
+
+	if _, err := services.NewGameRehydrator(env.games, env.events).CheckConsistency(gameID); err == nil {
+		t.Fatal("expected an error for a game without a snapshot")
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/projections"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Rematch starts a new game with the finished game's settings, players and
+// spectators, owned by the same owner. The new game is dealt from the same
+// cards, shuffled afresh when it begins, unless a deck subject is given to
+// generate a new deck from. A private game's rematch keeps its password but
+// gets a new invite code. Everyone still in the finished game's room is sent
+// the new game's id.
+func (gc *GameCoordinator) Rematch(gameId string, claim *entities.CustomClaim, deckSubject string) (*projections.GameView, error) {
+	private := false
+
+	err := gc.inspect(gameId, func(g *aggregates.Game) error {
+		private = g.IsPrivate()
+
+		return checkRules(gameId, validateWith(g).ValidateRematch(gameId, claim.UserID))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	inviteCode := ""
+
+	if private {
+		inviteCode, err = gc.newInviteCode()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Generating a deck is slow, so it is done before the game is taken
+	var collection *aggregates.Collection
+
+	if deckSubject != "" {
+		collection, err = gc.deckCreationService.GenerateDeck(deckSubject)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to create deck: %w", err)
+		}
+	}
+
+	var game *aggregates.Game
+
+	// The rematch is stored before it is recorded on the finished game, so a
+	// rematch that fails to be created can be tried again. It is only created
+	// once when the command is retried after a conflict.
+	err = gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		if err := checkRules(gameId, validateWith(g).ValidateRematch(gameId, claim.UserID)); err != nil {
+			return err
+		}
+
+		if game == nil {
+			rematch, err := gc.createRematch(g, claim, collection, inviteCode)
+
+			if err != nil {
+				return fmt.Errorf("failed to create rematch of game %s: %w", gameId, err)
+			}
+
+			game = rematch
+		}
+
+		return recorder.Record(aggregates.EventRematchCreated, aggregates.NewGameEventPayloadRematchCreated(gameId, claim.UserID, game.ID))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	gc.publisher.PublishToRoom(gameId, string(aggregates.RematchStartedMessage), map[string]interface{}{
+		"game_id":         gameId,
+		"rematch_game_id": game.ID,
+	})
+
+	return projections.NewGameView(game, claim.UserID), nil
+}
+
+// createRematch creates a new game like the finished one, dealt from the
+// given deck or the finished game's cards, and seats its lobby.
+func (gc *GameCoordinator) createRematch(g *aggregates.Game, claim *entities.CustomClaim, deck *aggregates.Collection, inviteCode string) (*aggregates.Game, error) {
+	if deck == nil {
+		deck = g.Collection.Clone()
+	}
+
+	created := aggregates.NewGameEventPayloadGameCreated(uuid.New().String(), g.Name, deck, g.WinnerCount, g.MaxPlayerCount, g.MinPlayerCount, g.HandSize, g.Mode, g.TieRule, g.HouseRules, g.Visibility, inviteCode, g.PasswordHash, uuid.New().String(), claim)
+	created.BannedUserIDs = slices.Clone(g.BannedUserIDs)
+	created.Timers = g.Timers
+	created.AutoStart = g.AutoStart
+
+	players, spectators := rematchLobby(g, claim.UserID)
+
+	return gc.createGame(created, func(rematch *aggregates.Game, recorder *eventRecorder) error {
+		for _, player := range players {
+			err := recorder.Record(aggregates.EventJoinedGame, aggregates.NewGameEventPayloadJoinedGame(rematch.ID, player.UserID, uuid.New().String(), player))
+
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, spectator := range spectators {
+			err := recorder.Record(aggregates.EventSpectatorJoined, aggregates.NewGameEventPayloadJoinedGame(rematch.ID, spectator.UserID, uuid.New().String(), spectator))
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// rematchLobby lists who is carried over into a rematch besides the owner.
+// Bots rejoin by house rule when the rematch begins, and anyone who has
+// disconnected is left to join again themselves.
+func rematchLobby(g *aggregates.Game, ownerId string) ([]*entities.CustomClaim, []*entities.CustomClaim) {
+	players := []*entities.CustomClaim{}
+	spectators := []*entities.CustomClaim{}
+
+	for _, player := range g.Players {
+		if player.UserID == ownerId || player.IsBot || player.IsDisconnected {
+			continue
+		}
+
+		players = append(players, rematchClaim(player))
+	}
+
+	for _, spectator := range g.Spectators {
+		spectators = append(spectators, rematchClaim(spectator))
+	}
+
+	return players, spectators
+}
+
+func rematchClaim(player *aggregates.Player) *entities.CustomClaim {
+	return &entities.CustomClaim{
+		UserID: player.UserID,
+		Name:   player.Name,
+		Image:  player.Image,
+	}
+}
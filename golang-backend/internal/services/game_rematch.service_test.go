@@ -0,0 +1,127 @@
+package services_test
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"testing"
+)
+
+// finishedGame is a game of u1, u2 and u3, watched by u4, that u2 or u3 won
+// in the first round.
+func finishedGame(t *testing.T) (*testEnv, string) {
+	t.Helper()
+
+	env := newTestEnv(t, services.GameRules{})
+
+	view, err := env.coordinator.Create("test", "subject", 1, 6, 7, "", "", valueobjects.HouseRules{}, "", "", claim("u1"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, userID := range []string{"u2", "u3"} {
+		if err := env.coordinator.Join(view.ID, claim(userID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertCode(t, rematch(env, view.ID, "u1"), validation.ErrCodeWrongGameStatus)
+
+	if err := env.coordinator.BeginGame(view.ID, claim("u1"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := env.coordinator.Join(view.ID, claim("u4")); err != nil {
+		t.Fatal(err)
+	}
+
+	env.playAll(t, view.ID)
+	env.pickFirst(t, view.ID)
+
+	if g := env.game(t, view.ID); !g.IsFinished() {
+		t.Fatalf("expected the first point to win, game is %s", g.Status)
+	}
+
+	return env, view.ID
+}
+
+func rematch(env *testEnv, gameID string, userID string) error {
+	_, err := env.coordinator.Rematch(gameID, claim(userID), "")
+
+	return err
+}
+
+func TestRematchStartsANewGameWithTheSameLobby(t *testing.T) {
+	env, gameID := finishedGame(t)
+
+	assertCode(t, rematch(env, gameID, "u2"), validation.ErrCodeNotGameOwner)
+
+	view, err := env.coordinator.Rematch(gameID, claim("u1"), "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finished := env.game(t, gameID)
+	g := env.game(t, view.ID)
+
+	if finished.RematchGameID != g.ID {
+		t.Fatalf("expected the finished game to point to the rematch, got %q", finished.RematchGameID)
+	}
+
+	if !g.IsInSetup() || g.HandSize != 7 || g.WinnerCount != 1 {
+		t.Fatalf("expected a new game in setup with the same settings, got %s with hand %d", g.Status, g.HandSize)
+	}
+
+	if owner := g.FindOwner(); owner == nil || owner.UserID != "u1" {
+		t.Fatal("expected u1 to own the rematch")
+	}
+
+	for _, userID := range []string{"u1", "u2", "u3"} {
+		player, err := g.FindPlayerByUserId(userID)
+
+		if err != nil {
+			t.Fatalf("expected %s to be in the rematch", userID)
+		}
+
+		if player.Score != 0 || len(player.Deck) != 0 {
+			t.Fatalf("expected %s to start over, has %d points and %d cards", userID, player.Score, len(player.Deck))
+		}
+	}
+
+	if _, err := g.FindSpectator("u4"); err != nil {
+		t.Fatal("expected u4 to watch the rematch")
+	}
+
+	if len(g.Collection.Cards) != len(finished.Collection.Cards) {
+		t.Fatalf("expected the rematch to use the same cards, got %d of %d", len(g.Collection.Cards), len(finished.Collection.Cards))
+	}
+
+	if sent := env.publisher.count(string(aggregates.RematchStartedMessage)); sent != 1 {
+		t.Fatalf("expected the room to be told about the rematch once, got %d", sent)
+	}
+
+	assertCode(t, rematch(env, gameID, "u1"), validation.ErrCodeRematchStarted)
+}
+
+func TestRematchIsNotRecordedUntilItIsCreated(t *testing.T) {
+	env, gameID := finishedGame(t)
+
+	env.events.failNewGames.Store(true)
+
+	if err := rematch(env, gameID, "u1"); err == nil {
+		t.Fatal("expected the rematch to fail while new games cannot be stored")
+	}
+
+	env.events.failNewGames.Store(false)
+
+	if finished := env.game(t, gameID); finished.RematchGameID != "" {
+		t.Fatalf("rematch that was never created was recorded as %q", finished.RematchGameID)
+	}
+
+	if err := rematch(env, gameID, "u1"); err != nil {
+		t.Fatalf("rematch once new games can be stored: %v", err)
+	}
+}
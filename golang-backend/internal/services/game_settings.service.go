@@ -0,0 +1,186 @@
+package services
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/projections"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/domain/valueobjects"
+	"fmt"
+	"strings"
+)
+
+// GameSettingsUpdate is a change to a game's settings. Settings left nil stay
+// as they are. Timers replace the game's timers as a whole.
+type GameSettingsUpdate struct {
+	Name           *string
+	WinnerCount    *int
+	MaxPlayerCount *int
+	HandSize       *int
+	Timers         *valueobjects.Timers
+	HouseRules     *valueobjects.HouseRules
+	Visibility     *string
+	AutoStart      *bool
+	// Password replaces a private game's password. An empty password removes it.
+	Password *string
+}
+
+// ChangeSettings changes the settings of a game that has not begun yet. A game
+// made private gets an invite code, and a game made public loses its code and
+// password. Everyone in the lobby sees the new settings straight away.
+func (gc *GameCoordinator) ChangeSettings(gameId string, claim *entities.CustomClaim, update GameSettingsUpdate) (*projections.GameView, error) {
+	visibility := valueobjects.Visibility("")
+
+	if update.Visibility != nil {
+		var err error
+
+		visibility, err = valueobjects.NewVisibility(*update.Visibility)
+
+		if err != nil {
+			return nil, validation.NewValidationError(validation.ErrCodeInvalidVisibility, err.Error(), "visibility")
+		}
+	}
+
+	// The invite code and password hash are not made yet, the settings are
+	// checked again with them once the game is taken
+	err := gc.inspect(gameId, func(g *aggregates.Game) error {
+		return checkRules(gameId, validateWith(g).ValidateChangeSettings(gameId, claim.UserID, mergeGameSettings(g.Settings(), update, visibility, "", "")))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Invite codes and password hashes are slow to make, so they are made
+	// before the game is taken. A game that is already private keeps its code.
+	inviteCode := ""
+
+	if visibility.IsPrivate() {
+		inviteCode, err = gc.newInviteCode()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	passwordHash := ""
+
+	if update.Password != nil {
+		passwordHash, err = hashGamePassword(*update.Password)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	changed := false
+
+	err = gc.execute(gameId, func(g *aggregates.Game, recorder *eventRecorder) error {
+		before := g.Settings()
+		settings := mergeGameSettings(before, update, visibility, inviteCode, passwordHash)
+
+		if err := checkRules(gameId, validateWith(g).ValidateChangeSettings(gameId, claim.UserID, settings)); err != nil {
+			return err
+		}
+
+		changed = !settingsEqual(before, settings)
+
+		if !changed {
+			return nil
+		}
+
+		return recorder.Record(aggregates.EventSettingsChanged, aggregates.NewGameEventPayloadSettingsChanged(gameId, claim.UserID, settings))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if changed {
+		gc.publisher.PublishToRoom(gameId, string(aggregates.ChatMessage), fmt.Sprintf("%s changed the game settings.", claim.Name))
+	}
+
+	var view *projections.GameView
+
+	err = gc.inspect(gameId, func(g *aggregates.Game) error {
+		view = projections.NewGameView(g, claim.UserID)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// mergeGameSettings applies an update to the game's settings. The invite code
+// and password hash were made beforehand, for a game made private and for a
+// new password.
+func mergeGameSettings(settings aggregates.GameSettings, update GameSettingsUpdate, visibility valueobjects.Visibility, inviteCode string, passwordHash string) aggregates.GameSettings {
+	// Games created before visibility was a setting are public
+	if settings.Visibility == "" {
+		settings.Visibility = valueobjects.PublicVisibility
+	}
+
+	// Games created without their own hand size deal the default hand
+	if settings.HandSize == 0 {
+		settings.HandSize = aggregates.DefaultHandSize
+	}
+
+	if update.Name != nil {
+		settings.Name = strings.TrimSpace(*update.Name)
+	}
+
+	if update.WinnerCount != nil {
+		settings.WinnerCount = *update.WinnerCount
+	}
+
+	if update.MaxPlayerCount != nil {
+		settings.MaxPlayerCount = *update.MaxPlayerCount
+	}
+
+	if update.HandSize != nil {
+		settings.HandSize = *update.HandSize
+	}
+
+	if update.Timers != nil {
+		settings.Timers = *update.Timers
+	}
+
+	if update.HouseRules != nil {
+		settings.HouseRules = *update.HouseRules
+	}
+
+	if update.Visibility != nil {
+		settings.Visibility = visibility
+	}
+
+	if update.AutoStart != nil {
+		settings.AutoStart = *update.AutoStart
+	}
+
+	if update.Password != nil {
+		settings.PasswordHash = passwordHash
+	}
+
+	switch {
+	case !settings.Visibility.IsPrivate() && update.Password == nil:
+		settings.InviteCode = ""
+		settings.PasswordHash = ""
+	case !settings.Visibility.IsPrivate():
+		settings.InviteCode = ""
+	case settings.InviteCode == "":
+		settings.InviteCode = inviteCode
+	}
+
+	return settings
+}
+
+func settingsEqual(a aggregates.GameSettings, b aggregates.GameSettings) bool {
+	timers := a.Timers.Equal(b.Timers)
+	a.Timers, b.Timers = valueobjects.Timers{}, valueobjects.Timers{}
+
+	return timers && a == b
+}
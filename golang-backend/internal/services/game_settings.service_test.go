@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"testing"
+)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestChangeSettingsKeepsHandSizeAndTimersInBounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		update services.GameSettingsUpdate
+		code   string
+	}{
+		{"smallest hand", services.GameSettingsUpdate{HandSize: intPtr(aggregates.MinHandSize)}, ""},
+		{"largest hand", services.GameSettingsUpdate{HandSize: intPtr(aggregates.MaxHandSize)}, ""},
+		{"no hand", services.GameSettingsUpdate{HandSize: intPtr(0)}, validation.ErrCodeInvalidHandSize},
+		{"hand too small for every black card", services.GameSettingsUpdate{HandSize: intPtr(aggregates.MinHandSize - 1)}, validation.ErrCodeInvalidHandSize},
+		{"hand too big", services.GameSettingsUpdate{HandSize: intPtr(aggregates.MaxHandSize + 1)}, validation.ErrCodeInvalidHandSize},
+		{"untimed", services.GameSettingsUpdate{Timers: &valueobjects.Timers{PickingSeconds: intPtr(0)}}, ""},
+		{"longest timer", services.GameSettingsUpdate{Timers: &valueobjects.Timers{JudgingSeconds: intPtr(valueobjects.MaxTimerSeconds)}}, ""},
+		{"negative timer", services.GameSettingsUpdate{Timers: &valueobjects.Timers{PickingSeconds: intPtr(-1)}}, validation.ErrCodeInvalidTimers},
+		{"timer too long", services.GameSettingsUpdate{Timers: &valueobjects.Timers{RoundPauseSeconds: intPtr(valueobjects.MaxTimerSeconds + 1)}}, validation.ErrCodeInvalidTimers},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := newTestEnv(t, services.GameRules{})
+			gameID := env.createGame(t, 6, valueobjects.HouseRules{})
+			before := env.game(t, gameID)
+
+			_, err := env.coordinator.ChangeSettings(gameID, claim("u1"), test.update)
+
+			if test.code == "" {
+				if err != nil {
+					t.Fatalf("expected the settings to change: %v", err)
+				}
+
+				return
+			}
+
+			assertCode(t, err, test.code)
+
+			if after := env.game(t, gameID); after.Version != before.Version {
+				t.Fatalf("rejected settings changed the game to version %d", after.Version)
+			}
+		})
+	}
+}
+
+func TestChangeSettingsOfGameWithDefaultHand(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+
+	view, err := env.coordinator.Create("test", "subject", 10, 6, 0, "", "", valueobjects.HouseRules{}, "", "", claim("u1"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := "renamed"
+
+	if _, err := env.coordinator.ChangeSettings(view.ID, claim("u1"), services.GameSettingsUpdate{Name: &name}); err != nil {
+		t.Fatalf("could not rename game created with the default hand: %v", err)
+	}
+
+	if g := env.game(t, view.ID); g.Name != name || g.TargetHandSize() != aggregates.DefaultHandSize {
+		t.Fatalf("expected the game renamed with the default hand, got %q with %d cards", g.Name, g.TargetHandSize())
+	}
+}
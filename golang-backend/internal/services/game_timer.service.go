@@ -0,0 +1,88 @@
+package services
+
+import (
+	"cardgame/internal/domain/repositories"
+	"context"
+	"log"
+	"time"
+)
+
+// timerBatchSize is the most timers claimed in one poll.
+const timerBatchSize = 100
+
+// timerLease is how long a claimed timer is held for its poller. A game that
+// could not be moved along comes due again once the lease runs out.
+const timerLease = 30 * time.Second
+
+// GameTimerPoller claims due game timers from the timer store and hands them
+// to the coordinator. With a TimerRepository as the coordinator's
+// DeadlineScheduler it stands in for the Temporal round workflow. Any number
+// of pollers can share a store, each timer is only claimed once per lease.
+type GameTimerPoller struct {
+	timerRepository repositories.TimerRepository
+	gameCoordinator *GameCoordinator
+	interval        time.Duration
+}
+
+func NewGameTimerPoller(timerRepository repositories.TimerRepository, gameCoordinator *GameCoordinator, interval time.Duration) *GameTimerPoller {
+	return &GameTimerPoller{
+		timerRepository: timerRepository,
+		gameCoordinator: gameCoordinator,
+		interval:        interval,
+	}
+}
+
+// Run polls until the context is cancelled.
+func (p *GameTimerPoller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.poll()
+		}
+	}
+}
+
+func (p *GameTimerPoller) poll() {
+	now := time.Now()
+
+	gameIds, err := p.timerRepository.ClaimDue(now, now.Add(timerLease), timerBatchSize)
+
+	if err != nil {
+		log.Printf("Error claiming due timers: %v", err)
+		return
+	}
+
+	for _, gameId := range gameIds {
+		// Each game waits on its own actor, so one busy game does not hold up
+		// the rest
+		go p.fire(gameId)
+	}
+}
+
+// fire moves a claimed game along, then hands the timer store the game's next
+// deadline in place of the lease. When anything fails the lease is left to
+// run out, so the game is tried again.
+func (p *GameTimerPoller) fire(gameId string) {
+	gc := p.gameCoordinator
+
+	// Another server may be running the game, and it picks the timer up once
+	// the lease runs out
+	if gc.getActor(gameId) == nil {
+		log.Printf("Game %s is not running here, leaving its timer to the lease", gameId)
+		return
+	}
+
+	if err := gc.AutoProgress(gameId); err != nil {
+		log.Printf("Error auto progressing game %s, retrying in %s: %v", gameId, timerLease, err)
+		return
+	}
+
+	if err := gc.rearmClock(gameId); err != nil {
+		log.Printf("Error re-arming the clock for game %s: %v", gameId, err)
+	}
+}
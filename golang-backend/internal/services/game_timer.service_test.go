@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/services"
+	"testing"
+	"time"
+)
+
+func TestPollerMovesDueGamesAlong(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{PickingTimeout: 20 * time.Millisecond, JudgingTimeout: time.Hour})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	if err := env.coordinator.BeginGame(gameID, claim("u1"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, armed := env.timers.FireAt(gameID); !armed {
+		t.Fatal("expected the picking deadline to be armed")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	services.NewGameTimerPoller(env.timers, env.coordinator, time.Hour).Poll()
+
+	// The judging deadline replaces the lease the timer was claimed under
+	eventually(t, "expected the judging deadline to be armed", func() bool {
+		fireAt, armed := env.timers.FireAt(gameID)
+
+		return armed && fireAt.After(time.Now().Add(time.Minute))
+	})
+
+	if status := env.game(t, gameID).RoundStatus; status != valueobjects.JudgePickingWinningCard {
+		t.Fatalf("expected random cards to be played, round is %s", status)
+	}
+}
+
+func TestPollerKeepsLeaseWhenGameFailsToMove(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{PickingTimeout: 20 * time.Millisecond})
+	gameID := env.createGame(t, 6, valueobjects.HouseRules{}, "u2", "u3")
+
+	if err := env.coordinator.BeginGame(gameID, claim("u1"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	env.events.fail.Store(true)
+	services.NewGameTimerPoller(env.timers, env.coordinator, time.Hour).Poll()
+
+	fireAt, armed := env.timers.FireAt(gameID)
+
+	if !armed || fireAt.Before(time.Now().Add(20*time.Second)) {
+		t.Fatalf("expected the game to be held under a lease, got %v %v", fireAt, armed)
+	}
+
+	eventually(t, "expected the failed attempt to leave the round as it was", func() bool {
+		return env.game(t, gameID).RoundStatus == valueobjects.PlayersPickingCard
+	})
+
+	time.Sleep(20 * time.Millisecond)
+
+	if after, _ := env.timers.FireAt(gameID); !after.Equal(fireAt) {
+		t.Fatalf("failed attempt replaced the lease with %v", after)
+	}
+}
+
+func TestPollerLeavesTimersOfGamesRunningElsewhereToTheLease(t *testing.T) {
+	env := newTestEnv(t, services.GameRules{})
+
+	env.timers.Schedule("elsewhere", time.Now().Add(-time.Second))
+	services.NewGameTimerPoller(env.timers, env.coordinator, time.Hour).Poll()
+
+	fireAt, armed := env.timers.FireAt("elsewhere")
+
+	if !armed || fireAt.Before(time.Now().Add(20*time.Second)) {
+		t.Fatalf("expected the timer to be held under a lease, got %v %v", fireAt, armed)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if after, armed := env.timers.FireAt("elsewhere"); !armed || !after.Equal(fireAt) {
+		t.Fatalf("expected the lease to be left to run out, got %v %v", after, armed)
+	}
+}
@@ -0,0 +1,296 @@
+package services_test
+
+import (
+	"cardgame/internal/domain/aggregates"
+	"cardgame/internal/domain/entities"
+	"cardgame/internal/domain/validation"
+	"cardgame/internal/domain/valueobjects"
+	"cardgame/internal/infra"
+	"cardgame/internal/services"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testDeck deals the same small deck to every game. Every third black card
+// asks for two white cards.
+type testDeck struct{}
+
+func (testDeck) GenerateDeck(subject string) (*aggregates.Collection, error) {
+	collection := aggregates.NewCollection()
+
+	for i := 0; i < 80; i++ {
+		collection.AddCard(entities.NewCard(fmt.Sprintf("w%d", i), entities.White, fmt.Sprintf("white %d", i)))
+	}
+
+	for i := 0; i < 20; i++ {
+		cardValue := fmt.Sprintf("black _____ %d", i)
+
+		if i%3 == 0 {
+			cardValue += " and _____"
+		}
+
+		collection.AddCard(entities.NewCard(fmt.Sprintf("b%d", i), entities.Black, cardValue))
+	}
+
+	return collection, nil
+}
+
+type publishedMessage struct {
+	roomID    string
+	eventType string
+	payload   any
+}
+
+// testPublisher records what was published. Per client views are built on
+// another goroutine, the way the hub builds them, so the race detector sees
+// a published game being changed afterwards.
+type testPublisher struct {
+	mu       sync.Mutex
+	messages []publishedMessage
+	views    int
+	kicked   []string
+}
+
+func (p *testPublisher) PublishToRoom(roomID string, eventType string, payload any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, publishedMessage{roomID, eventType, payload})
+
+	return nil
+}
+
+func (p *testPublisher) PublishToRoomPerClient(roomID string, eventType string, build func(userID string) any) error {
+	done := make(chan any)
+
+	go func() {
+		done <- build("u1")
+	}()
+
+	view := <-done
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.views++
+	p.messages = append(p.messages, publishedMessage{roomID, eventType, view})
+
+	return nil
+}
+
+func (p *testPublisher) DisconnectUser(roomID string, userID string, eventType string, payload any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.kicked = append(p.kicked, userID)
+
+	return nil
+}
+
+// count is how many messages of the given type were published.
+func (p *testPublisher) count(eventType string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	count := 0
+
+	for _, message := range p.messages {
+		if message.eventType == eventType {
+			count++
+		}
+	}
+
+	return count
+}
+
+// failingEventRepository fails every append while fail is set, and appends
+// that would start a new game while failNewGames is set.
+type failingEventRepository struct {
+	*infra.InMemoryEventRepository
+	fail         atomic.Bool
+	failNewGames atomic.Bool
+}
+
+func (r *failingEventRepository) AppendEvents(gameID string, expectedVersion int64, events []*aggregates.GameEvent) error {
+	if r.fail.Load() || (r.failNewGames.Load() && expectedVersion == 0) {
+		return errors.New("event store is down")
+	}
+
+	return r.InMemoryEventRepository.AppendEvents(gameID, expectedVersion, events)
+}
+
+type testEnv struct {
+	coordinator *services.GameCoordinator
+	events      *failingEventRepository
+	games       *infra.InMemoryGameRepository
+	timers      *infra.InMemoryTimerRepository
+	publisher   *testPublisher
+}
+
+func newTestEnv(t *testing.T, rules services.GameRules) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		events:    &failingEventRepository{InMemoryEventRepository: infra.NewInMemoryEventRepository()},
+		games:     infra.NewInMemoryGameRepository(),
+		timers:    infra.NewInMemoryTimerRepository(),
+		publisher: &testPublisher{},
+	}
+
+	rehydrator := services.NewGameRehydrator(env.games, env.events)
+	env.coordinator = services.NewGameCoordinator(env.games, env.events, testDeck{}, rehydrator, env.publisher, env.timers, rules, nil)
+
+	return env
+}
+
+func claim(userID string) *entities.CustomClaim {
+	return &entities.CustomClaim{UserID: userID, Name: userID}
+}
+
+// createGame creates a game owned by u1 and seats the other users.
+func (env *testEnv) createGame(t *testing.T, maxPlayerCount int, houseRules valueobjects.HouseRules, userIDs ...string) string {
+	t.Helper()
+
+	view, err := env.coordinator.Create("test", "subject", 100, maxPlayerCount, 5, "", "", houseRules, "", "", claim("u1"))
+
+	if err != nil {
+		t.Fatalf("could not create game: %v", err)
+	}
+
+	for _, userID := range userIDs {
+		if err := env.coordinator.Join(view.ID, claim(userID)); err != nil {
+			t.Fatalf("could not join %s: %v", userID, err)
+		}
+	}
+
+	return view.ID
+}
+
+// game is a copy of the live game, safe to read outside its actor.
+func (env *testEnv) game(t *testing.T, gameID string) *aggregates.Game {
+	t.Helper()
+
+	var game *aggregates.Game
+
+	err := env.coordinator.Inspect(gameID, func(g *aggregates.Game) error {
+		game = g.Clone()
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("could not inspect game %s: %v", gameID, err)
+	}
+
+	return game
+}
+
+// replayed is the game rebuilt from its event log alone.
+func (env *testEnv) replayed(t *testing.T, gameID string) *aggregates.Game {
+	t.Helper()
+
+	events, err := env.events.GetEventsForGame(gameID)
+
+	if err != nil {
+		t.Fatalf("could not get events: %v", err)
+	}
+
+	game, err := aggregates.NewGameFromEvents(events)
+
+	if err != nil {
+		t.Fatalf("could not replay game %s: %v", gameID, err)
+	}
+
+	return game
+}
+
+// playAll has every player still picking play the first card in their hand.
+func (env *testEnv) playAll(t *testing.T, gameID string) {
+	t.Helper()
+
+	g := env.game(t, gameID)
+
+	for _, player := range g.GetNonJudgePlayers() {
+		if player.IsBot || player.HasAlreadyPlayedWhiteCard() {
+			continue
+		}
+
+		if err := env.coordinator.PlayCard(gameID, claim(player.UserID), cardIDsOf(player.Deck[:g.BlackCard.PickCount()])); err != nil {
+			t.Fatalf("%s could not play: %v", player.UserID, err)
+		}
+	}
+}
+
+func judgeOf(g *aggregates.Game) string {
+	judge, err := g.FindCurrentJudge()
+
+	if err != nil {
+		return ""
+	}
+
+	return judge.UserID
+}
+
+func cardIDsOf(cards []*entities.Card) []string {
+	ids := make([]string, 0, len(cards))
+
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+
+	return ids
+}
+
+// assertSameGame fails unless both games hold the same state. Timestamps the
+// game repository sets are left out.
+func assertSameGame(t *testing.T, want *aggregates.Game, got *aggregates.Game) {
+	t.Helper()
+
+	wantJSON, gotJSON := gameJSON(t, want), gameJSON(t, got)
+
+	if wantJSON != gotJSON {
+		t.Fatalf("games differ\nwant: %s\ngot:  %s", wantJSON, gotJSON)
+	}
+}
+
+func gameJSON(t *testing.T, g *aggregates.Game) string {
+	t.Helper()
+
+	cloned := g.Clone()
+	cloned.UpdatedAt = time.Time{}
+
+	raw, err := json.Marshal(cloned)
+
+	if err != nil {
+		t.Fatalf("could not marshal game: %v", err)
+	}
+
+	return string(raw)
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var validationError *validation.ValidationError
+
+	if !errors.As(err, &validationError) || validationError.Code != code {
+		t.Fatalf("expected a %s error, got %v", code, err)
+	}
+}
+
+// eventually waits up to a second for condition to hold.
+func eventually(t *testing.T, message string, condition func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if condition() {
+			return
+		}
+	}
+
+	t.Fatal(message)
+}
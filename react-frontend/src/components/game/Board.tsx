@@ -1,9 +1,11 @@
 import { useAuth } from "@/context/AuthContext";
 import GameCard from "./GameCard";
+import { getPickCount } from "./Hand";
 
 export default function Board(props: {
   game: Game;
-  handlePickWinningCard: (cardId: string) => void;
+  handlePickWinningSubmission: (submissionId: string) => void;
+  handleVote: (submissionId: string) => void;
 }) {
   const { user } = useAuth();
 
@@ -23,13 +25,17 @@ export default function Board(props: {
     props.game.status === "InProgress" &&
     props.game.round_status === "JudgeChoseWinningCard";
 
-  let winningCard = null;
+  // A tied vote can have more than one winning submission
+  const winningSubmissionIds = hasRoundWinner
+    ? props.game.winning_submission_ids ?? [props.game.winning_submission_id]
+    : [];
 
-  if (hasRoundWinner) {
-    winningCard = props.game.round_winner.placed_card;
-  }
+  const isOwnSubmission = (submission: Submission) =>
+    submission.cards.some((card) =>
+      player?.placed_cards?.some((placed) => placed.id === card.id)
+    );
 
-  const onWhiteCardClick = (card: Card) => {
+  const onSubmissionClick = (submission: Submission) => {
     if (!player) {
       return;
     }
@@ -38,6 +44,14 @@ export default function Board(props: {
       return;
     }
 
+    if (props.game.round_status === "PlayersVoting") {
+      if (!player.has_voted && !isOwnSubmission(submission)) {
+        props.handleVote(submission.id);
+      }
+
+      return;
+    }
+
     if (props.game.round_status !== "JudgePickingWinningCard") {
       return;
     }
@@ -46,26 +60,40 @@ export default function Board(props: {
       return;
     }
 
-    props.handlePickWinningCard(card.id);
+    props.handlePickWinningSubmission(submission.id);
   };
 
   return (
     <div className="flex flex-col md:flex-row items-start gap-2 flex-wrap">
       <RenderBlackCard card={props.game.black_card} />
-      {props.game.white_cards.map((card) => (
-        <GameCard
-          key={card.id}
-          cardId={card.id}
-          value={card.card_value}
-          onCardClick={() => onWhiteCardClick(card)}
-          isDisabled={false}
-          isWinningCard={winningCard?.id === card.id ? true : false}
-        />
+      {props.game.submissions.map((submission) => (
+        <div key={submission.id} className="flex gap-1">
+          {submission.cards.map((card, index) => (
+            <GameCard
+              key={card.id}
+              cardId={card.id}
+              value={card.card_value}
+              onCardClick={() => onSubmissionClick(submission)}
+              isDisabled={false}
+              isWinningCard={winningSubmissionIds.includes(submission.id)}
+              badge={submissionBadge(submission, index)}
+            />
+          ))}
+        </div>
       ))}
     </div>
   );
 }
 
+function submissionBadge(submission: Submission, index: number) {
+  // Vote counts are shown on the first card once they are revealed
+  if (index === 0 && submission.votes) {
+    return `${submission.votes} ${submission.votes === 1 ? "vote" : "votes"}`;
+  }
+
+  return submission.cards.length > 1 ? `${index + 1}` : undefined;
+}
+
 interface RenderBlackCardProps {
   card: Card | null;
 }
@@ -85,6 +113,7 @@ function RenderBlackCard(props: RenderBlackCardProps) {
       onCardClick={() => {}}
       isDisabled
       isBlack
+      badge={getPickCount(card) > 1 ? `Pick ${getPickCount(card)}` : undefined}
     />
   );
 }
@@ -5,7 +5,7 @@ import { useAuth } from "@/context/AuthContext";
 import { AvatarImage } from "@radix-ui/react-avatar";
 import { Crown, Users } from "lucide-react";
 import { useState } from "react";
-import { useParams } from "react-router";
+import { useNavigate, useParams } from "react-router";
 import useWebSocket from "react-use-websocket";
 import { SiteHeader } from "../site-header";
 import { getWebSocketUrl } from "@/lib/websocket";
@@ -22,6 +22,7 @@ export default function GameComponent() {
   const [chatMessages, setChatMessages] = useState<any[]>([]);
   const [scrollingEmojis, setScrollingEmojis] = useState<Array<{ id: string, emoji: string, timestamp: number, rightOffset: number }>>([]);
   const { user } = useAuth();
+  const navigate = useNavigate();
 
   const handleEmojiClick = (emoji: string) => {
     const newEmoji = {
@@ -52,6 +53,12 @@ export default function GameComponent() {
         debugger;
         setGame(message.payload);
         break;
+      case "CLOCK_UPDATE":
+        setGame(prev => prev && {
+          ...prev,
+          next_auto_progress_at: message.payload.next_auto_progress_at,
+        });
+        break;
       case "CHAT_MESSAGE":
         setChatMessages([...chatMessages, message.payload]);
         break;
@@ -66,6 +73,20 @@ export default function GameComponent() {
 
         setScrollingEmojis(prev => [...prev, newEmoji]);
         break;
+      case "PLAYER_KICKED":
+        navigate("/games");
+        break;
+      case "REMATCH_STARTED":
+        setGame(null);
+        setChatMessages([]);
+        navigate(`/games/${message.payload.rematch_game_id}`);
+        break;
+      case "COMMAND_ERROR":
+        console.error(
+          `${message.payload.type} (${message.payload.request_id}) rejected:`,
+          message.payload.error
+        );
+        break;
       default:
     }
   };
@@ -88,6 +109,7 @@ export default function GameComponent() {
   const handleEmojiClicked = (emoji: string) => {
     sendMessage(
       JSON.stringify({
+        request_id: crypto.randomUUID(),
         type: "EmojiClicked",
         payload: {
           emoji,
@@ -101,6 +123,7 @@ export default function GameComponent() {
   const handleJoinGame = () => {
     sendMessage(
       JSON.stringify({
+        request_id: crypto.randomUUID(),
         type: "JoinedGame",
         payload: {
           //TODO remove user_id and use the claim on the backend
@@ -111,38 +134,56 @@ export default function GameComponent() {
     );
   };
 
-  const handleBeginGame = () => {
+  // Forcing begins the game without waiting for everyone to be ready
+  const handleBeginGame = (force: boolean) => {
     sendMessage(
       JSON.stringify({
+        request_id: crypto.randomUUID(),
         type: "GameBegins",
         payload: {
           //TODO remove user_id and use the claim on the backend
           game_id: gameId,
           user_id: user?.user_id,
+          force,
         },
       })
     );
   };
 
-  const handlePlayCard = (card: Card) => {
+  const handlePlayCards = (cards: Card[]) => {
     sendMessage(
       JSON.stringify({
+        request_id: crypto.randomUUID(),
         type: "CardPlayed",
         payload: {
-          card_id: card.id,
+          card_ids: cards.map((card) => card.id),
           game_id: gameId,
         },
       })
     );
   };
 
-  const handlePickWinningCard = (cardId: string) => {
+  const handlePickWinningSubmission = (submissionId: string) => {
     sendMessage(
       JSON.stringify({
+        request_id: crypto.randomUUID(),
         type: "JudgeChoseWinningCard",
         payload: {
-          card_id: cardId,
+          submission_id: submissionId,
+          game_id: gameId,
+        },
+      })
+    );
+  };
+
+  const handleVote = (submissionId: string) => {
+    sendMessage(
+      JSON.stringify({
+        request_id: crypto.randomUUID(),
+        type: "VoteCast",
+        payload: {
           game_id: gameId,
+          submission_id: submissionId,
         },
       })
     );
@@ -151,6 +192,7 @@ export default function GameComponent() {
   const handleContinueRound = () => {
     sendMessage(
       JSON.stringify({
+        request_id: crypto.randomUUID(),
         type: "RoundContinued",
         payload: {
           game_id: gameId,
@@ -159,6 +201,120 @@ export default function GameComponent() {
     );
   };
 
+  const handleRebootUniverse = () => {
+    sendMessage(
+      JSON.stringify({
+        request_id: crypto.randomUUID(),
+        type: "UniverseRebooted",
+        payload: {
+          game_id: gameId,
+        },
+      })
+    );
+  };
+
+  const handleMulligan = () => {
+    sendMessage(
+      JSON.stringify({
+        request_id: crypto.randomUUID(),
+        type: "HandMulliganed",
+        payload: {
+          game_id: gameId,
+        },
+      })
+    );
+  };
+
+  const handleLeaveGame = () => {
+    sendMessage(
+      JSON.stringify({
+        request_id: crypto.randomUUID(),
+        type: "PlayerLeft",
+        payload: {
+          game_id: gameId,
+        },
+      })
+    );
+
+    navigate("/games");
+  };
+
+  const handleWatchGame = () => {
+    sendMessage(
+      JSON.stringify({
+        request_id: crypto.randomUUID(),
+        type: "SpectatorJoined",
+        payload: {
+          game_id: gameId,
+        },
+      })
+    );
+  };
+
+  const handleModeratePlayer = (action: ModerationAction, playerId: string) => {
+    sendMessage(
+      JSON.stringify({
+        request_id: crypto.randomUUID(),
+        type: action,
+        payload: {
+          game_id: gameId,
+          player_id: playerId,
+        },
+      })
+    );
+  };
+
+  const handleOwnerAction = (action: OwnerAction) => {
+    sendMessage(
+      JSON.stringify({
+        request_id: crypto.randomUUID(),
+        type: action,
+        payload: {
+          game_id: gameId,
+        },
+      })
+    );
+  };
+
+  const handleReady = (ready: boolean) => {
+    sendMessage(
+      JSON.stringify({
+        request_id: crypto.randomUUID(),
+        type: ready ? "PlayerReady" : "PlayerUnready",
+        payload: {
+          game_id: gameId,
+        },
+      })
+    );
+  };
+
+  const handleChangeSettings = (settings: GameSettingsUpdate) => {
+    sendMessage(
+      JSON.stringify({
+        request_id: crypto.randomUUID(),
+        type: "SettingsChanged",
+        payload: {
+          game_id: gameId,
+          ...settings,
+        },
+      })
+    );
+  };
+
+  // An empty subject deals the rematch from the same cards
+  const handleRematch = (subject: string) => {
+    sendMessage(
+      JSON.stringify({
+        request_id: crypto.randomUUID(),
+        type: "RematchCreated",
+        payload: {
+          game_id: gameId,
+          subject,
+        },
+      })
+    );
+  };
+
   if (readyState !== 1 || !game) {
     return (
       <div className="min-h-screen flex items-center justify-center">
@@ -180,6 +336,8 @@ export default function GameComponent() {
             isActive={game.status === "InProgress"}
             nextAutoProgressAt={game.next_auto_progress_at}
             roundState={game.round_status}
+            isPaused={game.is_paused}
+            pausedAt={game.paused_at}
           />
         </div>
         <div className="container mx-auto p-6">
@@ -188,7 +346,14 @@ export default function GameComponent() {
               <PlayerList
                 handleJoinGame={handleJoinGame}
                 handleBeginGame={handleBeginGame}
+                handleReady={handleReady}
                 handleContinueRound={handleContinueRound}
+                handleLeaveGame={handleLeaveGame}
+                handleWatchGame={handleWatchGame}
+                handleModeratePlayer={handleModeratePlayer}
+                handleOwnerAction={handleOwnerAction}
+                handleRematch={handleRematch}
+                handleChangeSettings={handleChangeSettings}
                 game={game}
               />
             </div>
@@ -197,7 +362,7 @@ export default function GameComponent() {
             <div className="col-span-3 lg:col-span-2 order-2 lg:order-1">
               {/* Board - make it larger and responsive */}
               <div className="flex flex-col gap-4">
-                {renderGameBoard(game, handlePickWinningCard)}
+                {renderGameBoard(game, handlePickWinningSubmission, handleVote)}
               </div>
             </div>
           </div>
@@ -205,7 +370,12 @@ export default function GameComponent() {
       </div>
 
       {/* Player's hand */}
-      <PlayerHand game={game} handlePlayCard={handlePlayCard} />
+      <PlayerHand
+        game={game}
+        handlePlayCards={handlePlayCards}
+        handleRebootUniverse={handleRebootUniverse}
+        handleMulligan={handleMulligan}
+      />
 
       {/* Emoji List */}
       <div className="mx-auto container p-6">
@@ -224,19 +394,26 @@ export default function GameComponent() {
 
 function renderGameBoard(
   game: Game,
-  handlePickWinningCard: (cardId: string) => void
+  handlePickWinningSubmission: (submissionId: string) => void,
+  handleVote: (submissionId: string) => void
 ) {
   if (game.status === "Setup") {
     return <JoinGameGrid game={game} />;
   }
 
-  return <Board game={game} handlePickWinningCard={handlePickWinningCard} />;
+  return (
+    <Board
+      game={game}
+      handlePickWinningSubmission={handlePickWinningSubmission}
+      handleVote={handleVote}
+    />
+  );
 }
 
 function JoinGameGrid(props: { game: Game }) {
   const players = props.game.players;
 
-  const joinableSlots = props.game.max_player_count - props.game.players.length;
+  const joinableSlots = Math.max(props.game.max_player_count - props.game.players.length, 0);
   const emptySlots = new Array(joinableSlots).fill(null);
 
   const playerGrid = [...players, ...emptySlots];
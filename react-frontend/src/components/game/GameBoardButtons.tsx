@@ -1,5 +1,9 @@
 import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
 import { useAuth } from "@/context/AuthContext";
+import { Check, Pause, Play, RotateCcw, SkipForward } from "lucide-react";
+import { useState } from "react";
+import { Link } from "react-router";
 
 interface ContinueRoundButtonProps {
   game: Game;
@@ -11,7 +15,8 @@ function ContinueRoundButton(props: ContinueRoundButtonProps) {
   const isGameRoundOver =
     props.game.round_status === "JudgeChoseWinningCard";
 
-  const shouldRenderContinueRoundButton = isGameInProgress && isGameRoundOver;
+  const shouldRenderContinueRoundButton =
+    isGameInProgress && isGameRoundOver && !props.game.is_paused;
 
   if (!shouldRenderContinueRoundButton) {
     return null;
@@ -35,7 +40,8 @@ function JoinGameButton(props: JoinGameButtonProps) {
   const isUserInGame = props.game.players.some(
     (player: Player) => player.user_id === user?.user_id
   );
-  const shouldRenderJoinGameButton = !isUserInGame;
+  const isGameInSetupState = props.game.status === "Setup";
+  const shouldRenderJoinGameButton = !isUserInGame && isGameInSetupState;
 
   if (!shouldRenderJoinGameButton) {
     return null;
@@ -54,53 +60,284 @@ function JoinGameButton(props: JoinGameButtonProps) {
 
 interface BeginGameButtonProps {
   game: Game;
-  handleBeginGame: () => void;
+  handleBeginGame: (force: boolean) => void;
 }
 
+// The owner begins the game once everyone else is ready, or can begin it
+// anyway without waiting for them
 function BeginGameButton(props: BeginGameButtonProps) {
-  const isGameReadyToBegin = props.game.players.length > 1;
+  const { user } = useAuth();
+
+  const isOwner = props.game.players.some(
+    (player: Player) => player.user_id === user?.user_id && player.is_owner
+  );
+  const isGameReadyToBegin =
+    props.game.players.length >= props.game.min_player_count;
   const isGameInSetupState = props.game.status === "Setup";
 
-  const shouldRenderBeginGameButton = isGameReadyToBegin && isGameInSetupState;
+  const shouldRenderBeginGameButton =
+    isOwner && isGameReadyToBegin && isGameInSetupState;
 
   if (!shouldRenderBeginGameButton) {
     return null;
   }
 
+  const unreadyCount = props.game.players.filter(
+    (player: Player) => !player.is_bot && !player.is_owner && !player.is_ready
+  ).length;
+
+  if (unreadyCount === 0) {
+    return (
+      <Button onClick={() => props.handleBeginGame(false)} variant="secondary" size="sm">
+        Begin game
+      </Button>
+    );
+  }
+
+  return (
+    <div className="flex flex-col gap-1">
+      <Button variant="secondary" size="sm" disabled>
+        Waiting for {unreadyCount} {unreadyCount === 1 ? "player" : "players"}
+      </Button>
+      <Button onClick={() => props.handleBeginGame(true)} variant="ghost" size="sm">
+        Begin anyway
+      </Button>
+    </div>
+  );
+}
+
+interface ReadyButtonProps {
+  game: Game;
+  handleReady: (ready: boolean) => void;
+}
+
+// Players tell the owner they are ready while waiting in the lobby
+function ReadyButton(props: ReadyButtonProps) {
+  const { user } = useAuth();
+
+  const player = props.game.players.find(
+    (player: Player) => player.user_id === user?.user_id
+  );
+  const isGameInSetupState = props.game.status === "Setup";
+
+  if (!player || player.is_owner || !isGameInSetupState) {
+    return null;
+  }
+
+  return (
+    <Button
+      onClick={() => props.handleReady(!player.is_ready)}
+      variant={player.is_ready ? "ghost" : "secondary"}
+      size="sm"
+    >
+      {player.is_ready && <Check className="h-4 w-4" />}
+      {player.is_ready ? "Ready" : "Ready up"}
+    </Button>
+  );
+}
+
+interface WatchGameButtonProps {
+  game: Game;
+  handleWatchGame: () => void;
+}
+
+function WatchGameButton(props: WatchGameButtonProps) {
+  const { user } = useAuth();
+
+  const isUserInGame = props.game.players.some(
+    (player: Player) => player.user_id === user?.user_id
+  );
+  const isGameInSetupState = props.game.status === "Setup";
+
+  if (!isUserInGame || !isGameInSetupState) {
+    return null;
+  }
+
+  return (
+    <Button onClick={props.handleWatchGame} variant="ghost" size="sm">
+      Watch instead
+    </Button>
+  );
+}
+
+interface LeaveGameButtonProps {
+  game: Game;
+  handleLeaveGame: () => void;
+}
+
+function LeaveGameButton(props: LeaveGameButtonProps) {
+  const { user } = useAuth();
+
+  const isUserInGame = [...props.game.players, ...props.game.spectators].some(
+    (player: Player) => player.user_id === user?.user_id
+  );
+
+  if (!isUserInGame) {
+    return null;
+  }
+
   return (
-    <Button onClick={props.handleBeginGame} variant="secondary" size="sm">
-      Begin game
+    <Button onClick={props.handleLeaveGame} variant="ghost" size="sm">
+      Leave game
     </Button>
   );
 }
 
+interface OwnerControlsProps {
+  game: Game;
+  handleOwnerAction: (action: OwnerAction) => void;
+}
+
+// Only the owner can pause the game or move a stuck round on
+function OwnerControls(props: OwnerControlsProps) {
+  const { user } = useAuth();
+
+  const isOwner = props.game.players.some(
+    (player: Player) => player.user_id === user?.user_id && player.is_owner
+  );
+  const isGameInProgress = props.game.status === "InProgress";
+
+  if (!isOwner || !isGameInProgress) {
+    return null;
+  }
+
+  return (
+    <div className="flex gap-2">
+      {props.game.is_paused ? (
+        <Button
+          onClick={() => props.handleOwnerAction("GameResumed")}
+          variant="secondary"
+          size="sm"
+        >
+          <Play className="h-4 w-4" />
+          Resume
+        </Button>
+      ) : (
+        <>
+          <Button
+            onClick={() => props.handleOwnerAction("GamePaused")}
+            variant="ghost"
+            size="sm"
+          >
+            <Pause className="h-4 w-4" />
+            Pause
+          </Button>
+          <Button
+            onClick={() => props.handleOwnerAction("RoundForceAdvanced")}
+            variant="ghost"
+            size="sm"
+            title="Move the round on without waiting"
+          >
+            <SkipForward className="h-4 w-4" />
+            Skip
+          </Button>
+        </>
+      )}
+    </div>
+  );
+}
+
+interface RematchButtonProps {
+  game: Game;
+  handleRematch: (subject: string) => void;
+}
+
+// The owner starts a rematch once the game is finished. Everyone still in the
+// room is moved to it, and anyone who comes back later can follow the link.
+function RematchButton(props: RematchButtonProps) {
+  const { user } = useAuth();
+  const [subject, setSubject] = useState("");
+
+  const isOwner = props.game.players.some(
+    (player: Player) => player.user_id === user?.user_id && player.is_owner
+  );
+  const isGameFinished = props.game.status === "Finished";
+
+  if (!isGameFinished) {
+    return null;
+  }
+
+  if (props.game.rematch_game_id) {
+    return (
+      <Button variant="secondary" size="sm" asChild>
+        <Link to={`/games/${props.game.rematch_game_id}`}>Go to rematch</Link>
+      </Button>
+    );
+  }
+
+  if (!isOwner) {
+    return null;
+  }
+
+  return (
+    <div className="flex gap-2">
+      <Input
+        value={subject}
+        onChange={(event) => setSubject(event.target.value)}
+        placeholder="Same cards, or a new subject"
+        className="h-8"
+      />
+      <Button
+        onClick={() => props.handleRematch(subject.trim())}
+        variant="secondary"
+        size="sm"
+      >
+        <RotateCcw className="h-4 w-4" />
+        Rematch
+      </Button>
+    </div>
+  );
+}
+
 interface GameButtonsProps {
   game: Game;
-  handleBeginGame: () => void;
+  handleBeginGame: (force: boolean) => void;
+  handleReady: (ready: boolean) => void;
   handleJoinGame: () => void;
   handleContinueRound: () => void;
+  handleLeaveGame: () => void;
+  handleWatchGame: () => void;
+  handleOwnerAction: (action: OwnerAction) => void;
+  handleRematch: (subject: string) => void;
 }
 
 export function GameButtons(props: GameButtonsProps) {
   const { user } = useAuth();
-  const isGameReadyToBegin = props.game.players.length > 1;
+  const isGameReadyToBegin =
+    props.game.players.length >= props.game.min_player_count;
   const isGameInSetupState = props.game.status === "Setup";
   const isUserInGame = props.game.players.some(
     (player: Player) => player.user_id === user?.user_id
   );
+  const isUserSpectating = props.game.spectators.some(
+    (spectator: Player) => spectator.user_id === user?.user_id
+  );
+  const isUserOwner = props.game.players.some(
+    (player: Player) => player.user_id === user?.user_id && player.is_owner
+  );
 
   const isGameInProgress = props.game.status === "InProgress";
   const isGameRoundOver =
     props.game.round_status === "JudgeChoseWinningCard";
 
-  const shouldRenderContinueRoundButton = isGameInProgress && isGameRoundOver;
-  const shouldRenderBeginGameButton = isGameReadyToBegin && isGameInSetupState;
-  const shouldRenderJoinGameButton = !isUserInGame;
+  const shouldRenderContinueRoundButton =
+    isGameInProgress && isGameRoundOver && !props.game.is_paused;
+  const shouldRenderOwnerControls = isGameInProgress && isUserOwner;
+  const shouldRenderRematchButton =
+    props.game.status === "Finished" &&
+    (isUserOwner || !!props.game.rematch_game_id);
+  const shouldRenderBeginGameButton =
+    isUserOwner && isGameReadyToBegin && isGameInSetupState;
+  const shouldRenderJoinGameButton = !isUserInGame && isGameInSetupState;
+  const shouldRenderLeaveGameButton = isUserInGame || isUserSpectating;
 
   if (
     !shouldRenderBeginGameButton &&
     !shouldRenderContinueRoundButton &&
-    !shouldRenderJoinGameButton
+    !shouldRenderOwnerControls &&
+    !shouldRenderRematchButton &&
+    !shouldRenderJoinGameButton &&
+    !shouldRenderLeaveGameButton
   ) {
     return null;
   }
@@ -112,10 +349,27 @@ export function GameButtons(props: GameButtonsProps) {
         game={props.game}
         handleBeginGame={props.handleBeginGame}
       />
+      <ReadyButton game={props.game} handleReady={props.handleReady} />
       <ContinueRoundButton
         game={props.game}
         handleContinueRound={props.handleContinueRound}
       />
+      <OwnerControls
+        game={props.game}
+        handleOwnerAction={props.handleOwnerAction}
+      />
+      <RematchButton
+        game={props.game}
+        handleRematch={props.handleRematch}
+      />
+      <WatchGameButton
+        game={props.game}
+        handleWatchGame={props.handleWatchGame}
+      />
+      <LeaveGameButton
+        game={props.game}
+        handleLeaveGame={props.handleLeaveGame}
+      />
     </div>
   );
 }
@@ -15,6 +15,11 @@ interface CardProps {
    */
   selected?: boolean;
 
+  /**
+   * Short label shown in the top left corner, e.g. the order a card was picked in
+   */
+  badge?: string;
+
   /**
    * Force the aspect ratio of the card
    */
@@ -30,6 +35,7 @@ export default function GameCard(props: CardProps) {
     isWinningCard,
     onCardClick,
     selected,
+    badge,
     scalable = true,
   } = props;
 
@@ -53,6 +59,11 @@ export default function GameCard(props: CardProps) {
       aria-disabled={isDisabled ? true : false}
       aria-selected={selected}
     >
+      {badge && (
+        <span className="absolute -left-2 -top-2 z-10 flex h-6 min-w-6 px-1.5 items-center justify-center rounded-full bg-primary text-xs font-bold text-white">
+          {badge}
+        </span>
+      )}
       {isWinningCard && (
         <span className="absolute -right-3 -top-4 z-10 rotate-30 text-yellow-400 animate-bounce">
           <Crown />
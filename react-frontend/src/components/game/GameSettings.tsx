@@ -0,0 +1,287 @@
+import { Button } from "@/components/ui/button";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+  DialogTrigger,
+} from "@/components/ui/dialog";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Slider } from "@/components/ui/slider";
+import { Switch } from "@/components/ui/switch";
+import { useAuth } from "@/context/AuthContext";
+import { Settings } from "lucide-react";
+import { useState } from "react";
+
+interface GameSettingsProps {
+  game: Game;
+  handleChangeSettings: (settings: GameSettingsUpdate) => void;
+}
+
+const timerFields: { key: keyof GameTimers; label: string }[] = [
+  { key: "picking_seconds", label: "Picking" },
+  { key: "judging_seconds", label: "Judging" },
+  { key: "round_pause_seconds", label: "Between rounds" },
+];
+
+// An empty timer falls back to the server's timeout
+function toTimers(timers: Record<keyof GameTimers, string>): GameTimers {
+  const result: GameTimers = {};
+
+  for (const { key } of timerFields) {
+    if (timers[key].trim() !== "") {
+      result[key] = Math.max(0, Number(timers[key]));
+    }
+  }
+
+  return result;
+}
+
+function fromTimers(timers: GameTimers | undefined): Record<keyof GameTimers, string> {
+  return {
+    picking_seconds: timers?.picking_seconds?.toString() ?? "",
+    judging_seconds: timers?.judging_seconds?.toString() ?? "",
+    round_pause_seconds: timers?.round_pause_seconds?.toString() ?? "",
+  };
+}
+
+// The owner can change the game's settings until it begins. Everyone in the
+// lobby sees the new settings as soon as they are saved.
+export default function GameSettings(props: GameSettingsProps) {
+  const { user } = useAuth();
+  const [open, setOpen] = useState(false);
+  const [name, setName] = useState(props.game.name);
+  const [winnerCount, setWinnerCount] = useState(props.game.winner_count);
+  const [maxPlayerCount, setMaxPlayerCount] = useState(props.game.max_player_count);
+  const [handSize, setHandSize] = useState(props.game.hand_size || 10);
+  const [timers, setTimers] = useState(fromTimers(props.game.timers));
+  const [houseRules, setHouseRules] = useState<HouseRules>(props.game.house_rules);
+  const [autoStart, setAutoStart] = useState(props.game.auto_start);
+  const [isPrivate, setIsPrivate] = useState(props.game.visibility === "Private");
+  const [password, setPassword] = useState("");
+  const [removePassword, setRemovePassword] = useState(false);
+
+  const isOwner = props.game.players.some(
+    (player: Player) => player.user_id === user?.user_id && player.is_owner
+  );
+
+  if (!isOwner || props.game.status !== "Setup") {
+    return null;
+  }
+
+  // The form starts from the game's current settings each time it is opened
+  const handleOpenChange = (open: boolean) => {
+    if (open) {
+      setName(props.game.name);
+      setWinnerCount(props.game.winner_count);
+      setMaxPlayerCount(props.game.max_player_count);
+      setHandSize(props.game.hand_size || 10);
+      setTimers(fromTimers(props.game.timers));
+      setHouseRules(props.game.house_rules);
+      setAutoStart(props.game.auto_start);
+      setIsPrivate(props.game.visibility === "Private");
+      setPassword("");
+      setRemovePassword(false);
+    }
+
+    setOpen(open);
+  };
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault();
+
+    const settings: GameSettingsUpdate = {
+      name: name.trim(),
+      winner_count: winnerCount,
+      max_player_count: maxPlayerCount,
+      hand_size: handSize,
+      timers: toTimers(timers),
+      house_rules: houseRules,
+      auto_start: autoStart,
+      visibility: isPrivate ? "Private" : "Public",
+    };
+
+    if (isPrivate && password !== "") {
+      settings.password = password;
+    } else if (isPrivate && removePassword) {
+      settings.password = "";
+    }
+
+    props.handleChangeSettings(settings);
+    setOpen(false);
+  };
+
+  const humanPlayers = props.game.players.filter((player) => !player.is_bot).length;
+
+  return (
+    <Dialog open={open} onOpenChange={handleOpenChange}>
+      <DialogTrigger asChild>
+        <Button variant="outline" className="w-full mb-4">
+          <Settings className="h-4 w-4" />
+          Game settings
+        </Button>
+      </DialogTrigger>
+      <DialogContent className="sm:max-w-[425px] max-h-[90vh] overflow-y-auto">
+        <DialogHeader>
+          <DialogTitle>Game settings</DialogTitle>
+          <DialogDescription>
+            Settings can be changed until the game begins.
+          </DialogDescription>
+        </DialogHeader>
+        <form className="space-y-5" onSubmit={handleSubmit}>
+          <div className="space-y-2">
+            <Label htmlFor="settings-name">Game name</Label>
+            <Input
+              id="settings-name"
+              value={name}
+              onChange={(e) => setName(e.target.value)}
+            />
+          </div>
+
+          <div className="space-y-2">
+            <Label>Winning count: {winnerCount}</Label>
+            <Slider
+              min={3}
+              max={20}
+              step={1}
+              value={[winnerCount]}
+              onValueChange={(vals) => setWinnerCount(vals[0])}
+            />
+          </div>
+
+          <div className="space-y-2">
+            <Label>Max players: {maxPlayerCount}</Label>
+            <Slider
+              min={Math.max(props.game.min_player_count || 3, humanPlayers)}
+              max={6}
+              step={1}
+              value={[maxPlayerCount]}
+              onValueChange={(vals) => setMaxPlayerCount(vals[0])}
+            />
+          </div>
+
+          <div className="space-y-2">
+            <Label>Hand size: {handSize}</Label>
+            <Slider
+              min={5}
+              max={15}
+              step={1}
+              value={[handSize]}
+              onValueChange={(vals) => setHandSize(vals[0])}
+            />
+          </div>
+
+          <div className="space-y-2">
+            <Label>Timers (seconds)</Label>
+            <p className="text-xs text-muted-foreground">
+              Leave empty for the default, or 0 to take as long as you like.
+            </p>
+            <div className="grid grid-cols-3 gap-2">
+              {timerFields.map(({ key, label }) => (
+                <div key={key} className="space-y-1">
+                  <span className="text-xs">{label}</span>
+                  <Input
+                    type="number"
+                    min={0}
+                    value={timers[key]}
+                    placeholder="Default"
+                    onChange={(e) => setTimers({ ...timers, [key]: e.target.value })}
+                  />
+                </div>
+              ))}
+            </div>
+          </div>
+
+          <div className="space-y-3 rounded-lg border p-3">
+            <div className="flex items-center justify-between">
+              <Label htmlFor="settings-rando">Rando Cardrissian</Label>
+              <Switch
+                id="settings-rando"
+                checked={houseRules.rando_cardrissian}
+                onCheckedChange={(checked) =>
+                  setHouseRules({ ...houseRules, rando_cardrissian: checked })
+                }
+              />
+            </div>
+            <div className="flex items-center justify-between">
+              <Label htmlFor="settings-reboot">Rebooting the Universe</Label>
+              <Switch
+                id="settings-reboot"
+                checked={houseRules.rebooting_the_universe}
+                onCheckedChange={(checked) =>
+                  setHouseRules({ ...houseRules, rebooting_the_universe: checked })
+                }
+              />
+            </div>
+            <div className="flex items-center justify-between">
+              <Label htmlFor="settings-mulligan">Mulligan</Label>
+              <Switch
+                id="settings-mulligan"
+                checked={houseRules.mulligan}
+                onCheckedChange={(checked) =>
+                  setHouseRules({ ...houseRules, mulligan: checked })
+                }
+              />
+            </div>
+          </div>
+
+          <div className="flex items-center justify-between rounded-lg border p-3">
+            <div className="space-y-0.5">
+              <Label htmlFor="settings-auto-start">Start when everyone is ready</Label>
+              <p className="text-xs text-muted-foreground">
+                Saving settings asks everyone to ready up again.
+              </p>
+            </div>
+            <Switch
+              id="settings-auto-start"
+              checked={autoStart}
+              onCheckedChange={setAutoStart}
+            />
+          </div>
+
+          <div className="space-y-3 rounded-lg border p-3">
+            <div className="flex items-center justify-between">
+              <Label htmlFor="settings-private">Private game</Label>
+              <Switch
+                id="settings-private"
+                checked={isPrivate}
+                onCheckedChange={setIsPrivate}
+              />
+            </div>
+            {isPrivate && (
+              <Input
+                type="password"
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+                placeholder={
+                  props.game.has_password
+                    ? "New password, or leave empty to keep it"
+                    : "Password (optional)"
+                }
+              />
+            )}
+            {isPrivate && props.game.has_password && password === "" && (
+              <div className="flex items-center justify-between">
+                <Label htmlFor="settings-remove-password">Remove password</Label>
+                <Switch
+                  id="settings-remove-password"
+                  checked={removePassword}
+                  onCheckedChange={setRemovePassword}
+                />
+              </div>
+            )}
+          </div>
+
+          <DialogFooter>
+            <Button type="submit" disabled={name.trim() === ""}>
+              Save settings
+            </Button>
+          </DialogFooter>
+        </form>
+      </DialogContent>
+    </Dialog>
+  );
+}
@@ -1,17 +1,18 @@
 import GameCard from "@/components/game/GameCard";
 import { useAuth } from "@/context/AuthContext";
 import { cn } from "@/lib/utils";
-import { Check, X } from "lucide-react";
+import { Check, RefreshCw, Shuffle, X } from "lucide-react";
 import { useState } from "react";
 import { Button } from "../ui/button";
 
 export default function Hand(props: {
   game: Game;
-  handlePlayCard: (card: Card) => void;
+  handlePlayCards: (cards: Card[]) => void;
+  handleRebootUniverse: () => void;
+  handleMulligan: () => void;
 }) {
-  const [selectedCardId, setSelectedCardId] = useState<string | undefined>(
-    undefined
-  );
+  // Selection order is the order the cards fill the blanks
+  const [selectedCardIds, setSelectedCardIds] = useState<string[]>([]);
   const { user } = useAuth();
 
   const player = props.game.players.find((p) => p.user_id === user?.user_id);
@@ -26,28 +27,54 @@ export default function Hand(props: {
     return null
   }
 
-  const disabled = Boolean(player.placed_card) || playerIsJudge;
+  const disabled = player.has_placed_card || playerIsJudge;
+  const pick = getPickCount(props.game.black_card);
+
+  // Hands can only be swapped before the player has picked this round
+  const canRedraw =
+    props.game.round_status === "PlayersPickingCard" &&
+    !player.has_placed_card;
+  const canRebootUniverse =
+    canRedraw && props.game.house_rules?.rebooting_the_universe && player.score > 0;
+  const canMulligan =
+    canRedraw && props.game.house_rules?.mulligan && !player.has_mulliganed;
+
+  const onRedraw = (redraw: () => void) => {
+    setSelectedCardIds([]);
+    redraw();
+  };
 
   const onSelectCard = (cardId: string) => {
-    const card = player.deck.find((card: Card) => card.id === cardId);
+    setSelectedCardIds((ids) => {
+      if (ids.includes(cardId)) {
+        return ids.filter((id) => id !== cardId);
+      }
 
-    setSelectedCardId((id) => (id === card?.id ? undefined : card?.id));
+      if (ids.length >= pick) {
+        return ids;
+      }
 
-    // if (card) {
-    //   props.handlePlayCard(card);
-    // }
+      return [...ids, cardId];
+    });
   };
 
-  const onChooseChard = () => {
-    const card = player.deck.find((card: Card) => card.id === selectedCardId);
-
-    console.log({ card });
+  const onChooseCards = () => {
+    const cards = selectedCardIds
+      .map((id) => player.deck.find((card: Card) => card.id === id))
+      .filter((card): card is Card => Boolean(card));
 
-    if (card) {
-      props.handlePlayCard(card);
+    if (cards.length === pick) {
+      props.handlePlayCards(cards);
+      setSelectedCardIds([]);
     }
   };
 
+  const selectionOrder = (cardId: string) => {
+    const index = selectedCardIds.indexOf(cardId);
+
+    return pick > 1 && index >= 0 ? `${index + 1}` : undefined;
+  };
+
   const rotations = [
     "rotate-295",
     "rotate-315",
@@ -74,20 +101,43 @@ export default function Hand(props: {
             </span>
           ) : (
             <>
-              <Button disabled={!selectedCardId} onClick={onChooseChard}>
+              <Button
+                disabled={selectedCardIds.length !== pick}
+                onClick={onChooseCards}
+              >
                 <Check />
-                Choose
+                {pick > 1
+                  ? `Choose ${selectedCardIds.length}/${pick}`
+                  : "Choose"}
               </Button>
               <Button
                 variant="outline"
-                disabled={!selectedCardId}
-                onClick={() => setSelectedCardId(undefined)}
+                disabled={selectedCardIds.length === 0}
+                onClick={() => setSelectedCardIds([])}
               >
                 <X />
                 Cancel
               </Button>
             </>
           )}
+          {canMulligan && (
+            <Button
+              variant="outline"
+              onClick={() => onRedraw(props.handleMulligan)}
+            >
+              <Shuffle />
+              Mulligan
+            </Button>
+          )}
+          {canRebootUniverse && (
+            <Button
+              variant="outline"
+              onClick={() => onRedraw(props.handleRebootUniverse)}
+            >
+              <RefreshCw />
+              Reboot the Universe (-1 point)
+            </Button>
+          )}
         </div>
       </div>
 
@@ -100,7 +150,8 @@ export default function Hand(props: {
               cardId={card.id}
               value={card.card_value}
               isDisabled={disabled}
-              selected={selectedCardId === card.id}
+              selected={selectedCardIds.includes(card.id)}
+              badge={selectionOrder(card.id)}
             />
           </>
         ))}
@@ -124,7 +175,8 @@ export default function Hand(props: {
               cardId={card.id}
               value={card.card_value}
               isDisabled={disabled}
-              selected={selectedCardId === card.id}
+              selected={selectedCardIds.includes(card.id)}
+              badge={selectionOrder(card.id)}
             />
           </div>
         ))}
@@ -133,3 +185,17 @@ export default function Hand(props: {
     </div>
   );
 }
+
+export function getPickCount(card: Card | null) {
+  if (!card) {
+    return 1;
+  }
+
+  if (card.pick && card.pick > 0) {
+    return Math.min(card.pick, 3);
+  }
+
+  const blanks = card.card_value.match(/_{2,}/g)?.length ?? 0;
+
+  return Math.min(Math.max(blanks, 1), 3);
+}
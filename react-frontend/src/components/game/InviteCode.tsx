@@ -0,0 +1,54 @@
+import { Button } from "@/components/ui/button";
+import { useAuth } from "@/context/AuthContext";
+import { Copy, KeyRound, Lock, RefreshCw } from "lucide-react";
+
+interface InviteCodeProps {
+  game: Game;
+  handleOwnerAction: (action: OwnerAction) => void;
+}
+
+// Private games are joined with their invite code, which anyone in the game
+// can share. Only the owner can swap it for a new one.
+export default function InviteCode(props: InviteCodeProps) {
+  const { user } = useAuth();
+
+  if (props.game.visibility !== "Private" || !props.game.invite_code) {
+    return null;
+  }
+
+  const isOwner = props.game.players.some(
+    (player: Player) => player.user_id === user?.user_id && player.is_owner
+  );
+
+  return (
+    <div className="flex items-center justify-between rounded-lg border p-3 mb-4">
+      <div className="flex items-center gap-2 text-sm">
+        <KeyRound className="h-4 w-4" />
+        <span className="font-mono font-bold">{props.game.invite_code}</span>
+        {props.game.has_password && (
+          <Lock className="h-3 w-3 text-zinc-500" aria-label="Password protected" />
+        )}
+      </div>
+      <div className="flex gap-1">
+        <Button
+          variant="ghost"
+          size="sm"
+          title="Copy invite code"
+          onClick={() => navigator.clipboard.writeText(props.game.invite_code ?? "")}
+        >
+          <Copy className="h-4 w-4" />
+        </Button>
+        {isOwner && (
+          <Button
+            variant="ghost"
+            size="sm"
+            title="Make a new invite code"
+            onClick={() => props.handleOwnerAction("InviteCodeRotated")}
+          >
+            <RefreshCw className="h-4 w-4" />
+          </Button>
+        )}
+      </div>
+    </div>
+  );
+}
@@ -3,14 +3,28 @@ import Players from "@/components/game/Players";
 export default function PlayerList(props: {
     game: Game;
     handleJoinGame: () => void;
-    handleBeginGame: () => void;
+    handleBeginGame: (force: boolean) => void;
+    handleReady: (ready: boolean) => void;
     handleContinueRound: () => void;
+    handleLeaveGame: () => void;
+    handleWatchGame: () => void;
+    handleModeratePlayer: (action: ModerationAction, playerId: string) => void;
+    handleOwnerAction: (action: OwnerAction) => void;
+    handleRematch: (subject: string) => void;
+    handleChangeSettings: (settings: GameSettingsUpdate) => void;
   }) {
     return (
         <Players
           handleJoinGame={props.handleJoinGame}
           handleBeginGame={props.handleBeginGame}
+          handleReady={props.handleReady}
           handleContinueRound={props.handleContinueRound}
+          handleLeaveGame={props.handleLeaveGame}
+          handleWatchGame={props.handleWatchGame}
+          handleModeratePlayer={props.handleModeratePlayer}
+          handleOwnerAction={props.handleOwnerAction}
+          handleRematch={props.handleRematch}
+          handleChangeSettings={props.handleChangeSettings}
           game={props.game}
         />
     );
@@ -1,17 +1,27 @@
 import PlayerBadge from "@/components/game/players/Badge";
+import ModerationMenu from "@/components/game/players/ModerationMenu";
 import { Button } from "@/components/ui/button";
 import { useIsMobile } from "@/hooks/use-mobile";
-import { ChevronDown, ChevronUp, Users } from "lucide-react";
+import { ChevronDown, ChevronUp, Eye, Users } from "lucide-react";
 import { useEffect, useState } from "react";
 import { Avatar, AvatarFallback, AvatarImage } from "../ui/avatar";
 import { Card, CardContent, CardHeader } from "../ui/card";
 import { GameButtons } from "./GameBoardButtons";
+import GameSettings from "./GameSettings";
+import InviteCode from "./InviteCode";
 
 export default function Players(props: {
   game: Game;
   handleJoinGame: () => void;
-  handleBeginGame: () => void;
+  handleBeginGame: (force: boolean) => void;
+  handleReady: (ready: boolean) => void;
   handleContinueRound: () => void;
+  handleLeaveGame: () => void;
+  handleWatchGame: () => void;
+  handleModeratePlayer: (action: ModerationAction, playerId: string) => void;
+  handleOwnerAction: (action: OwnerAction) => void;
+  handleRematch: (subject: string) => void;
+  handleChangeSettings: (settings: GameSettingsUpdate) => void;
 }) {
   const isMobile = useIsMobile();
   const [expanded, setExpanded] = useState(true);
@@ -45,8 +55,23 @@ export default function Players(props: {
       <GameButtons
         game={props.game}
         handleBeginGame={props.handleBeginGame}
+        handleReady={props.handleReady}
         handleContinueRound={props.handleContinueRound}
         handleJoinGame={props.handleJoinGame}
+        handleLeaveGame={props.handleLeaveGame}
+        handleWatchGame={props.handleWatchGame}
+        handleOwnerAction={props.handleOwnerAction}
+        handleRematch={props.handleRematch}
+      />
+
+      <InviteCode
+        game={props.game}
+        handleOwnerAction={props.handleOwnerAction}
+      />
+
+      <GameSettings
+        game={props.game}
+        handleChangeSettings={props.handleChangeSettings}
       />
 
       <Card className="w-full">
@@ -81,10 +106,51 @@ export default function Players(props: {
                     <PlayerBadge player={player} game={props.game} />
                     </div>
                   </div>
-                  <span>{player.score} pts</span>
+                  <div className="flex items-center gap-1">
+                    <span>{player.score} pts</span>
+                    <ModerationMenu
+                      game={props.game}
+                      player={player}
+                      handleModeratePlayer={props.handleModeratePlayer}
+                    />
+                  </div>
                 </div>
               ))}
             </div>
+
+            {props.game.spectators.length > 0 && (
+              <div className="mt-4 space-y-2">
+                <h3 className="text-sm font-medium flex items-center gap-2 text-muted-foreground">
+                  <Eye className="h-4 w-4" /> Watching
+                </h3>
+                {props.game.spectators.map((spectator) => (
+                  <div
+                    key={spectator.user_id}
+                    className="flex justify-between items-center"
+                  >
+                    <div className="flex items-center gap-2">
+                      <Avatar>
+                        <AvatarImage alt={spectator.name} />
+                        <AvatarFallback>{spectator.name.at(0)}</AvatarFallback>
+                      </Avatar>
+                      <div className="flex flex-col">
+                        <p>{spectator.name}</p>
+                        {spectator.is_promoted && (
+                          <span className="text-xs text-muted-foreground">
+                            Joins next round
+                          </span>
+                        )}
+                      </div>
+                    </div>
+                    <ModerationMenu
+                      game={props.game}
+                      player={spectator}
+                      handleModeratePlayer={props.handleModeratePlayer}
+                    />
+                  </div>
+                ))}
+              </div>
+            )}
           </CardContent>
         )}
       </Card>
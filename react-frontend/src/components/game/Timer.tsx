@@ -1,47 +1,40 @@
-import { Clock } from "lucide-react";
-import { useEffect, useState, useRef } from "react";
+import { Clock, Pause } from "lucide-react";
+import { useEffect, useState } from "react";
 
 interface TimerProps {
   isActive?: boolean;
   onReset?: () => void;
   nextAutoProgressAt?: string | null; // ISO timestamp from the game object
   roundState?: string; // Current round state to detect changes
+  isPaused?: boolean;
+  pausedAt?: string; // ISO timestamp the owner paused the game at
 }
 
-export default function Timer({ isActive = false, onReset, nextAutoProgressAt, roundState }: TimerProps) {
+export default function Timer({ isActive = false, onReset, nextAutoProgressAt, roundState, isPaused = false, pausedAt }: TimerProps) {
   const [secondsRemaining, setSecondsRemaining] = useState<number | null>(null);
-  const previousRoundState = useRef<string | undefined>(undefined);
 
-  // Calculate time remaining from nextAutoProgressAt
+  // Calculate time remaining from nextAutoProgressAt, which the server moves
+  // on every time the round state changes
   useEffect(() => {
     if (!nextAutoProgressAt) {
+      setSecondsRemaining(null);
       return;
     }
 
     const calculateTimeRemaining = () => {
-      const now = new Date().getTime();
+      // A paused clock shows whatever was left when it was paused
+      const now = isPaused && pausedAt ? new Date(pausedAt).getTime() : new Date().getTime();
       const targetTime = new Date(nextAutoProgressAt).getTime();
       const diff = Math.max(0, Math.floor((targetTime - now) / 1000));
       setSecondsRemaining(diff);
     };
 
     calculateTimeRemaining();
-  }, [nextAutoProgressAt]);
-
-  // Reset timer to 30 seconds when round state changes (but not on initial render)
-  useEffect(() => {
-    if (roundState && previousRoundState.current !== undefined && previousRoundState.current !== roundState) {
-      // Only reset if we have a previous round state and it's different
-      setSecondsRemaining(30);
-    }
-    
-    // Update the previous round state
-    previousRoundState.current = roundState;
-  }, [roundState]);
+  }, [nextAutoProgressAt, roundState, isPaused, pausedAt]);
 
   // Countdown effect - decrement the timer every second
   useEffect(() => {
-    if (!isActive || secondsRemaining === null || secondsRemaining <= 0) {
+    if (!isActive || isPaused || secondsRemaining === null || secondsRemaining <= 0) {
       return;
     }
 
@@ -53,7 +46,7 @@ export default function Timer({ isActive = false, onReset, nextAutoProgressAt, r
     }, 1000);
 
     return () => clearInterval(interval);
-  }, [isActive, secondsRemaining]);
+  }, [isActive, isPaused, secondsRemaining]);
 
   if (!isActive || secondsRemaining === null || secondsRemaining <= 0) {
     return null;
@@ -67,6 +60,7 @@ export default function Timer({ isActive = false, onReset, nextAutoProgressAt, r
 
   // Color based on time remaining
   const getTimerColor = () => {
+    if (isPaused) return "text-zinc-500";
     if (secondsRemaining <= 10) return "text-red-500";
     if (secondsRemaining <= 20) return "text-yellow-500";
     return "text-blue-500";
@@ -74,7 +68,7 @@ export default function Timer({ isActive = false, onReset, nextAutoProgressAt, r
 
   return (
     <div className={`flex items-center gap-2 text-sm ${getTimerColor()}`}>
-      <Clock className="h-6 w-6" />
+      {isPaused ? <Pause className="h-6 w-6" /> : <Clock className="h-6 w-6" />}
       <span className="font-mono font-bold text-xl">
         {formatTime(secondsRemaining)}
       </span>
@@ -1,5 +1,5 @@
 import { cn } from "@/lib/utils";
-import { Crown, Eye } from "lucide-react";
+import { Bell, Bot, Check, Crown, Eye, VolumeX, WifiOff } from "lucide-react";
 import { Badge } from "@/components/ui/badge";
 
 interface PlayerBadgeProps {
@@ -9,7 +9,51 @@ interface PlayerBadgeProps {
 
 export default function PlayerBadge(props: PlayerBadgeProps) {
   if (props.game.status === "Setup") {
-    return <div></div>;
+    if (!props.player.is_ready) {
+      return <div></div>;
+    }
+
+    return (
+      <div>
+        <Badge variant="outline" className="text-xs">
+          <Check className="h-4 w-4 text-primary" />
+          Ready
+        </Badge>
+      </div>
+    );
+  }
+
+  if (props.player.is_shamed) {
+    return (
+      <div>
+        <Badge variant="destructive" className="text-xs">
+          <Bell className="h-4 w-4" />
+          Shame!
+        </Badge>
+      </div>
+    );
+  }
+
+  if (props.player.is_disconnected) {
+    return (
+      <div>
+        <Badge variant="outline" className="text-xs text-muted-foreground">
+          <WifiOff className="h-4 w-4" />
+          Away
+        </Badge>
+      </div>
+    );
+  }
+
+  if (props.player.is_muted) {
+    return (
+      <div>
+        <Badge variant="outline" className="text-xs text-muted-foreground">
+          <VolumeX className="h-4 w-4" />
+          Muted
+        </Badge>
+      </div>
+    );
   }
 
   if (props.player?.user_id === props.game?.round_winner?.user_id) {
@@ -23,7 +67,28 @@ export default function PlayerBadge(props: PlayerBadgeProps) {
     );
   }
 
-  if (!props.player.is_judge && !props.player.placed_card) {
+  if (
+    props.game.round_status === "PlayersVoting" &&
+    !props.player.is_bot &&
+    !props.player.has_voted
+  ) {
+    return (
+      <div>
+        <Badge
+          variant="secondary"
+          className={cn(
+            "text-xs animate-pulse",
+            "bg-primary/10 text-primary hover:bg-primary/10"
+          )}
+        >
+          <Eye className="h-4 w-4 text-primary" />
+          Voting...
+        </Badge>
+      </div>
+    );
+  }
+
+  if (!props.player.is_judge && !props.player.has_placed_card) {
     return (
       <div>
         <Badge
@@ -40,6 +105,17 @@ export default function PlayerBadge(props: PlayerBadgeProps) {
     );
   }
 
+  if (props.player.is_bot) {
+    return (
+      <div>
+        <Badge variant="outline" className="text-xs">
+          <Bot className="h-4 w-4" />
+          Bot
+        </Badge>
+      </div>
+    );
+  }
+
   if (props.player.is_judge) {
     return (
       <div>
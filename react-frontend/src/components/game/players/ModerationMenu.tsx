@@ -0,0 +1,76 @@
+import { Button } from "@/components/ui/button";
+import {
+  DropdownMenu,
+  DropdownMenuContent,
+  DropdownMenuItem,
+  DropdownMenuTrigger,
+} from "@/components/ui/dropdown-menu";
+import { useAuth } from "@/context/AuthContext";
+import { Ban, MoreVertical, UserPlus, UserX, Volume2, VolumeX } from "lucide-react";
+
+interface ModerationMenuProps {
+  game: Game;
+  player: Player;
+  handleModeratePlayer: (action: ModerationAction, playerId: string) => void;
+}
+
+// Only the owner can moderate, and never themselves or a bot
+export default function ModerationMenu(props: ModerationMenuProps) {
+  const { user } = useAuth();
+
+  const isOwner = props.game.players.some(
+    (player: Player) => player.user_id === user?.user_id && player.is_owner
+  );
+
+  if (!isOwner || props.player.is_bot || props.player.user_id === user?.user_id) {
+    return null;
+  }
+
+  return (
+    <DropdownMenu>
+      <DropdownMenuTrigger asChild>
+        <Button variant="ghost" size="sm" title={`Moderate ${props.player.name}`}>
+          <MoreVertical className="h-4 w-4" />
+        </Button>
+      </DropdownMenuTrigger>
+      <DropdownMenuContent align="end">
+        {props.player.role === "Spectator" && !props.player.is_promoted && (
+          <DropdownMenuItem
+            onClick={() => props.handleModeratePlayer("SpectatorPromoted", props.player.user_id)}
+          >
+            <UserPlus className="h-4 w-4" />
+            Give a seat
+          </DropdownMenuItem>
+        )}
+        {props.player.is_muted ? (
+          <DropdownMenuItem
+            onClick={() => props.handleModeratePlayer("PlayerUnmuted", props.player.user_id)}
+          >
+            <Volume2 className="h-4 w-4" />
+            Unmute
+          </DropdownMenuItem>
+        ) : (
+          <DropdownMenuItem
+            onClick={() => props.handleModeratePlayer("PlayerMuted", props.player.user_id)}
+          >
+            <VolumeX className="h-4 w-4" />
+            Mute
+          </DropdownMenuItem>
+        )}
+        <DropdownMenuItem
+          onClick={() => props.handleModeratePlayer("PlayerKicked", props.player.user_id)}
+        >
+          <UserX className="h-4 w-4" />
+          Kick
+        </DropdownMenuItem>
+        <DropdownMenuItem
+          variant="destructive"
+          onClick={() => props.handleModeratePlayer("PlayerBanned", props.player.user_id)}
+        >
+          <Ban className="h-4 w-4" />
+          Ban
+        </DropdownMenuItem>
+      </DropdownMenuContent>
+    </DropdownMenu>
+  );
+}
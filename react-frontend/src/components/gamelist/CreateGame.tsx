@@ -20,8 +20,16 @@ import {
   FormMessage,
 } from "@/components/ui/form";
 import { Input } from "@/components/ui/input";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
 import { Slider } from "@/components/ui/slider";
 import { Spinner } from "@/components/ui/spinner";
+import { Switch } from "@/components/ui/switch";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { useMutation, useQueryClient } from "@tanstack/react-query";
 import api from "@/lib/axios";
@@ -40,7 +48,13 @@ const formSchema = z.object({
   subject: z.string().min(3, {
     message: "Collection name must be at least 3 characters.",
   }),
-  max_player_count: z.number().min(2).max(6),
+  max_player_count: z.number().min(3).max(6),
+  hand_size: z.number().min(5).max(15),
+  mode: z.enum(["Czar", "Democracy"]),
+  tie_rule: z.enum(["AllWin", "NoPoints", "Random"]),
+  rando_cardrissian: z.boolean().default(false).optional(),
+  rebooting_the_universe: z.boolean().default(false).optional(),
+  mulligan: z.boolean().default(false).optional(),
   winner_count: z.number().min(3).max(50),
   is_private: z.boolean().default(false).optional(),
 
@@ -58,6 +72,12 @@ export function CreateGameDialog() {
       name: "",
       subject: "",
       max_player_count: 3,
+      hand_size: 10,
+      mode: "Czar",
+      tie_rule: "AllWin",
+      rando_cardrissian: false,
+      rebooting_the_universe: false,
+      mulligan: false,
       winner_count: 10,
       is_private: false,
       password: "",
@@ -70,6 +90,14 @@ export function CreateGameDialog() {
         name: form.getValues("name"),
         subject: form.getValues("subject"),
         max_player_count: form.getValues("max_player_count"),
+        hand_size: form.getValues("hand_size"),
+        mode: form.getValues("mode"),
+        tie_rule: form.getValues("tie_rule"),
+        house_rules: {
+          rando_cardrissian: form.getValues("rando_cardrissian") ?? false,
+          rebooting_the_universe: form.getValues("rebooting_the_universe") ?? false,
+          mulligan: form.getValues("mulligan") ?? false,
+        },
         winner_count: form.getValues("winner_count"),
       });
     },
@@ -169,11 +197,11 @@ export function CreateGameDialog() {
                         Max Players: {field.value}
                       </FormLabel>
                       <FormDescription className="text-slate-400">
-                        Set the maximum number of players (2-6)
+                        Set the maximum number of players (3-6)
                       </FormDescription>
                       <FormControl>
                         <Slider
-                          min={2}
+                          min={3}
                           max={6}
                           step={1}
                           defaultValue={[field.value]}
@@ -186,6 +214,32 @@ export function CreateGameDialog() {
                   )}
                 />
 
+                <FormField
+                  control={form.control}
+                  name="hand_size"
+                  render={({ field }) => (
+                    <FormItem>
+                      <FormLabel className="text-slate-300">
+                        Hand Size: {field.value}
+                      </FormLabel>
+                      <FormDescription className="text-slate-400">
+                        Set how many white cards each player holds (5-15)
+                      </FormDescription>
+                      <FormControl>
+                        <Slider
+                          min={5}
+                          max={15}
+                          step={1}
+                          defaultValue={[field.value]}
+                          onValueChange={(vals) => field.onChange(vals[0])}
+                          className="py-4"
+                        />
+                      </FormControl>
+                      <FormMessage />
+                    </FormItem>
+                  )}
+                />
+
                 <FormField
                   control={form.control}
                   name="winner_count"
@@ -212,6 +266,126 @@ export function CreateGameDialog() {
                   )}
                 />
 
+                <FormField
+                  control={form.control}
+                  name="mode"
+                  render={({ field }) => (
+                    <FormItem>
+                      <FormLabel className="text-slate-300">Game Mode</FormLabel>
+                      <FormDescription className="text-slate-400">
+                        Czar has a rotating judge pick the winner. In Democracy everyone
+                        votes for their favorite card instead.
+                      </FormDescription>
+                      <Select onValueChange={field.onChange} defaultValue={field.value}>
+                        <FormControl>
+                          <SelectTrigger className="bg-slate-800 border-slate-700 text-white">
+                            <SelectValue />
+                          </SelectTrigger>
+                        </FormControl>
+                        <SelectContent>
+                          <SelectItem value="Czar">Czar</SelectItem>
+                          <SelectItem value="Democracy">Democracy</SelectItem>
+                        </SelectContent>
+                      </Select>
+                      <FormMessage />
+                    </FormItem>
+                  )}
+                />
+
+                {form.watch("mode") === "Democracy" && (
+                  <FormField
+                    control={form.control}
+                    name="tie_rule"
+                    render={({ field }) => (
+                      <FormItem>
+                        <FormLabel className="text-slate-300">Ties</FormLabel>
+                        <FormDescription className="text-slate-400">
+                          Choose who scores when cards tie for the most votes
+                        </FormDescription>
+                        <Select onValueChange={field.onChange} defaultValue={field.value}>
+                          <FormControl>
+                            <SelectTrigger className="bg-slate-800 border-slate-700 text-white">
+                              <SelectValue />
+                            </SelectTrigger>
+                          </FormControl>
+                          <SelectContent>
+                            <SelectItem value="AllWin">Every tied card scores</SelectItem>
+                            <SelectItem value="NoPoints">Nobody scores</SelectItem>
+                            <SelectItem value="Random">One tied card at random</SelectItem>
+                          </SelectContent>
+                        </Select>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+                )}
+
+                <FormField
+                  control={form.control}
+                  name="rando_cardrissian"
+                  render={({ field }) => (
+                    <FormItem className="flex flex-row items-center justify-between rounded-lg border border-slate-700 p-3">
+                      <div className="space-y-0.5">
+                        <FormLabel className="text-slate-300">Rando Cardrissian</FormLabel>
+                        <FormDescription className="text-slate-400">
+                          Add a bot that plays random cards every round. If it wins,
+                          everyone is shamed.
+                        </FormDescription>
+                      </div>
+                      <FormControl>
+                        <Switch
+                          checked={field.value}
+                          onCheckedChange={field.onChange}
+                        />
+                      </FormControl>
+                    </FormItem>
+                  )}
+                />
+
+                <FormField
+                  control={form.control}
+                  name="rebooting_the_universe"
+                  render={({ field }) => (
+                    <FormItem className="flex flex-row items-center justify-between rounded-lg border border-slate-700 p-3">
+                      <div className="space-y-0.5">
+                        <FormLabel className="text-slate-300">Rebooting the Universe</FormLabel>
+                        <FormDescription className="text-slate-400">
+                          Let players trade in a point to discard their hand and
+                          draw a new one.
+                        </FormDescription>
+                      </div>
+                      <FormControl>
+                        <Switch
+                          checked={field.value}
+                          onCheckedChange={field.onChange}
+                        />
+                      </FormControl>
+                    </FormItem>
+                  )}
+                />
+
+                <FormField
+                  control={form.control}
+                  name="mulligan"
+                  render={({ field }) => (
+                    <FormItem className="flex flex-row items-center justify-between rounded-lg border border-slate-700 p-3">
+                      <div className="space-y-0.5">
+                        <FormLabel className="text-slate-300">Mulligan</FormLabel>
+                        <FormDescription className="text-slate-400">
+                          Let every player redraw their hand once per game for
+                          free.
+                        </FormDescription>
+                      </div>
+                      <FormControl>
+                        <Switch
+                          checked={field.value}
+                          onCheckedChange={field.onChange}
+                        />
+                      </FormControl>
+                    </FormItem>
+                  )}
+                />
+
                 {/* <FormField
             control={form.control}
             name="is_private"
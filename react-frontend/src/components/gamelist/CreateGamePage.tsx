@@ -15,8 +15,16 @@ import {
   FormMessage,
 } from "@/components/ui/form";
 import { Input } from "@/components/ui/input";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
 import { Slider } from "@/components/ui/slider";
 import { Spinner } from "@/components/ui/spinner";
+import { Switch } from "@/components/ui/switch";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { useMutation, useQueryClient } from "@tanstack/react-query";
 import api from "@/lib/axios";
@@ -37,7 +45,13 @@ const formSchema = z.object({
   subject: z.string().min(3, {
     message: "Collection name must be at least 3 characters.",
   }),
-  max_player_count: z.number().min(2).max(6),
+  max_player_count: z.number().min(3).max(6),
+  hand_size: z.number().min(5).max(15),
+  mode: z.enum(["Czar", "Democracy"]),
+  tie_rule: z.enum(["AllWin", "NoPoints", "Random"]),
+  rando_cardrissian: z.boolean().default(false).optional(),
+  rebooting_the_universe: z.boolean().default(false).optional(),
+  mulligan: z.boolean().default(false).optional(),
   winner_count: z.number().min(3).max(50),
   is_private: z.boolean().default(false).optional(),
   password: z.string().optional(),
@@ -54,6 +68,12 @@ export function CreateGamePage() {
       name: "",
       subject: "",
       max_player_count: 3,
+      hand_size: 10,
+      mode: "Czar",
+      tie_rule: "AllWin",
+      rando_cardrissian: false,
+      rebooting_the_universe: false,
+      mulligan: false,
       winner_count: 10,
       is_private: false,
       password: "",
@@ -66,7 +86,17 @@ export function CreateGamePage() {
         name: form.getValues("name"),
         subject: form.getValues("subject"),
         max_player_count: form.getValues("max_player_count"),
+        hand_size: form.getValues("hand_size"),
+        mode: form.getValues("mode"),
+        tie_rule: form.getValues("tie_rule"),
+        house_rules: {
+          rando_cardrissian: form.getValues("rando_cardrissian") ?? false,
+          rebooting_the_universe: form.getValues("rebooting_the_universe") ?? false,
+          mulligan: form.getValues("mulligan") ?? false,
+        },
         winner_count: form.getValues("winner_count"),
+        visibility: isPrivate ? "Private" : "Public",
+        password: isPrivate ? form.getValues("password") : "",
       });
       return response.data;
     },
@@ -171,11 +201,11 @@ export function CreateGamePage() {
                           Max Players: {field.value}
                         </FormLabel>
                         <FormDescription>
-                          Set the maximum number of players (2-6)
+                          Set the maximum number of players (3-6)
                         </FormDescription>
                         <FormControl>
                           <Slider
-                            min={2}
+                            min={3}
                             max={6}
                             step={1}
                             defaultValue={[field.value]}
@@ -188,6 +218,32 @@ export function CreateGamePage() {
                     )}
                   />
 
+                  <FormField
+                    control={form.control}
+                    name="hand_size"
+                    render={({ field }) => (
+                      <FormItem>
+                        <FormLabel>
+                          Hand Size: {field.value}
+                        </FormLabel>
+                        <FormDescription>
+                          Set how many white cards each player holds (5-15)
+                        </FormDescription>
+                        <FormControl>
+                          <Slider
+                            min={5}
+                            max={15}
+                            step={1}
+                            defaultValue={[field.value]}
+                            onValueChange={(vals) => field.onChange(vals[0])}
+                            className="py-4"
+                          />
+                        </FormControl>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+
                   <FormField
                     control={form.control}
                     name="winner_count"
@@ -214,6 +270,174 @@ export function CreateGamePage() {
                     )}
                   />
 
+                  <FormField
+                    control={form.control}
+                    name="mode"
+                    render={({ field }) => (
+                      <FormItem>
+                        <FormLabel>Game Mode</FormLabel>
+                        <FormDescription>
+                          Czar has a rotating judge pick the winner. In Democracy everyone
+                          votes for their favorite card instead.
+                        </FormDescription>
+                        <Select onValueChange={field.onChange} defaultValue={field.value}>
+                          <FormControl>
+                            <SelectTrigger>
+                              <SelectValue />
+                            </SelectTrigger>
+                          </FormControl>
+                          <SelectContent>
+                            <SelectItem value="Czar">Czar</SelectItem>
+                            <SelectItem value="Democracy">Democracy</SelectItem>
+                          </SelectContent>
+                        </Select>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+
+                  {form.watch("mode") === "Democracy" && (
+                    <FormField
+                      control={form.control}
+                      name="tie_rule"
+                      render={({ field }) => (
+                        <FormItem>
+                          <FormLabel>Ties</FormLabel>
+                          <FormDescription>
+                            Choose who scores when cards tie for the most votes
+                          </FormDescription>
+                          <Select onValueChange={field.onChange} defaultValue={field.value}>
+                            <FormControl>
+                              <SelectTrigger>
+                                <SelectValue />
+                              </SelectTrigger>
+                            </FormControl>
+                            <SelectContent>
+                              <SelectItem value="AllWin">Every tied card scores</SelectItem>
+                              <SelectItem value="NoPoints">Nobody scores</SelectItem>
+                              <SelectItem value="Random">One tied card at random</SelectItem>
+                            </SelectContent>
+                          </Select>
+                          <FormMessage />
+                        </FormItem>
+                      )}
+                    />
+                  )}
+
+                  <FormField
+                    control={form.control}
+                    name="rando_cardrissian"
+                    render={({ field }) => (
+                      <FormItem className="flex flex-row items-center justify-between rounded-lg border p-3">
+                        <div className="space-y-0.5">
+                          <FormLabel>Rando Cardrissian</FormLabel>
+                          <FormDescription>
+                            Add a bot that plays random cards every round. If it wins,
+                            everyone is shamed.
+                          </FormDescription>
+                        </div>
+                        <FormControl>
+                          <Switch
+                            checked={field.value}
+                            onCheckedChange={field.onChange}
+                          />
+                        </FormControl>
+                      </FormItem>
+                    )}
+                  />
+
+                  <FormField
+                    control={form.control}
+                    name="rebooting_the_universe"
+                    render={({ field }) => (
+                      <FormItem className="flex flex-row items-center justify-between rounded-lg border p-3">
+                        <div className="space-y-0.5">
+                          <FormLabel>Rebooting the Universe</FormLabel>
+                          <FormDescription>
+                            Let players trade in a point to discard their hand and
+                            draw a new one.
+                          </FormDescription>
+                        </div>
+                        <FormControl>
+                          <Switch
+                            checked={field.value}
+                            onCheckedChange={field.onChange}
+                          />
+                        </FormControl>
+                      </FormItem>
+                    )}
+                  />
+
+                  <FormField
+                    control={form.control}
+                    name="mulligan"
+                    render={({ field }) => (
+                      <FormItem className="flex flex-row items-center justify-between rounded-lg border p-3">
+                        <div className="space-y-0.5">
+                          <FormLabel>Mulligan</FormLabel>
+                          <FormDescription>
+                            Let every player redraw their hand once per game for
+                            free.
+                          </FormDescription>
+                        </div>
+                        <FormControl>
+                          <Switch
+                            checked={field.value}
+                            onCheckedChange={field.onChange}
+                          />
+                        </FormControl>
+                      </FormItem>
+                    )}
+                  />
+
+                  <FormField
+                    control={form.control}
+                    name="is_private"
+                    render={({ field }) => (
+                      <FormItem className="flex flex-row items-center justify-between rounded-lg border p-3">
+                        <div className="space-y-0.5">
+                          <FormLabel>Private game</FormLabel>
+                          <FormDescription>
+                            Hide the game from the list. Players join with its
+                            invite code instead.
+                          </FormDescription>
+                        </div>
+                        <FormControl>
+                          <Switch
+                            checked={field.value}
+                            onCheckedChange={(checked) => {
+                              field.onChange(checked);
+                              setIsPrivate(checked);
+                            }}
+                          />
+                        </FormControl>
+                      </FormItem>
+                    )}
+                  />
+
+                  {isPrivate && (
+                    <FormField
+                      control={form.control}
+                      name="password"
+                      render={({ field }) => (
+                        <FormItem>
+                          <FormLabel>Password</FormLabel>
+                          <FormControl>
+                            <Input
+                              type="password"
+                              placeholder="Optional"
+                              {...field}
+                            />
+                          </FormControl>
+                          <FormDescription>
+                            Players will need it as well as the invite code.
+                          </FormDescription>
+                          <FormMessage />
+                        </FormItem>
+                      )}
+                    />
+                  )}
+
                   <div className="flex gap-4 pt-4">
                     <Button
                       type="button"
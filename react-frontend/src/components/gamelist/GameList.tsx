@@ -15,6 +15,7 @@ import api from "@/lib/axios";
 import {
   CircleArrowRight,
   LoaderIcon,
+  Lock,
   Plus,
   RefreshCw,
   Sparkles,
@@ -25,6 +26,7 @@ import {
 import { useNavigate } from "react-router";
 import { SiteHeader } from "../site-header";
 import { Separator } from "../ui/separator";
+import { JoinWithInviteCode } from "./JoinWithInviteCode";
 import { NoGamesPlaceholder } from "./NoGamesPlaceholder";
 import Timer from "../game/Timer";
 
@@ -117,6 +119,10 @@ export default function GameLobby() {
           </CardContent>
         </Card>
 
+        <div className="mt-8">
+          <JoinWithInviteCode />
+        </div>
+
         <div className="flex flex-col md:flex-row gap-8 mb-16 items-start">
           {/* Online Players Section */}
           {/* <div className="w-full md:w-1/3">
@@ -190,6 +196,9 @@ function Games(props: { games: Game[] }) {
             <div className="flex justify-between items-center">
               <div className="flex gap-2">
                 <h3 className="font-semibold">Game: {game.name}</h3>
+                {game.visibility === "Private" && (
+                  <Lock className="h-4 w-4 text-zinc-500" aria-label="Private game" />
+                )}
               </div>
 
               <Button
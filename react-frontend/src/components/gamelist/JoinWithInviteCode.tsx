@@ -0,0 +1,110 @@
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import api from "@/lib/axios";
+import { useMutation } from "@tanstack/react-query";
+import { KeyRound, Lock } from "lucide-react";
+import { useState } from "react";
+import { useNavigate } from "react-router";
+
+// Private games aren't listed, so they are found by their invite code first.
+// Games with a password ask for it before letting the player in.
+export function JoinWithInviteCode() {
+  const navigate = useNavigate();
+  const [code, setCode] = useState("");
+  const [password, setPassword] = useState("");
+  const [game, setGame] = useState<Game | null>(null);
+
+  const resolve = useMutation({
+    mutationFn: async () => {
+      const response = await api.get(`/games/invite/${encodeURIComponent(code.trim())}`);
+      return response.data as Game;
+    },
+    onSuccess: (data) => {
+      if (!data.has_password) {
+        join.mutate();
+        return;
+      }
+
+      setGame(data);
+    },
+  });
+
+  const join = useMutation({
+    mutationFn: async () => {
+      const response = await api.post(`/games/invite/${encodeURIComponent(code.trim())}`, {
+        password,
+      });
+      return response.data as Game;
+    },
+    onSuccess: (data) => {
+      navigate(`/games/${data.id}`);
+    },
+  });
+
+  const error = resolve.error ?? join.error;
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault();
+
+    if (game) {
+      join.mutate();
+    } else {
+      resolve.mutate();
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <KeyRound className="h-5 w-5" />
+          Join a private game
+        </CardTitle>
+        <CardDescription>
+          Enter the invite code you were given, such as BRAVE-OTTER-420917.
+        </CardDescription>
+      </CardHeader>
+      <CardContent>
+        <form onSubmit={handleSubmit} className="flex flex-col gap-3 md:flex-row">
+          <Input
+            value={code}
+            onChange={(e) => {
+              setCode(e.target.value);
+              setGame(null);
+            }}
+            placeholder="Invite code"
+            className="uppercase"
+          />
+          {game && (
+            <Input
+              type="password"
+              value={password}
+              onChange={(e) => setPassword(e.target.value)}
+              placeholder={`Password for ${game.name}`}
+              autoFocus
+            />
+          )}
+          <Button
+            type="submit"
+            disabled={!code.trim() || resolve.isPending || join.isPending}
+          >
+            {game && <Lock className="h-4 w-4" />}
+            {game ? "Join game" : "Find game"}
+          </Button>
+        </form>
+        {error && (
+          <p className="text-sm text-red-500 mt-2">
+            {(error as any).response?.data?.message ?? "Could not join the game."}
+          </p>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
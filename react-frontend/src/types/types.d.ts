@@ -4,10 +4,32 @@ type RoundStatus =
   | "Waiting"
   | "PlayersPickingCard"
   | "JudgePickingWinningCard"
+  | "PlayersVoting"
   | "JudgeChoseWinningCard";
 
 type GameStatus = "Setup" | "InProgress" | "Finished";
 
+type GameMode = "Czar" | "Democracy";
+
+type TieRule = "AllWin" | "NoPoints" | "Random";
+
+type PlayerRole = "Participant" | "Owner" | "Spectator";
+
+type ModerationAction =
+  | "PlayerKicked"
+  | "PlayerBanned"
+  | "PlayerMuted"
+  | "PlayerUnmuted"
+  | "SpectatorPromoted";
+
+type GameVisibility = "Public" | "Private";
+
+type OwnerAction =
+  | "GamePaused"
+  | "GameResumed"
+  | "RoundForceAdvanced"
+  | "InviteCodeRotated";
+
 interface User {
   name: string;
   email: string;
@@ -25,6 +47,14 @@ interface Card {
   id: string;
   type: CardType;
   card_value: string;
+  pick?: number; // Black cards only, how many white cards answer it
+}
+
+interface Submission {
+  id: string;
+  cards: Card[]; // In the order they fill the blanks
+  user_id?: string; // Only set once the judge has picked
+  votes?: number; // Only set once the votes are counted
 }
 
 interface Collection {
@@ -34,32 +64,87 @@ interface Collection {
 interface Player {
   score: number;
   role: PlayerRole;
+  is_owner: boolean;
   user_id: string;
   name: string;
   image?: string; // Optional image field
-  deck: Card[]; // Array of cards in the player's deck
+  deck: Card[]; // Only populated for the current user
+  deck_size: number;
   is_judge: boolean;
   was_judge: boolean;
-  placed_card?: Card | null; // Nullable placed card
+  placed_cards: Card[]; // Hidden for other players until the judge has picked
+  has_placed_card: boolean;
   is_round_winner: boolean;
   is_game_winner: boolean;
+  is_shamed: boolean; // Lost the game to Rando Cardrissian
+  is_bot: boolean;
+  has_mulliganed: boolean;
+  has_voted: boolean;
+  is_disconnected: boolean; // Keeps their seat for a grace period before being removed
+  is_muted: boolean;
+  is_promoted: boolean; // A spectator who takes the next free seat
+  is_ready: boolean; // Only used in setup, the owner is ready by beginning the game
+}
+
+interface HouseRules {
+  rando_cardrissian: boolean;
+  rebooting_the_universe: boolean;
+  mulligan: boolean;
+}
+
+// Timeouts in seconds. Left out means the server's timeout, 0 means untimed.
+interface GameTimers {
+  picking_seconds?: number;
+  judging_seconds?: number;
+  round_pause_seconds?: number;
+}
+
+// A change to a game's settings in setup. Settings left out stay as they are.
+interface GameSettingsUpdate {
+  name?: string;
+  winner_count?: number;
+  max_player_count?: number;
+  hand_size?: number;
+  timers?: GameTimers;
+  house_rules?: HouseRules;
+  visibility?: GameVisibility;
+  auto_start?: boolean;
+  password?: string; // An empty password removes it
 }
 
 interface Game {
   id: string;
   name: string;
-  collection: Collection;
+  collection_size: number;
   winner_count: number;
   max_player_count: number;
+  min_player_count: number;
+  hand_size: number;
+  mode: GameMode;
+  tie_rule: TieRule;
+  house_rules: HouseRules;
+  timers: GameTimers;
+  auto_start: boolean; // Begins on its own once everyone is ready
+  visibility: GameVisibility;
+  invite_code?: string; // Only sent to those who can already get into a private game
+  has_password: boolean;
   status: GameStatus;
   players: Player[];
-  white_cards: Card[];
+  spectators: Player[]; // Never dealt cards or made judge
+  submissions: Submission[]; // Empty while players are still picking
+  submission_count: number;
+  vote_count: number;
+  winning_submission_id: string;
+  winning_submission_ids: string[]; // More than one after a tied vote
   black_card: Card | null;
   round_status: RoundStatus;
   round_winner: Player;
   current_game_round: number;
   last_vacated_at: Date | null;
   next_auto_progress_at: string | null; // ISO timestamp when next auto-progress will happen
+  is_paused: boolean;
+  paused_at: string; // Zero time unless paused
+  rematch_game_id: string; // Set once the owner starts a rematch of a finished game
   vacated: boolean;
   created_at: Date;
   updated_at: Date;